@@ -0,0 +1,255 @@
+// Command catalog-import loads a product dataset into the barcode catalog.
+//
+// The input is a delimited file with a header row, such as the Open Food Facts
+// CSV export (tab-separated, optionally gzipped). Columns are found by name:
+// code or gtin, product_name or name, brands or brand, category and quantity.
+// Rows with an invalid barcode or no name are skipped; products that are
+// already in the catalog are replaced.
+//
+//	go run ./cmd/catalog-import -file en.openfoodfacts.org.products.csv.gz
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/config"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/gtin"
+	"github.com/PocketPalCo/shopping-service/pkg/itemparser"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// columns lists the header names each field is read from, in order of preference.
+var columns = map[string][]string{
+	"gtin":     {"gtin", "code", "barcode"},
+	"name":     {"name", "product_name"},
+	"brand":    {"brand", "brands"},
+	"category": {"category"},
+	"quantity": {"quantity"},
+}
+
+func main() {
+	file := flag.String("file", "", "product dataset to import; a .gz file is decompressed")
+	delimiter := flag.String("delimiter", "\t", "field delimiter")
+	batchSize := flag.Int("batch", 1000, "products written per statement")
+	flag.Parse()
+
+	if *file == "" || len(*delimiter) != 1 || *batchSize < 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		slog.Error("failed to load config", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+
+	conn, err := postgres.Init(&cfg)
+	if err != nil {
+		slog.Error("failed to connect to database", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	defer conn.Close()
+
+	dictionary := categories.Default()
+	if cfg.CategoryDictionary != "" {
+		if dictionary, err = categories.Load(cfg.CategoryDictionary); err != nil {
+			slog.Error("failed to load category dictionary", slog.String("error", err.Error()))
+			os.Exit(1)
+		}
+	}
+
+	importer := &importer{
+		storage:    repository.NewProductStorageRepo(conn),
+		dictionary: dictionary,
+		batchSize:  *batchSize,
+	}
+	if err := importer.run(context.Background(), *file, rune((*delimiter)[0])); err != nil {
+		slog.Error("catalog import failed", slog.String("error", err.Error()),
+			slog.Int("imported", importer.imported), slog.Int("skipped", importer.skipped))
+		os.Exit(1)
+	}
+
+	slog.Info("catalog imported", slog.Int("imported", importer.imported), slog.Int("skipped", importer.skipped))
+}
+
+type importer struct {
+	storage    *repository.ProductStorageRepo
+	dictionary *categories.Dictionary
+	batchSize  int
+
+	imported int
+	skipped  int
+}
+
+func (im *importer) run(ctx context.Context, path string, delimiter rune) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var input io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		input = gz
+	}
+
+	reader := csv.NewReader(input)
+	reader.Comma = delimiter
+	reader.LazyQuotes = true
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+	index := columnIndex(header)
+	if _, ok := index["gtin"]; !ok {
+		return errors.New("no gtin or code column")
+	}
+	if _, ok := index["name"]; !ok {
+		return errors.New("no name or product_name column")
+	}
+
+	// batch holds one product per GTIN: a statement cannot update a row twice.
+	batch := make(map[string]shopping_list.Product, im.batchSize)
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			im.skipped++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		product, ok := im.product(record, index)
+		if !ok {
+			im.skipped++
+			continue
+		}
+		batch[product.GTIN] = product
+		if len(batch) == im.batchSize {
+			if err := im.flush(ctx, batch); err != nil {
+				return err
+			}
+		}
+	}
+
+	return im.flush(ctx, batch)
+}
+
+// product reads a record, reporting false when it has no valid barcode or name.
+func (im *importer) product(record []string, index map[string]int) (shopping_list.Product, bool) {
+	field := func(name string) string {
+		i, ok := index[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	code, err := gtin.Normalize(field("gtin"))
+	if err != nil {
+		return shopping_list.Product{}, false
+	}
+	name := field("name")
+	if name == "" {
+		return shopping_list.Product{}, false
+	}
+
+	category := field("category")
+	if _, ok := im.dictionary.Category(category); !ok {
+		category = im.dictionary.Categorize(name)
+	}
+	brand, _, _ := strings.Cut(field("brand"), ",")
+
+	return shopping_list.Product{
+		GTIN:     code,
+		Name:     name,
+		Brand:    strings.TrimSpace(brand),
+		Category: category,
+		Quantity: packSize(field("quantity")),
+	}, true
+}
+
+func (im *importer) flush(ctx context.Context, batch map[string]shopping_list.Product) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	products := make([]shopping_list.Product, 0, len(batch))
+	for _, product := range batch {
+		products = append(products, product)
+	}
+	if err := im.storage.ImportProducts(ctx, products, time.Now()); err != nil {
+		return err
+	}
+
+	im.imported += len(products)
+	clear(batch)
+	slog.Info("imported products", slog.Int("imported", im.imported))
+	return nil
+}
+
+// packSize reads a product's declared size, such as "500 g" or "1,5 L", with
+// the item parser. Only a weight or volume is taken; anything else, including
+// multipacks like "6 x 330 ml", makes one scan add one piece.
+func packSize(size string) quantity.Quantity {
+	if size == "" {
+		return quantity.One
+	}
+
+	parsed, err := itemparser.Parse(size + " product")
+	if err != nil || parsed.Name != "product" {
+		return quantity.One
+	}
+	switch parsed.Quantity.Unit.Dimension() {
+	case quantity.Mass, quantity.Volume:
+		if !strings.ContainsAny(strings.ToLower(size), "x×") {
+			return parsed.Quantity
+		}
+	}
+	return quantity.One
+}
+
+func columnIndex(header []string) map[string]int {
+	positions := make(map[string]int, len(header))
+	for i, column := range header {
+		positions[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	index := make(map[string]int, len(columns))
+	for field, names := range columns {
+		for _, name := range names {
+			if i, ok := positions[name]; ok {
+				index[field] = i
+				break
+			}
+		}
+	}
+	return index
+}
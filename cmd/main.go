@@ -19,6 +19,10 @@ func main() {
 		slog.Error("failed to load config", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
+	if err = cfg.Validate(); err != nil {
+		slog.Error("invalid config", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
 
 	defaultLogger := logger.NewLogger(&cfg)
 	slog.SetDefault(defaultLogger)
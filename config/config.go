@@ -6,12 +6,37 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/viper"
 )
 
+// DefaultInviteSecret signs invites when SSV_INVITE_SECRET is not set. Anyone
+// reading this file can forge invites with it, so it is only accepted in
+// localEnvironments.
+const DefaultInviteSecret = "local-invite-secret"
+
+// localEnvironments are the values of SSV_ENVIRONMENT that run on a
+// developer's machine rather than serving real users.
+var localEnvironments = []string{"local", "dev", "development"}
+
+// ErrInviteSecret is returned by Validate when invites would be signed with a
+// key that is empty or public.
+var ErrInviteSecret = errors.New("SSV_INVITE_SECRET must be set to a private key outside local environments")
+
+// ErrSchedulerInterval is returned by Validate when background jobs would
+// have no time between their runs.
+var ErrSchedulerInterval = errors.New("SSV_SCHEDULER_INTERVAL must be a positive number of seconds")
+
+// ErrTombstoneRetention and ErrTrashRetention are returned by Validate when
+// deletions would be purged as soon as they are made.
+var (
+	ErrTombstoneRetention = errors.New("SSV_TOMBSTONE_RETENTION must be a positive number of days")
+	ErrTrashRetention     = errors.New("SSV_TRASH_RETENTION must be a positive number of days")
+)
+
 type Config struct {
 	Environment       string     `mapstructure:"SSV_ENVIRONMENT"`
 	ServerName        string     `mapstructure:"SSV_SERVER_NAME"`
@@ -42,6 +67,27 @@ type Config struct {
 
 	OtlpEndpoint   string `mapstructure:"SSV_OTLP_ENDPOINT"`
 	JaegerEndpoint string `mapstructure:"SSV_JAEGER_ENDPOINT"`
+
+	// Invites
+	InviteSecret string `mapstructure:"SSV_INVITE_SECRET"` // HMAC key for invite tokens
+
+	// Categories
+	CategoryDictionary string `mapstructure:"SSV_CATEGORY_DICTIONARY"` // JSON keyword dictionary; empty uses the built-in one
+
+	// Background jobs
+	SchedulerInterval int `mapstructure:"SSV_SCHEDULER_INTERVAL"` // seconds between recurring template runs
+
+	// Sync
+	TombstoneRetention int `mapstructure:"SSV_TOMBSTONE_RETENTION"` // days deletions are kept for clients to catch up on
+
+	// Claims
+	ClaimExpiry int `mapstructure:"SSV_CLAIM_EXPIRY"` // seconds until an item claim expires unless the claim says otherwise
+
+	// Trash
+	TrashRetention int `mapstructure:"SSV_TRASH_RETENTION"` // days deleted lists and items stay restorable
+
+	// Duplicates
+	DuplicateThreshold float64 `mapstructure:"SSV_DUPLICATE_THRESHOLD"` // name similarity from 0 to 1 at which an added item is flagged as a likely duplicate
 }
 
 // DefaultConfig generates a config with sane defaults.
@@ -76,6 +122,24 @@ func DefaultConfig() Config {
 
 		OtlpEndpoint:   "localhost:4317",
 		JaegerEndpoint: "http://localhost:14268/api/traces",
+
+		// Invites
+		InviteSecret: DefaultInviteSecret,
+
+		// Background jobs
+		SchedulerInterval: 60,
+
+		// Sync
+		TombstoneRetention: 30,
+
+		// Claims
+		ClaimExpiry: 4 * 60 * 60,
+
+		// Trash
+		TrashRetention: 30,
+
+		// Duplicates
+		DuplicateThreshold: 0.5,
 	}
 }
 
@@ -124,6 +188,13 @@ func ConfigFromEnvironment() (config Config, err error) {
 	viper.SetDefault("SSV_REDIS_USER", config.RedisUser)
 	viper.SetDefault("SSV_REDIS_PASS", config.RedisPass)
 	viper.SetDefault("SSV_REDIS_DB", config.RedisDb)
+	viper.SetDefault("SSV_INVITE_SECRET", config.InviteSecret)
+	viper.SetDefault("SSV_CATEGORY_DICTIONARY", config.CategoryDictionary)
+	viper.SetDefault("SSV_SCHEDULER_INTERVAL", config.SchedulerInterval)
+	viper.SetDefault("SSV_TOMBSTONE_RETENTION", config.TombstoneRetention)
+	viper.SetDefault("SSV_CLAIM_EXPIRY", config.ClaimExpiry)
+	viper.SetDefault("SSV_TRASH_RETENTION", config.TrashRetention)
+	viper.SetDefault("SSV_DUPLICATE_THRESHOLD", config.DuplicateThreshold)
 
 	// Override config values with environment variables
 	viper.AutomaticEnv()
@@ -153,6 +224,23 @@ func ConfigFromFile(f string) (config Config, err error) {
 	return
 }
 
+// Validate reports settings the service must not start with.
+func (c Config) Validate() error {
+	if c.InviteSecret == "" || (c.InviteSecret == DefaultInviteSecret && !slices.Contains(localEnvironments, c.Environment)) {
+		return ErrInviteSecret
+	}
+	if c.SchedulerInterval <= 0 {
+		return ErrSchedulerInterval
+	}
+	if c.TombstoneRetention <= 0 {
+		return ErrTombstoneRetention
+	}
+	if c.TrashRetention <= 0 {
+		return ErrTrashRetention
+	}
+	return nil
+}
+
 // Fiber initializes and returns a Fiber config based on server config values.
 // See https://docs.gofiber.io/api/fiber#config
 func (c Config) Fiber() fiber.Config {
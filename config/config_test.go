@@ -1,6 +1,9 @@
 package config
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestDbConnectionString(t *testing.T) {
 	cfg := Config{
@@ -18,3 +21,49 @@ func TestDbConnectionString(t *testing.T) {
 		t.Errorf("DbConnectionString() = %q, want %q", got, expected)
 	}
 }
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		environment string
+		secret      string
+		want        error
+	}{
+		{"local", DefaultInviteSecret, nil},
+		{"dev", DefaultInviteSecret, nil},
+		{"production", DefaultInviteSecret, ErrInviteSecret},
+		{"", DefaultInviteSecret, ErrInviteSecret},
+		{"local", "", ErrInviteSecret},
+		{"production", "", ErrInviteSecret},
+		{"production", "a-private-key", nil},
+	}
+
+	for _, tt := range tests {
+		cfg := DefaultConfig()
+		cfg.Environment, cfg.InviteSecret = tt.environment, tt.secret
+		if err := cfg.Validate(); !errors.Is(err, tt.want) {
+			t.Errorf("Validate() with environment %q and secret %q = %v, want %v", tt.environment, tt.secret, err, tt.want)
+		}
+	}
+}
+
+func TestValidateDurations(t *testing.T) {
+	tests := []struct {
+		name string
+		set  func(cfg *Config, value int)
+		want error
+	}{
+		{"scheduler interval", func(cfg *Config, value int) { cfg.SchedulerInterval = value }, ErrSchedulerInterval},
+		{"tombstone retention", func(cfg *Config, value int) { cfg.TombstoneRetention = value }, ErrTombstoneRetention},
+		{"trash retention", func(cfg *Config, value int) { cfg.TrashRetention = value }, ErrTrashRetention},
+	}
+
+	for _, tt := range tests {
+		for _, value := range []int{0, -1} {
+			cfg := DefaultConfig()
+			tt.set(&cfg, value)
+			if err := cfg.Validate(); !errors.Is(err, tt.want) {
+				t.Errorf("Validate() with a %s of %d = %v, want %v", tt.name, value, err, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,283 @@
+package shopping_list
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+// BatchService applies many changes to a list's items at once, such as a
+// pasted list, for editors.
+type BatchService struct {
+	lists *ListService
+}
+
+func NewBatchService(lists *ListService) *BatchService {
+	return &BatchService{
+		lists: lists,
+	}
+}
+
+// Apply applies the operations in order, each as the matching item route
+// would, and fails as a whole at the first one that fails, with an error
+// naming its index. Adds merge into unchecked items with the same name, on
+// the list or added earlier in the batch. So that large batches take few
+// round trips, the items adds may merge into and the categories the
+// household chose for their names are read up front, and the new items and
+// the list history are written together once every operation has been
+// applied.
+func (s *BatchService) Apply(ctx context.Context, actor, listID uuid.UUID, in BatchInput) (BatchResult, error) {
+	if len(in.Operations) == 0 || len(in.Operations) > MaxBatchSize {
+		return BatchResult{}, ErrInvalidBatch
+	}
+	entries := make(map[int]itemEntry)
+	var names []string
+	for i, op := range in.Operations {
+		entry, err := validateOperation(op)
+		if err != nil {
+			return BatchResult{}, fmt.Errorf("operation %d: %w", i, err)
+		}
+		if op.Op == BatchAdd {
+			entries[i] = entry
+			names = append(names, entry.name)
+		}
+	}
+
+	list, err := s.lists.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return BatchResult{}, err
+	}
+	b, err := s.start(ctx, actor, list, names)
+	if err != nil {
+		return BatchResult{}, err
+	}
+
+	result := BatchResult{ListID: listID, Results: make([]OperationResult, 0, len(in.Operations))}
+	for i, op := range in.Operations {
+		applied, err := b.apply(ctx, op, entries[i])
+		if err != nil {
+			return BatchResult{}, fmt.Errorf("operation %d: %w", i, err)
+		}
+		result.Results = append(result.Results, applied)
+	}
+
+	if err := b.flush(ctx); err != nil {
+		return BatchResult{}, err
+	}
+	return result, nil
+}
+
+// start prepares a batch for the list that adds items with the given names.
+func (s *BatchService) start(ctx context.Context, actor uuid.UUID, list List, names []string) (*batch, error) {
+	history := &pendingHistory{ListHistoryStorage: s.lists.history}
+	lists := *s.lists
+	lists.history = history
+	b := &batch{
+		lists:      &lists,
+		history:    history,
+		actor:      actor,
+		list:       list,
+		now:        time.Now(),
+		open:       make(map[string][]*Item),
+		categories: make(map[string]string),
+		position:   -1,
+	}
+	if len(names) == 0 {
+		return b, nil
+	}
+
+	for _, name := range names {
+		b.open[strings.ToLower(name)] = nil
+	}
+	open, err := s.lists.storage.OpenItemsByNames(ctx, list.ID, names)
+	if err != nil {
+		return nil, err
+	}
+	for i := range open {
+		key := strings.ToLower(open[i].Name)
+		b.open[key] = append(b.open[key], &open[i])
+	}
+	if b.categories, err = s.lists.categorizer.Recall(ctx, list.HouseholdID, names); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// validateOperation checks that the operation has what it needs and resolves
+// the item an add carries.
+func validateOperation(op BatchOperation) (itemEntry, error) {
+	switch op.Op {
+	case BatchAdd:
+		if op.Item == nil {
+			return itemEntry{}, ErrInvalidBatchOp
+		}
+		return resolveItem(*op.Item)
+	case BatchUpdate:
+		if op.ItemID == nil || op.Update == nil {
+			return itemEntry{}, ErrInvalidBatchOp
+		}
+	case BatchCheck, BatchUncheck, BatchDelete:
+		if op.ItemID == nil {
+			return itemEntry{}, ErrInvalidBatchOp
+		}
+	default:
+		return itemEntry{}, ErrInvalidBatchOp
+	}
+	return itemEntry{}, nil
+}
+
+// pendingHistory keeps the events a batch records until it is flushed; they
+// have no sequence number before that.
+type pendingHistory struct {
+	ListHistoryStorage
+	events []ListEvent
+}
+
+func (h *pendingHistory) AppendListEvent(_ context.Context, event ListEvent) (int64, error) {
+	h.events = append(h.events, event)
+	return 0, nil
+}
+
+// batch is a batch being applied to a list, through a copy of the list
+// service that records to its pending history. Open holds the unchecked
+// items, in list order, with each name the batch adds, lower-cased, and
+// categories what the household chose for those names, by normalized name.
+// Items the batch adds are kept in created until flush stores them.
+type batch struct {
+	lists      *ListService
+	history    *pendingHistory
+	actor      uuid.UUID
+	list       List
+	now        time.Time
+	open       map[string][]*Item
+	categories map[string]string
+	created    []*Item
+	position   int
+}
+
+func (b *batch) apply(ctx context.Context, op BatchOperation, entry itemEntry) (OperationResult, error) {
+	result := OperationResult{Op: op.Op}
+	var err error
+	switch op.Op {
+	case BatchAdd:
+		result.Item, result.Merged, err = b.add(ctx, entry)
+		return result, err
+	case BatchUpdate:
+		result.Item, err = b.lists.editItem(ctx, b.actor, b.list, *op.ItemID, *op.Update)
+	case BatchCheck, BatchUncheck:
+		result.Item, err = b.lists.checkItem(ctx, b.actor, b.list, *op.ItemID, op.Op == BatchCheck)
+	case BatchDelete:
+		result.Item, err = b.lists.removeItem(ctx, b.actor, b.list, *op.ItemID)
+	}
+	if err != nil {
+		return OperationResult{}, err
+	}
+	b.track(result.Item, op.Op != BatchDelete && !result.Item.Checked)
+	return result, nil
+}
+
+// add merges the entry into the first unchecked item with the same name and
+// a compatible unit, or else makes a new item at the end of the list.
+func (b *batch) add(ctx context.Context, entry itemEntry) (Item, bool, error) {
+	if entry.category != "" {
+		if err := b.lists.categorizer.Remember(ctx, b.list.HouseholdID, entry.name, entry.category); err != nil {
+			return Item{}, false, err
+		}
+		b.categories[categories.Normalize(entry.name)] = entry.category
+	}
+
+	key := strings.ToLower(entry.name)
+	for _, item := range b.open[key] {
+		merged, err := item.Quantity.Add(entry.quantity)
+		if errors.Is(err, quantity.ErrIncompatible) {
+			continue
+		}
+		if err != nil {
+			return Item{}, false, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+
+		before := *item
+		item.Quantity = merged
+		item.Note = mergeNotes(item.Note, entry.note)
+		item.UpdatedAt = b.now
+		if slices.Contains(b.created, item) {
+			after := *item
+			_, err = b.lists.record(ctx, b.actor, b.list, itemEvent(ItemEdited, &before, &after))
+		} else {
+			err = b.lists.saveItem(ctx, b.actor, b.list, before, *item)
+		}
+		if err != nil {
+			return Item{}, false, err
+		}
+		return *item, true, nil
+	}
+
+	if b.position < 0 {
+		var err error
+		if b.position, err = b.lists.storage.NextItemPosition(ctx, b.list.ID); err != nil {
+			return Item{}, false, err
+		}
+	}
+	if entry.category == "" {
+		entry.category = b.lists.categorizer.pick(b.categories[categories.Normalize(entry.name)], entry.name)
+	}
+	item, err := b.lists.newItem(ctx, b.list, entry, b.position, b.now)
+	if err != nil {
+		return Item{}, false, err
+	}
+	b.position++
+	b.created = append(b.created, &item)
+	b.open[key] = append(b.open[key], &item)
+
+	added := item
+	if _, err := b.lists.record(ctx, b.actor, b.list, itemEvent(ItemAdded, nil, &added)); err != nil {
+		return Item{}, false, err
+	}
+	return item, false, nil
+}
+
+// track keeps the open items in step with an operation on an item that was
+// already on the list: it is taken out of them, and put back under its
+// current name while it is still open.
+func (b *batch) track(item Item, open bool) {
+	for key, items := range b.open {
+		b.open[key] = slices.DeleteFunc(items, func(i *Item) bool {
+			return i.ID == item.ID
+		})
+	}
+
+	key := strings.ToLower(item.Name)
+	items, ok := b.open[key]
+	if !open || !ok {
+		return
+	}
+	items = append(items, &item)
+	slices.SortStableFunc(items, func(x, y *Item) int {
+		return cmp.Compare(x.Position, y.Position)
+	})
+	b.open[key] = items
+}
+
+// flush stores the items the batch added and the list history it recorded.
+func (b *batch) flush(ctx context.Context) error {
+	if len(b.created) > 0 {
+		items := make([]Item, 0, len(b.created))
+		for _, item := range b.created {
+			items = append(items, *item)
+		}
+		if err := b.lists.storage.CreateItems(ctx, items); err != nil {
+			return err
+		}
+	}
+	if len(b.history.events) == 0 {
+		return nil
+	}
+	return b.history.ListHistoryStorage.AppendListEvents(ctx, b.history.events)
+}
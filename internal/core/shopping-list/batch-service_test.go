@@ -0,0 +1,127 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"testing"
+)
+
+func TestBatchAppliesInOrder(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	recorded := len(h.history.events)
+
+	result, err := NewBatchService(h.service).Apply(ctx, h.editor, h.list.ID, BatchInput{Operations: []BatchOperation{
+		{Op: BatchAdd, Item: &NewItem{Name: "milk"}},
+		{Op: BatchAdd, Item: &NewItem{Name: "Eggs"}},
+		{Op: BatchAdd, Item: &NewItem{Text: "5 eggs"}},
+		{Op: BatchCheck, ItemID: &milk.ID},
+	}})
+	if err != nil {
+		t.Fatalf("Apply() = %v", err)
+	}
+
+	merged := []bool{true, false, true, false}
+	for i, r := range result.Results {
+		if r.Merged != merged[i] {
+			t.Errorf("operation %d merged = %v, want %v", i, r.Merged, merged[i])
+		}
+	}
+	eggs := result.Results[1].Item
+	if got := h.lists.items[eggs.ID]; got.Quantity.Value != 6 || got.Category != "dairy" {
+		t.Errorf("eggs = %+v, want 6 pieces in dairy", got)
+	}
+	if got := h.lists.items[milk.ID]; got.Quantity.Value != 2 || !got.Checked {
+		t.Errorf("milk = %+v, want 2 pieces checked off", got)
+	}
+
+	events := h.history.events[recorded:]
+	want := []ListEventType{ItemEdited, ItemAdded, ItemEdited, ItemChecked}
+	if len(events) != len(want) {
+		t.Fatalf("Apply() recorded %d events, want %d", len(events), len(want))
+	}
+	for i, event := range events {
+		if event.Type != want[i] || event.Seq != int64(recorded+i+1) {
+			t.Errorf("event %d = %s #%d, want %s #%d", i, event.Type, event.Seq, want[i], recorded+i+1)
+		}
+	}
+}
+
+// A batch that fails does so before anything it holds back is written: the
+// items it adds and the history it records. What its operations did write
+// through is undone by the transaction the route runs it in.
+func TestBatchFailsAsAWhole(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	missing := uuid.New()
+
+	tests := []struct {
+		name       string
+		operations []BatchOperation
+		index      string
+		want       error
+	}{
+		{"an invalid operation", []BatchOperation{
+			{Op: BatchCheck, ItemID: &milk.ID},
+			{Op: "archive", ItemID: &milk.ID},
+		}, "operation 1", ErrInvalidBatchOp},
+		{"an invalid item", []BatchOperation{
+			{Op: BatchAdd, Item: &NewItem{Name: "Eggs"}},
+			{Op: BatchAdd, Item: &NewItem{Name: " "}},
+		}, "operation 1", ErrEmptyName},
+		{"a missing item", []BatchOperation{
+			{Op: BatchAdd, Item: &NewItem{Name: "Eggs"}},
+			{Op: BatchCheck, ItemID: &milk.ID},
+			{Op: BatchDelete, ItemID: &missing},
+		}, "operation 2", ErrItemNotFound},
+	}
+
+	for _, tt := range tests {
+		created, recorded := h.lists.created, len(h.history.events)
+
+		_, err := NewBatchService(h.service).Apply(ctx, h.editor, h.list.ID, BatchInput{Operations: tt.operations})
+		if !errors.Is(err, tt.want) || !strings.HasPrefix(err.Error(), tt.index+":") {
+			t.Errorf("Apply() with %s = %v, want %s: %v", tt.name, err, tt.index, tt.want)
+		}
+		if h.lists.created != created {
+			t.Errorf("Apply() with %s stored %d items", tt.name, h.lists.created-created)
+		}
+		if len(h.history.events) != recorded {
+			t.Errorf("Apply() with %s recorded %d events", tt.name, len(h.history.events)-recorded)
+		}
+	}
+}
+
+// Operations are only applied once every one of them is valid.
+func TestBatchValidatesBeforeApplying(t *testing.T) {
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+
+	_, err := NewBatchService(h.service).Apply(context.Background(), h.editor, h.list.ID, BatchInput{Operations: []BatchOperation{
+		{Op: BatchCheck, ItemID: &milk.ID},
+		{Op: BatchUpdate, ItemID: &milk.ID},
+	}})
+	if !errors.Is(err, ErrInvalidBatchOp) {
+		t.Errorf("Apply() with an update that carries no changes = %v, want ErrInvalidBatchOp", err)
+	}
+	if h.lists.items[milk.ID].Checked {
+		t.Error("Apply() checked the item off before finding the batch invalid")
+	}
+}
+
+func TestBatchAuthorization(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	in := BatchInput{Operations: []BatchOperation{{Op: BatchAdd, Item: &NewItem{Name: "Eggs"}}}}
+
+	if _, err := NewBatchService(h.service).Apply(ctx, h.viewer, h.list.ID, in); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Apply() by a viewer = %v, want ErrForbidden", err)
+	}
+	if _, err := NewBatchService(h.service).Apply(ctx, h.editor, h.list.ID, BatchInput{}); !errors.Is(err, ErrInvalidBatch) {
+		t.Errorf("Apply() without operations = %v, want ErrInvalidBatch", err)
+	}
+}
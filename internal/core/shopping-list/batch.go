@@ -0,0 +1,46 @@
+package shopping_list
+
+import "github.com/google/uuid"
+
+// MaxBatchSize is the most operations a batch can have.
+const MaxBatchSize = 500
+
+type BatchOp string
+
+const (
+	BatchAdd     BatchOp = "add"
+	BatchUpdate  BatchOp = "update"
+	BatchCheck   BatchOp = "check"
+	BatchUncheck BatchOp = "uncheck"
+	BatchDelete  BatchOp = "delete"
+)
+
+// BatchOperation is one change in a batch: an add carries the Item to add,
+// every other operation the ItemID of an item already on the list, and an
+// update the Update to make.
+type BatchOperation struct {
+	Op     BatchOp     `json:"op"`
+	ItemID *uuid.UUID  `json:"item_id"`
+	Item   *NewItem    `json:"item"`
+	Update *ItemUpdate `json:"update"`
+}
+
+// BatchInput is a batch of operations, applied in order.
+type BatchInput struct {
+	Operations []BatchOperation `json:"operations"`
+}
+
+// OperationResult is what an operation did. Item is the item as the operation
+// left it, or as it was when deleted; Merged is set for an add whose amount
+// went to an unchecked item with the same name instead.
+type OperationResult struct {
+	Op     BatchOp `json:"op"`
+	Item   Item    `json:"item"`
+	Merged bool    `json:"merged,omitempty"`
+}
+
+// BatchResult has the result of each of a batch's operations, in order.
+type BatchResult struct {
+	ListID  uuid.UUID         `json:"list_id"`
+	Results []OperationResult `json:"results"`
+}
@@ -0,0 +1,215 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/google/uuid"
+	"slices"
+	"time"
+)
+
+// BudgetStorage persists household budgets.
+type BudgetStorage interface {
+	HouseholdBudgets(ctx context.Context, householdID uuid.UUID) ([]Budget, error)
+	GetBudget(ctx context.Context, id uuid.UUID) (Budget, error)
+	CreateBudget(ctx context.Context, budget Budget) error
+	UpdateBudget(ctx context.Context, budget Budget) error
+	DeleteBudget(ctx context.Context, id uuid.UUID) error
+}
+
+// BudgetService manages household budgets and tracks spending against them.
+// Members can view budgets, editors can set and change them and owners can
+// delete them. Spending is the sum of the prices recorded in a period and of
+// what trips finished in it cost beyond their items' prices.
+type BudgetService struct {
+	storage    BudgetStorage
+	prices     PriceStorage
+	households HouseholdStorage
+	dictionary *categories.Dictionary
+	publisher  EventPublisher
+}
+
+func NewBudgetService(storage BudgetStorage, prices PriceStorage, households HouseholdStorage, dictionary *categories.Dictionary, publisher EventPublisher) *BudgetService {
+	return &BudgetService{
+		storage:    storage,
+		prices:     prices,
+		households: households,
+		dictionary: dictionary,
+		publisher:  publisher,
+	}
+}
+
+func (s *BudgetService) authorize(ctx context.Context, actor, budgetID uuid.UUID, required Role) (Budget, error) {
+	budget, err := s.storage.GetBudget(ctx, budgetID)
+	if err != nil {
+		return Budget{}, err
+	}
+	if err := requireRole(ctx, s.households, budget.HouseholdID, actor, required); err != nil {
+		return Budget{}, err
+	}
+	return budget, nil
+}
+
+func (s *BudgetService) Budgets(ctx context.Context, actor, householdID uuid.UUID) ([]Budget, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.HouseholdBudgets(ctx, householdID)
+}
+
+// SetBudget creates a budget. A household has at most one budget per period
+// and category.
+func (s *BudgetService) SetBudget(ctx context.Context, actor, householdID uuid.UUID, in BudgetInput) (Budget, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return Budget{}, err
+	}
+
+	now := time.Now()
+	budget := Budget{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		CreatedAt:   now,
+	}
+	if err := s.fill(ctx, &budget, in, now); err != nil {
+		return Budget{}, err
+	}
+	if err := s.storage.CreateBudget(ctx, budget); err != nil {
+		return Budget{}, err
+	}
+
+	return budget, nil
+}
+
+func (s *BudgetService) UpdateBudget(ctx context.Context, actor, id uuid.UUID, in BudgetInput) (Budget, error) {
+	budget, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return Budget{}, err
+	}
+
+	if err := s.fill(ctx, &budget, in, time.Now()); err != nil {
+		return Budget{}, err
+	}
+	if err := s.storage.UpdateBudget(ctx, budget); err != nil {
+		return Budget{}, err
+	}
+
+	return budget, nil
+}
+
+func (s *BudgetService) DeleteBudget(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleOwner); err != nil {
+		return err
+	}
+	return s.storage.DeleteBudget(ctx, id)
+}
+
+// Summary reports how each of the household's budgets stands in its current period.
+func (s *BudgetService) Summary(ctx context.Context, actor, householdID uuid.UUID) ([]BudgetSummary, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	budgets, err := s.storage.HouseholdBudgets(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	summaries := make([]BudgetSummary, 0, len(budgets))
+	for _, budget := range budgets {
+		start, end := budget.Period.Bounds(now)
+		spent, err := s.prices.Spend(ctx, householdID, budget.Category, budget.Currency, start, end)
+		if err != nil {
+			return nil, err
+		}
+		summaries = append(summaries, budget.summarize(spent, now))
+	}
+
+	return summaries, nil
+}
+
+// recordSpend alerts the household's members about every threshold of a
+// current budget that the newly recorded price pushed spending past. Prices
+// paid in an earlier period do not alert, since that period is over.
+func (s *BudgetService) recordSpend(ctx context.Context, actor uuid.UUID, price Price) error {
+	budgets, err := s.storage.HouseholdBudgets(ctx, price.HouseholdID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, budget := range budgets {
+		start, end := budget.Period.Bounds(now)
+		if !budget.covers(price) || price.PaidAt.Before(start) || !price.PaidAt.Before(end) {
+			continue
+		}
+
+		spent, err := s.prices.Spend(ctx, price.HouseholdID, budget.Category, budget.Currency, start, end)
+		if err != nil {
+			return err
+		}
+		for _, threshold := range budget.crossed(spent-price.Amount, spent) {
+			err := notifyHousehold(ctx, s.households, s.publisher, Event{
+				Type:        EventBudgetThreshold,
+				HouseholdID: price.HouseholdID,
+				ActorID:     actor,
+				Data:        BudgetAlert{Budget: budget, Threshold: threshold, Spent: spent},
+				OccurredAt:  now,
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fill validates the input and copies it onto the budget.
+func (s *BudgetService) fill(ctx context.Context, budget *Budget, in BudgetInput, now time.Time) error {
+	if !in.Period.Valid() {
+		return ErrInvalidPeriod
+	}
+	if in.Category != "" {
+		if _, ok := s.dictionary.Category(in.Category); !ok {
+			return fmt.Errorf("%w %q", ErrUnknownCategory, in.Category)
+		}
+	}
+	if in.Amount <= 0 {
+		return ErrInvalidBudget
+	}
+	currency, err := parseCurrency(in.Currency)
+	if err != nil {
+		return err
+	}
+
+	thresholds := DefaultThresholds
+	if len(in.Thresholds) > 0 {
+		thresholds = slices.Clone(in.Thresholds)
+		slices.Sort(thresholds)
+		thresholds = slices.Compact(thresholds)
+	}
+	if thresholds[0] <= 0 || thresholds[len(thresholds)-1] > 1000 {
+		return ErrInvalidThresholds
+	}
+
+	budgets, err := s.storage.HouseholdBudgets(ctx, budget.HouseholdID)
+	if err != nil {
+		return err
+	}
+	for _, other := range budgets {
+		if other.ID != budget.ID && other.Period == in.Period && other.Category == in.Category {
+			return ErrDuplicateBudget
+		}
+	}
+
+	budget.Category = in.Category
+	budget.Period = in.Period
+	budget.Amount = in.Amount
+	budget.Currency = currency
+	budget.Thresholds = thresholds
+	budget.UpdatedAt = now
+
+	return nil
+}
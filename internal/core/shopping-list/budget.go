@@ -0,0 +1,113 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Period is how often a budget starts over. Periods follow the calendar in
+// UTC: weeks start on Monday and months on the 1st.
+type Period string
+
+const (
+	PeriodWeekly  Period = "weekly"
+	PeriodMonthly Period = "monthly"
+)
+
+func (p Period) Valid() bool {
+	return p == PeriodWeekly || p == PeriodMonthly
+}
+
+// Bounds returns the start of the period t falls in and the start of the next one.
+func (p Period) Bounds(t time.Time) (time.Time, time.Time) {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if p == PeriodWeekly {
+		start := day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		return start, start.AddDate(0, 0, 7)
+	}
+	start := day.AddDate(0, 0, 1-day.Day())
+	return start, start.AddDate(0, 1, 0)
+}
+
+// DefaultThresholds are the percentages of a budget that alert members when
+// spending reaches them, unless the budget sets its own.
+var DefaultThresholds = []int{80, 100}
+
+// Budget caps what a household spends in a period, on everything or on one
+// category. Amount is in the currency's minor unit; only prices paid in the
+// budget's currency count towards it.
+type Budget struct {
+	ID          uuid.UUID `json:"id"`
+	HouseholdID uuid.UUID `json:"household_id"`
+	Category    string    `json:"category,omitempty"`
+	Period      Period    `json:"period"`
+	Amount      int64     `json:"amount"`
+	Currency    string    `json:"currency"`
+	Thresholds  []int     `json:"thresholds"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// BudgetInput is the input for setting a budget. Without a category the budget
+// covers all spending; without thresholds DefaultThresholds apply.
+type BudgetInput struct {
+	Category   string `json:"category"`
+	Period     Period `json:"period"`
+	Amount     int64  `json:"amount"`
+	Currency   string `json:"currency"`
+	Thresholds []int  `json:"thresholds"`
+}
+
+// BudgetSummary is how a budget stands in its current period. Projected is
+// what will have been spent by the end of the period at the rate so far.
+type BudgetSummary struct {
+	Budget      Budget    `json:"budget"`
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"`
+	Spent       int64     `json:"spent"`
+	Remaining   int64     `json:"remaining"`
+	Projected   int64     `json:"projected"`
+}
+
+// BudgetAlert is the data of a budget_threshold_reached event.
+type BudgetAlert struct {
+	Budget    Budget `json:"budget"`
+	Threshold int    `json:"threshold"`
+	Spent     int64  `json:"spent"`
+}
+
+// covers reports whether a price counts towards the budget.
+func (b Budget) covers(price Price) bool {
+	return price.Currency == b.Currency && (b.Category == "" || price.Category == b.Category)
+}
+
+// crossed returns the thresholds that spending from before to after reached.
+func (b Budget) crossed(before, after int64) []int {
+	var reached []int
+	for _, threshold := range b.Thresholds {
+		limit := b.Amount * int64(threshold) / 100
+		if before < limit && after >= limit {
+			reached = append(reached, threshold)
+		}
+	}
+	return reached
+}
+
+// summarize projects spent over the whole period, assuming spending goes on
+// at the rate so far. The first day counts as a full day so an early purchase
+// does not project an absurd total.
+func (b Budget) summarize(spent int64, now time.Time) BudgetSummary {
+	start, end := b.Period.Bounds(now)
+	elapsed := max(now.Sub(start), 24*time.Hour)
+	projected := float64(spent) * float64(end.Sub(start)) / float64(elapsed)
+
+	return BudgetSummary{
+		Budget:      b,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Spent:       spent,
+		Remaining:   b.Amount - spent,
+		Projected:   max(int64(projected), spent),
+	}
+}
@@ -0,0 +1,65 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/gtin"
+	"github.com/google/uuid"
+)
+
+// ProductStorage looks products up in the catalog. The catalog is shared by
+// all households and loaded offline by the catalog import command.
+type ProductStorage interface {
+	GetProduct(ctx context.Context, gtin string) (Product, error)
+}
+
+// CatalogService looks barcodes up in the product catalog and puts scanned
+// products on lists. Editors of a list can scan onto it.
+type CatalogService struct {
+	storage ProductStorage
+	lists   *ListService
+}
+
+func NewCatalogService(storage ProductStorage, lists *ListService) *CatalogService {
+	return &CatalogService{
+		storage: storage,
+		lists:   lists,
+	}
+}
+
+// Product returns the catalog entry for a barcode.
+func (s *CatalogService) Product(ctx context.Context, code string) (Product, error) {
+	normalized, err := gtin.Normalize(code)
+	if err != nil {
+		return Product{}, fmt.Errorf("%w: %v", ErrInvalidGTIN, err)
+	}
+
+	return s.storage.GetProduct(ctx, normalized)
+}
+
+// Scan adds the product with the barcode to the list under the catalog's name,
+// category and quantity, merging it into an unchecked item of the same name.
+// A category the dictionary does not know is picked as for a typed item.
+func (s *CatalogService) Scan(ctx context.Context, actor, listID uuid.UUID, in ScanInput) (AddedItem, error) {
+	normalized, err := gtin.Normalize(in.GTIN)
+	if err != nil {
+		return AddedItem{}, fmt.Errorf("%w: %v", ErrInvalidGTIN, err)
+	}
+
+	list, err := s.lists.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return AddedItem{}, err
+	}
+
+	product, err := s.storage.GetProduct(ctx, normalized)
+	if err != nil {
+		return AddedItem{}, err
+	}
+
+	entry := itemEntry{name: product.Name, quantity: product.Quantity}
+	if s.lists.categorizer.Known(product.Category) {
+		entry.category = product.Category
+	}
+
+	return s.lists.mergeItem(ctx, actor, list, entry)
+}
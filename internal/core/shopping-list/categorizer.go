@@ -0,0 +1,104 @@
+package shopping_list
+
+import (
+	"context"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// CategoryStorage remembers the category a household last chose for an item name.
+type CategoryStorage interface {
+	// HouseholdCategory returns the remembered category for the normalized
+	// name, or "" when the household never chose one.
+	HouseholdCategory(ctx context.Context, householdID uuid.UUID, name string) (string, error)
+	// HouseholdCategories returns the remembered categories of the
+	// normalized names the household chose one for, by name.
+	HouseholdCategories(ctx context.Context, householdID uuid.UUID, names []string) (map[string]string, error)
+	SaveHouseholdCategory(ctx context.Context, householdID uuid.UUID, name, category string, at time.Time) error
+}
+
+// Categorizer assigns categories to items. A category the household picked
+// for the same item name before wins over the keyword dictionary.
+type Categorizer struct {
+	dictionary *categories.Dictionary
+	storage    CategoryStorage
+}
+
+func NewCategorizer(dictionary *categories.Dictionary, storage CategoryStorage) *Categorizer {
+	return &Categorizer{
+		dictionary: dictionary,
+		storage:    storage,
+	}
+}
+
+func (c *Categorizer) Categories() []categories.Category {
+	return c.dictionary.Categories()
+}
+
+// Known reports whether the dictionary has the category.
+func (c *Categorizer) Known(category string) bool {
+	_, ok := c.dictionary.Category(category)
+	return ok
+}
+
+func (c *Categorizer) Categorize(ctx context.Context, householdID uuid.UUID, name string) (string, error) {
+	remembered, err := c.storage.HouseholdCategory(ctx, householdID, categories.Normalize(name))
+	if err != nil {
+		return "", err
+	}
+	return c.pick(remembered, name), nil
+}
+
+// Recall returns the categories the household chose for any of the names,
+// by normalized name, for categorizing many items with pick.
+func (c *Categorizer) Recall(ctx context.Context, householdID uuid.UUID, names []string) (map[string]string, error) {
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		normalized = append(normalized, categories.Normalize(name))
+	}
+	return c.storage.HouseholdCategories(ctx, householdID, normalized)
+}
+
+// pick categorizes name given the category the household chose for it, if any.
+func (c *Categorizer) pick(remembered, name string) string {
+	if c.Known(remembered) {
+		return remembered
+	}
+	return c.dictionary.Categorize(name)
+}
+
+// Remember stores the household's choice of category for an item name so
+// later items with that name land in the same category.
+func (c *Categorizer) Remember(ctx context.Context, householdID uuid.UUID, name, category string) error {
+	if !c.Known(category) {
+		return ErrUnknownCategory
+	}
+	return c.storage.SaveHouseholdCategory(ctx, householdID, categories.Normalize(name), category, time.Now())
+}
+
+// Group splits items into their categories in dictionary order, keeping the
+// order of the items within each category. Empty categories are left out.
+// Items in a category the dictionary no longer has are grouped as Other.
+func (c *Categorizer) Group(items []Item) []CategoryGroup {
+	byCategory := make(map[string][]Item)
+	for _, item := range items {
+		id := item.Category
+		if _, ok := c.dictionary.Category(id); !ok {
+			id = categories.Other
+		}
+		byCategory[id] = append(byCategory[id], item)
+	}
+
+	groups := make([]CategoryGroup, 0, len(byCategory))
+	for id, grouped := range byCategory {
+		category, _ := c.dictionary.Category(id)
+		groups = append(groups, CategoryGroup{Category: category, Items: grouped})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return c.dictionary.Position(groups[i].Category.ID) < c.dictionary.Position(groups[j].Category.ID)
+	})
+
+	return groups
+}
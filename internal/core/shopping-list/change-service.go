@@ -0,0 +1,135 @@
+package shopping_list
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// ChangeStorage reads the change feed of the lists a user can see.
+type ChangeStorage interface {
+	// ChangeHorizon returns the ID of the oldest transaction still running.
+	// Every change made by an older transaction is committed and visible.
+	ChangeHorizon(ctx context.Context) (uint64, error)
+	// ChangedLists, ChangedItems and Tombstones return up to limit of the
+	// user's changes in the window, by Seq.
+	ChangedLists(ctx context.Context, userID uuid.UUID, window ChangeWindow, limit int) ([]Change, error)
+	ChangedItems(ctx context.Context, userID uuid.UUID, window ChangeWindow, limit int) ([]Change, error)
+	Tombstones(ctx context.Context, userID uuid.UUID, window ChangeWindow, limit int) ([]Change, error)
+	// PurgeTombstones deletes the tombstones of deletions made before the
+	// given time and returns how many there were.
+	PurgeTombstones(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ChangeService lets clients catch up on the lists and items changed since
+// they last looked instead of fetching every list again.
+type ChangeService struct {
+	storage   ChangeStorage
+	retention time.Duration
+}
+
+func NewChangeService(storage ChangeStorage, retention time.Duration) *ChangeService {
+	return &ChangeService{
+		storage:   storage,
+		retention: retention,
+	}
+}
+
+// Changes returns a page of the changes to the user's lists since the
+// cursor. Without a cursor it returns every list and item, without
+// tombstones. A list or item changed several times shows up once, as it is
+// now, and may show up again in a later page or window when it changes
+// while the client is reading.
+//
+// Changes are read in windows of finished transactions: a window ends at the
+// oldest transaction still running when it is opened, so a change that
+// commits late is never skipped, it only waits for the next window.
+func (s *ChangeService) Changes(ctx context.Context, actor uuid.UUID, cursor string, limit int) (ChangeSet, error) {
+	if limit < 1 || limit > MaxChangeLimit {
+		return ChangeSet{}, ErrInvalidLimit
+	}
+	c, err := decodeChangeCursor(cursor)
+	if err != nil {
+		return ChangeSet{}, err
+	}
+	now := time.Now()
+	if c.From > 0 && c.Since.Before(now.Add(-s.retention)) {
+		return ChangeSet{}, ErrCursorExpired
+	}
+
+	if c.To == 0 {
+		horizon, err := s.storage.ChangeHorizon(ctx)
+		if err != nil {
+			return ChangeSet{}, err
+		}
+		c.To, c.Until, c.After = max(horizon, c.From), now, 0
+	}
+
+	changes, err := s.read(ctx, actor, c.ChangeWindow, limit+1)
+	if err != nil {
+		return ChangeSet{}, err
+	}
+
+	set := ChangeSet{Lists: []List{}, Items: []Item{}, Tombstones: []Tombstone{}}
+	if len(changes) > limit {
+		changes = changes[:limit]
+		set.HasMore = true
+	}
+	for _, change := range changes {
+		switch {
+		case change.List != nil:
+			set.Lists = append(set.Lists, *change.List)
+		case change.Item != nil:
+			set.Items = append(set.Items, *change.Item)
+		case change.Tombstone != nil:
+			set.Tombstones = append(set.Tombstones, *change.Tombstone)
+		}
+	}
+
+	if set.HasMore {
+		c.After = changes[len(changes)-1].Seq
+	} else {
+		c = changeCursor{ChangeWindow: ChangeWindow{From: c.To}, Since: c.Until}
+	}
+	set.Cursor = c.encode()
+
+	return set, nil
+}
+
+// read returns the first limit changes in the window across lists, items and
+// tombstones. Each source returns its own first limit, so the merge holds
+// every change up to the last one kept.
+func (s *ChangeService) read(ctx context.Context, actor uuid.UUID, window ChangeWindow, limit int) ([]Change, error) {
+	lists, err := s.storage.ChangedLists(ctx, actor, window, limit)
+	if err != nil {
+		return nil, err
+	}
+	items, err := s.storage.ChangedItems(ctx, actor, window, limit)
+	if err != nil {
+		return nil, err
+	}
+	changes := append(lists, items...)
+
+	// A client starting from scratch has nothing to drop.
+	if window.From > 0 {
+		tombstones, err := s.storage.Tombstones(ctx, actor, window, limit)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, tombstones...)
+	}
+
+	sort.Slice(changes, func(a, b int) bool {
+		return changes[a].Seq < changes[b].Seq
+	})
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes, nil
+}
+
+// PurgeTombstones deletes the tombstones older than the retention period.
+func (s *ChangeService) PurgeTombstones(ctx context.Context, now time.Time) (int64, error) {
+	return s.storage.PurgeTombstones(ctx, now.Add(-s.retention))
+}
@@ -0,0 +1,195 @@
+package shopping_list
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+	"time"
+)
+
+// memoryChanges is a change feed where each change was made by the
+// transaction tx; transactions from horizon on are still running.
+type memoryChanges struct {
+	ChangeStorage
+	horizon uint64
+	changes []feedChange
+}
+
+type feedChange struct {
+	tx uint64
+	Change
+}
+
+func (m *memoryChanges) ChangeHorizon(context.Context) (uint64, error) {
+	return m.horizon, nil
+}
+
+func (m *memoryChanges) ChangedLists(_ context.Context, _ uuid.UUID, window ChangeWindow, limit int) ([]Change, error) {
+	return m.window(window, limit, func(c Change) bool { return c.List != nil }), nil
+}
+
+func (m *memoryChanges) ChangedItems(_ context.Context, _ uuid.UUID, window ChangeWindow, limit int) ([]Change, error) {
+	return m.window(window, limit, func(c Change) bool { return c.Item != nil }), nil
+}
+
+func (m *memoryChanges) Tombstones(_ context.Context, _ uuid.UUID, window ChangeWindow, limit int) ([]Change, error) {
+	return m.window(window, limit, func(c Change) bool { return c.Tombstone != nil }), nil
+}
+
+func (m *memoryChanges) window(window ChangeWindow, limit int, kind func(Change) bool) []Change {
+	var changes []Change
+	for _, c := range m.changes {
+		if kind(c.Change) && c.tx >= window.From && c.tx < window.To && c.Seq > window.After {
+			changes = append(changes, c.Change)
+		}
+	}
+	slices.SortFunc(changes, func(a, b Change) int { return cmp.Compare(a.Seq, b.Seq) })
+	if len(changes) > limit {
+		changes = changes[:limit]
+	}
+	return changes
+}
+
+func (m *memoryChanges) add(tx uint64, seq int64, change Change) {
+	change.Seq = seq
+	m.changes = append(m.changes, feedChange{tx: tx, Change: change})
+}
+
+// itemIDs returns the IDs of the set's items in order.
+func itemIDs(set ChangeSet) []uuid.UUID {
+	var ids []uuid.UUID
+	for _, item := range set.Items {
+		ids = append(ids, item.ID)
+	}
+	return ids
+}
+
+func TestChangesPagesThroughWindow(t *testing.T) {
+	ctx := context.Background()
+	storage := &memoryChanges{horizon: 10}
+	var want []uuid.UUID
+	for i := range 5 {
+		item := Item{ID: uuid.New()}
+		want = append(want, item.ID)
+		storage.add(uint64(i+1), int64(i+1), Change{Item: &item})
+	}
+	s := NewChangeService(storage, time.Hour)
+
+	var got []uuid.UUID
+	cursor := ""
+	for page := 0; ; page++ {
+		set, err := s.Changes(ctx, uuid.New(), cursor, 2)
+		if err != nil {
+			t.Fatalf("Changes() page %d = %v", page, err)
+		}
+		got = append(got, itemIDs(set)...)
+		cursor = set.Cursor
+		if !set.HasMore {
+			break
+		}
+		if page > 5 {
+			t.Fatal("Changes() keeps having more")
+		}
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("Changes() returned items %v, want %v", got, want)
+	}
+
+	set, err := s.Changes(ctx, uuid.New(), cursor, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Items) != 0 || set.HasMore {
+		t.Errorf("Changes() after the last page = %+v, want nothing", set)
+	}
+}
+
+// A transaction that commits after later ones have been read still has its
+// changes delivered, in the next window, though they are numbered lower.
+func TestChangesWaitForRunningTransactions(t *testing.T) {
+	ctx := context.Background()
+	storage := &memoryChanges{horizon: 5}
+	early, late, next := Item{ID: uuid.New()}, Item{ID: uuid.New()}, Item{ID: uuid.New()}
+	storage.add(4, 1, Change{Item: &early})
+	storage.add(5, 2, Change{Item: &late})
+	storage.add(6, 3, Change{Item: &next})
+	s := NewChangeService(storage, time.Hour)
+
+	first, err := s.Changes(ctx, uuid.New(), "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := itemIDs(first); !slices.Equal(got, []uuid.UUID{early.ID}) {
+		t.Errorf("first window = %v, want only the committed item %v", got, early.ID)
+	}
+
+	storage.horizon = 7
+	second, err := s.Changes(ctx, uuid.New(), first.Cursor, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := itemIDs(second); !slices.Equal(got, []uuid.UUID{late.ID, next.ID}) {
+		t.Errorf("second window = %v, want %v", got, []uuid.UUID{late.ID, next.ID})
+	}
+}
+
+// Tombstones only tell clients to drop what an earlier sync gave them.
+func TestChangesTombstonesAfterFirstSync(t *testing.T) {
+	ctx := context.Background()
+	storage := &memoryChanges{horizon: 3}
+	storage.add(1, 1, Change{Tombstone: &Tombstone{Kind: TombstoneItem, ID: uuid.New()}})
+	s := NewChangeService(storage, time.Hour)
+
+	first, err := s.Changes(ctx, uuid.New(), "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first.Tombstones) != 0 {
+		t.Errorf("Changes() without a cursor returned %d tombstones", len(first.Tombstones))
+	}
+
+	storage.add(3, 2, Change{Tombstone: &Tombstone{Kind: TombstoneList, ID: uuid.New()}})
+	storage.horizon = 4
+	second, err := s.Changes(ctx, uuid.New(), first.Cursor, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.Tombstones) != 1 || second.Tombstones[0].Kind != TombstoneList {
+		t.Errorf("Changes() returned tombstones %+v, want the list deleted since", second.Tombstones)
+	}
+}
+
+func TestChangesRejectsBadCursors(t *testing.T) {
+	ctx := context.Background()
+	s := NewChangeService(&memoryChanges{horizon: 1}, time.Hour)
+
+	for _, cursor := range []string{"not a cursor", base64.RawURLEncoding.EncodeToString([]byte("2.1.2.3.4.5"))} {
+		if _, err := s.Changes(ctx, uuid.New(), cursor, 10); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Changes(%q) = %v, want ErrInvalidCursor", cursor, err)
+		}
+	}
+
+	stale := changeCursor{ChangeWindow: ChangeWindow{From: 1}, Since: time.Now().Add(-2 * time.Hour)}
+	if _, err := s.Changes(ctx, uuid.New(), stale.encode(), 10); !errors.Is(err, ErrCursorExpired) {
+		t.Errorf("Changes() with a cursor older than the retention = %v, want ErrCursorExpired", err)
+	}
+}
+
+func TestChangeCursorRoundTrip(t *testing.T) {
+	want := changeCursor{
+		ChangeWindow: ChangeWindow{From: 12, To: 40, After: 7},
+		Since:        time.Unix(1700000000, 0),
+		Until:        time.Unix(1700000600, 0),
+	}
+	got, err := decodeChangeCursor(want.encode())
+	if err != nil {
+		t.Fatalf("decodeChangeCursor() = %v", err)
+	}
+	if got.ChangeWindow != want.ChangeWindow || !got.Since.Equal(want.Since) || !got.Until.Equal(want.Until) {
+		t.Errorf("decodeChangeCursor() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package shopping_list
+
+import (
+	"encoding/base64"
+	"fmt"
+	"github.com/google/uuid"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultChangeLimit = 200
+	MaxChangeLimit     = 500
+)
+
+type TombstoneKind string
+
+const (
+	TombstoneList TombstoneKind = "list"
+	TombstoneItem TombstoneKind = "item"
+)
+
+// Tombstone tells a client to drop a list or item it has. Deleting a list
+// drops its items too, and so does losing access to the list.
+type Tombstone struct {
+	Kind      TombstoneKind `json:"kind"`
+	ID        uuid.UUID     `json:"id"`
+	ListID    *uuid.UUID    `json:"list_id,omitempty"`
+	DeletedAt time.Time     `json:"deleted_at"`
+}
+
+// Change is one entry of the change feed: a list or item as it is now, or a
+// tombstone. Seq orders changes across all of them.
+type Change struct {
+	Seq       int64
+	List      *List
+	Item      *Item
+	Tombstone *Tombstone
+}
+
+// ChangeWindow selects the changes of the transactions with an ID from From up
+// to but not including To, after the change numbered After.
+type ChangeWindow struct {
+	From  uint64
+	To    uint64
+	After int64
+}
+
+// ChangeSet is a page of changes. Cursor continues from it; while HasMore is
+// set the client should ask again right away.
+type ChangeSet struct {
+	Lists      []List      `json:"lists"`
+	Items      []Item      `json:"items"`
+	Tombstones []Tombstone `json:"tombstones"`
+	Cursor     string      `json:"cursor"`
+	HasMore    bool        `json:"has_more"`
+}
+
+// changeCursor is the state behind an opaque cursor: the window being read,
+// how far into it, and when its bounds were taken. To is zero between windows.
+type changeCursor struct {
+	ChangeWindow
+	Since time.Time
+	Until time.Time
+}
+
+func (c changeCursor) encode() string {
+	raw := fmt.Sprintf("1.%d.%d.%d.%d.%d", c.From, c.To, c.After, c.Since.Unix(), c.Until.Unix())
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeChangeCursor reads a cursor; an empty one starts from scratch.
+func decodeChangeCursor(cursor string) (changeCursor, error) {
+	if cursor == "" {
+		return changeCursor{}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return changeCursor{}, ErrInvalidCursor
+	}
+	parts := strings.Split(string(raw), ".")
+	if len(parts) != 6 || parts[0] != "1" {
+		return changeCursor{}, ErrInvalidCursor
+	}
+
+	var c changeCursor
+	var since, until int64
+	for i, target := range []any{&c.From, &c.To, &c.After, &since, &until} {
+		switch target := target.(type) {
+		case *uint64:
+			*target, err = strconv.ParseUint(parts[i+1], 10, 64)
+		case *int64:
+			*target, err = strconv.ParseInt(parts[i+1], 10, 64)
+		}
+		if err != nil {
+			return changeCursor{}, ErrInvalidCursor
+		}
+	}
+	c.Since, c.Until = time.Unix(since, 0), time.Unix(until, 0)
+
+	return c, nil
+}
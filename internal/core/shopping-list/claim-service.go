@@ -0,0 +1,148 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// ClaimStorage finds item claims across lists.
+type ClaimStorage interface {
+	// ClaimedItems returns the unchecked items with a claim of the user that
+	// is active at now, on the lists of the user's households, in list order.
+	ClaimedItems(ctx context.Context, userID uuid.UUID, now time.Time) ([]Item, error)
+}
+
+// ClaimService lets household members say who is buying which item so that
+// nobody buys the same thing twice. Editors claim items for themselves or
+// assign them to other members; every claim expires after a while.
+type ClaimService struct {
+	storage   ClaimStorage
+	lists     *ListService
+	publisher EventPublisher
+	ttl       time.Duration
+}
+
+// NewClaimService returns a service whose claims expire after ttl unless the
+// claim asks for another duration.
+func NewClaimService(storage ClaimStorage, lists *ListService, publisher EventPublisher, ttl time.Duration) *ClaimService {
+	return &ClaimService{
+		storage:   storage,
+		lists:     lists,
+		publisher: publisher,
+		ttl:       ttl,
+	}
+}
+
+// ClaimItem claims the item for the actor or assigns it to another member.
+// While a claim is active only the member holding it and the one who assigned
+// it can claim the item again, which renews the claim or passes it on.
+func (s *ClaimService) ClaimItem(ctx context.Context, actor, listID, itemID uuid.UUID, in ClaimInput) (Item, error) {
+	ttl := s.ttl
+	if in.ExpiresIn != 0 {
+		ttl = time.Duration(in.ExpiresIn) * time.Second
+	}
+	if ttl <= 0 || ttl > MaxClaimTTL {
+		return Item{}, ErrInvalidExpiry
+	}
+
+	list, err := s.lists.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return Item{}, err
+	}
+	assignee := actor
+	if in.UserID != nil && *in.UserID != actor {
+		assignee = *in.UserID
+		if _, err := s.lists.households.MemberRole(ctx, list.HouseholdID, assignee); errors.Is(err, ErrNotMember) {
+			return Item{}, ErrInvalidAssignee
+		} else if err != nil {
+			return Item{}, err
+		}
+	}
+
+	item, err := s.lists.storage.GetItem(ctx, list.ID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	if item.Checked {
+		return Item{}, ErrItemChecked
+	}
+	now := time.Now()
+	if holder := item.Claim; holder.Active(now) && holder.UserID != actor && holder.AssignedBy != actor {
+		return Item{}, ErrAlreadyClaimed
+	}
+
+	item.Claim = &ItemClaim{UserID: assignee, AssignedBy: actor, ClaimedAt: now, ExpiresAt: now.Add(ttl)}
+	item.UpdatedAt = now
+	if err := s.lists.storage.UpdateItem(ctx, item); err != nil {
+		return Item{}, err
+	}
+
+	return item, s.notify(ctx, actor, list, EventItemClaimed, item, now)
+}
+
+// ReleaseClaim drops the item's claim. The member holding it and the one who
+// assigned it can release it, and so can owners.
+func (s *ClaimService) ReleaseClaim(ctx context.Context, actor, listID, itemID uuid.UUID) (Item, error) {
+	list, err := s.lists.authorize(ctx, actor, listID, RoleViewer)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := s.lists.storage.GetItem(ctx, list.ID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	if item.Claim == nil {
+		return item, nil
+	}
+	if item.Claim.UserID != actor && item.Claim.AssignedBy != actor {
+		if err := requireRole(ctx, s.lists.households, list.HouseholdID, actor, RoleOwner); err != nil {
+			return Item{}, err
+		}
+	}
+
+	now := time.Now()
+	item.Claim = nil
+	item.UpdatedAt = now
+	if err := s.lists.storage.UpdateItem(ctx, item); err != nil {
+		return Item{}, err
+	}
+
+	return item, s.notify(ctx, actor, list, EventItemReleased, item, now)
+}
+
+// MyItems returns the items the actor is buying, grouped by list.
+func (s *ClaimService) MyItems(ctx context.Context, actor uuid.UUID) ([]ClaimedList, error) {
+	items, err := s.storage.ClaimedItems(ctx, actor, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	lists, err := s.lists.storage.UserLists(ctx, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make(map[uuid.UUID][]Item)
+	for _, item := range items {
+		claimed[item.ListID] = append(claimed[item.ListID], item)
+	}
+	result := make([]ClaimedList, 0, len(claimed))
+	for _, list := range lists {
+		if items, ok := claimed[list.ID]; ok {
+			result = append(result, ClaimedList{List: list, Items: items})
+		}
+	}
+
+	return result, nil
+}
+
+func (s *ClaimService) notify(ctx context.Context, actor uuid.UUID, list List, eventType EventType, item Item, now time.Time) error {
+	return notifyHousehold(ctx, s.lists.households, s.publisher, Event{
+		Type:        eventType,
+		HouseholdID: list.HouseholdID,
+		ActorID:     actor,
+		Data:        item,
+		OccurredAt:  now,
+	})
+}
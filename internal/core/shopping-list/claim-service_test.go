@@ -0,0 +1,151 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func newClaimService(h *household) *ClaimService {
+	return NewClaimService(nil, h.service, discardPublisher{}, time.Hour)
+}
+
+func TestClaimItem(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	s := newClaimService(h)
+
+	claimed, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{})
+	if err != nil {
+		t.Fatalf("ClaimItem() = %v", err)
+	}
+	claim := claimed.Claim
+	if claim == nil || claim.UserID != h.editor || claim.AssignedBy != h.editor || claim.ExpiresAt.Sub(claim.ClaimedAt) != time.Hour {
+		t.Fatalf("ClaimItem() claim = %+v, want the editor's for an hour", claim)
+	}
+	if stored := h.lists.items[milk.ID].Claim; stored == nil || *stored != *claim {
+		t.Errorf("stored claim = %+v, want %+v", stored, claim)
+	}
+
+	if _, err := s.ClaimItem(ctx, h.owner, h.list.ID, milk.ID, ClaimInput{}); !errors.Is(err, ErrAlreadyClaimed) {
+		t.Errorf("ClaimItem() by another member = %v, want ErrAlreadyClaimed", err)
+	}
+	if _, err := s.ClaimItem(ctx, h.viewer, h.list.ID, milk.ID, ClaimInput{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("ClaimItem() by a viewer = %v, want ErrForbidden", err)
+	}
+
+	renewed, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{ExpiresIn: 60})
+	if err != nil {
+		t.Fatalf("ClaimItem() again = %v", err)
+	}
+	if renewed.Claim.UserID != h.editor || renewed.Claim.ExpiresAt.Sub(renewed.Claim.ClaimedAt) != time.Minute {
+		t.Errorf("ClaimItem() again = %+v, want the claim renewed for a minute", renewed.Claim)
+	}
+}
+
+func TestClaimItemInvalid(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	s := newClaimService(h)
+	stranger := uuid.New()
+
+	if _, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{UserID: &stranger}); !errors.Is(err, ErrInvalidAssignee) {
+		t.Errorf("ClaimItem() for a non-member = %v, want ErrInvalidAssignee", err)
+	}
+	for _, expiresIn := range []int{-1, int(MaxClaimTTL/time.Second) + 1} {
+		if _, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{ExpiresIn: expiresIn}); !errors.Is(err, ErrInvalidExpiry) {
+			t.Errorf("ClaimItem() expiring in %ds = %v, want ErrInvalidExpiry", expiresIn, err)
+		}
+	}
+	if _, err := h.service.CheckItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{}); !errors.Is(err, ErrItemChecked) {
+		t.Errorf("ClaimItem() of a checked item = %v, want ErrItemChecked", err)
+	}
+}
+
+// Only the member holding a claim and the one who assigned it decide who
+// holds it next; other editors cannot take it over by assigning it, not even
+// to its holder.
+func TestClaimItemPassOn(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	s := newClaimService(h)
+	other := uuid.New()
+	h.members.members = append(h.members.members, Member{HouseholdID: h.id, UserID: other, Role: RoleEditor})
+
+	if _, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{UserID: &h.viewer}); err != nil {
+		t.Fatalf("ClaimItem() assigning it = %v", err)
+	}
+
+	for _, assignee := range []uuid.UUID{h.viewer, other, h.owner} {
+		if _, err := s.ClaimItem(ctx, other, h.list.ID, milk.ID, ClaimInput{UserID: &assignee}); !errors.Is(err, ErrAlreadyClaimed) {
+			t.Errorf("ClaimItem() by another editor for %v = %v, want ErrAlreadyClaimed", assignee, err)
+		}
+	}
+	if claim := h.lists.items[milk.ID].Claim; claim.UserID != h.viewer || claim.AssignedBy != h.editor {
+		t.Errorf("claim = %+v, want the viewer's, assigned by the editor", claim)
+	}
+
+	passed, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{UserID: &other})
+	if err != nil {
+		t.Fatalf("ClaimItem() passing it on = %v", err)
+	}
+	if passed.Claim.UserID != other || passed.Claim.AssignedBy != h.editor {
+		t.Errorf("ClaimItem() passing it on = %+v, want it assigned to the other editor", passed.Claim)
+	}
+}
+
+// An expired claim no longer holds anyone back.
+func TestClaimItemExpired(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	past := time.Now().Add(-time.Hour)
+	milk.Claim = &ItemClaim{UserID: h.editor, AssignedBy: h.editor, ClaimedAt: past.Add(-time.Hour), ExpiresAt: past}
+	h.lists.items[milk.ID] = milk
+
+	claimed, err := newClaimService(h).ClaimItem(ctx, h.owner, h.list.ID, milk.ID, ClaimInput{})
+	if err != nil {
+		t.Fatalf("ClaimItem() over an expired claim = %v", err)
+	}
+	if claimed.Claim.UserID != h.owner {
+		t.Errorf("ClaimItem() = %+v, want the owner's claim", claimed.Claim)
+	}
+}
+
+func TestReleaseClaim(t *testing.T) {
+	ctx := context.Background()
+	for _, releaser := range []string{"holder", "assigner", "owner", "viewer"} {
+		h := newHousehold(t)
+		milk := h.add(t, "Milk")
+		s := newClaimService(h)
+		holder := uuid.New()
+		h.members.members = append(h.members.members, Member{HouseholdID: h.id, UserID: holder, Role: RoleViewer})
+		if _, err := s.ClaimItem(ctx, h.editor, h.list.ID, milk.ID, ClaimInput{UserID: &holder}); err != nil {
+			t.Fatal(err)
+		}
+
+		actor := map[string]uuid.UUID{"holder": holder, "assigner": h.editor, "owner": h.owner, "viewer": h.viewer}[releaser]
+		released, err := s.ReleaseClaim(ctx, actor, h.list.ID, milk.ID)
+		if releaser == "viewer" {
+			if !errors.Is(err, ErrForbidden) {
+				t.Errorf("ReleaseClaim() by another viewer = %v, want ErrForbidden", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ReleaseClaim() by the %s = %v", releaser, err)
+			continue
+		}
+		if released.Claim != nil || h.lists.items[milk.ID].Claim != nil {
+			t.Errorf("ReleaseClaim() by the %s left the claim %+v", releaser, h.lists.items[milk.ID].Claim)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// MaxClaimTTL is the longest a claim can be held before it expires.
+const MaxClaimTTL = 30 * 24 * time.Hour
+
+// ItemClaim says who is buying an item. AssignedBy is UserID itself when the
+// user claimed the item and another member when they assigned it.
+type ItemClaim struct {
+	UserID     uuid.UUID `json:"user_id"`
+	AssignedBy uuid.UUID `json:"assigned_by"`
+	ClaimedAt  time.Time `json:"claimed_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// Active reports whether the claim still holds at now.
+func (c *ItemClaim) Active(now time.Time) bool {
+	return c != nil && now.Before(c.ExpiresAt)
+}
+
+// ClaimInput claims an item for the actor, or assigns it to UserID. ExpiresIn
+// overrides the default claim duration.
+type ClaimInput struct {
+	UserID    *uuid.UUID `json:"user_id"`
+	ExpiresIn int        `json:"expires_in"` // seconds
+}
+
+// ClaimedList is a list with the items on it that a user is buying.
+type ClaimedList struct {
+	List
+	Items []Item `json:"items"`
+}
@@ -0,0 +1,77 @@
+package shopping_list
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Base errors callers can match with errors.Is to pick a response status.
+var (
+	ErrNotFound  = errors.New("not found")
+	ErrInvalid   = errors.New("invalid input")
+	ErrForbidden = errors.New("forbidden")
+	ErrConflict  = errors.New("conflict")
+	ErrGone      = errors.New("no longer available")
+)
+
+var (
+	ErrListNotFound          = fmt.Errorf("list %w", ErrNotFound)
+	ErrItemNotFound          = fmt.Errorf("item %w", ErrNotFound)
+	ErrHouseholdNotFound     = fmt.Errorf("household %w", ErrNotFound)
+	ErrMemberNotFound        = fmt.Errorf("member %w", ErrNotFound)
+	ErrInviteNotFound        = fmt.Errorf("invite %w", ErrNotFound)
+	ErrStoreNotFound         = fmt.Errorf("store %w", ErrNotFound)
+	ErrTemplateNotFound      = fmt.Errorf("template %w", ErrNotFound)
+	ErrPantryItemNotFound    = fmt.Errorf("pantry item %w", ErrNotFound)
+	ErrRecipeNotFound        = fmt.Errorf("recipe %w", ErrNotFound)
+	ErrMealPlanEntryNotFound = fmt.Errorf("meal plan entry %w", ErrNotFound)
+	ErrBudgetNotFound        = fmt.Errorf("budget %w", ErrNotFound)
+	ErrProductNotFound       = fmt.Errorf("product %w", ErrNotFound)
+	ErrListEventNotFound     = fmt.Errorf("list event %w", ErrNotFound)
+	ErrTripNotFound          = fmt.Errorf("trip %w", ErrNotFound)
+	ErrEmptyName             = fmt.Errorf("%w: name must not be empty", ErrInvalid)
+	ErrInvalidRole           = fmt.Errorf("%w: role must be owner, editor or viewer", ErrInvalid)
+	ErrNotMember             = fmt.Errorf("%w: not a household member", ErrForbidden)
+	ErrLastOwner             = fmt.Errorf("%w: household must keep at least one owner", ErrConflict)
+	ErrInvalidExpiry         = fmt.Errorf("%w: expiry must be between 1 second and 30 days", ErrInvalid)
+	ErrInvalidMaxUses        = fmt.Errorf("%w: max uses must not be negative", ErrInvalid)
+	ErrInviteExpired         = fmt.Errorf("invite %w: expired", ErrGone)
+	ErrInviteRevoked         = fmt.Errorf("invite %w: revoked", ErrGone)
+	ErrInviteUsedUp          = fmt.Errorf("invite %w: all uses redeemed", ErrGone)
+	ErrUnknownCategory       = fmt.Errorf("%w: unknown category", ErrInvalid)
+	ErrDuplicateSection      = fmt.Errorf("%w: category listed twice in store sections", ErrInvalid)
+	ErrInvalidCadence        = fmt.Errorf("%w: cadence needs either every_days (1-365) or day_of_month (1-31)", ErrInvalid)
+	ErrInvalidThreshold      = fmt.Errorf("%w: invalid threshold", ErrInvalid)
+	ErrInvalidDays           = fmt.Errorf("%w: days must not be negative", ErrInvalid)
+	ErrInvalidServings       = fmt.Errorf("%w: servings must be from 1 to %d", ErrInvalid, MaxServings)
+	ErrInvalidMeal           = fmt.Errorf("%w: meal must be breakfast, lunch, dinner or snack", ErrInvalid)
+	ErrInvalidDate           = fmt.Errorf("%w: dates must look like 2006-01-02", ErrInvalid)
+	ErrInvalidAmount         = fmt.Errorf("%w: amount must not be negative", ErrInvalid)
+	ErrInvalidCurrency       = fmt.Errorf("%w: currency must be a three-letter ISO 4217 code", ErrInvalid)
+	ErrInvalidPeriod         = fmt.Errorf("%w: period must be weekly or monthly", ErrInvalid)
+	ErrInvalidBudget         = fmt.Errorf("%w: budget amount must be positive", ErrInvalid)
+	ErrInvalidThresholds     = fmt.Errorf("%w: thresholds must be percentages between 1 and 1000", ErrInvalid)
+	ErrDuplicateBudget       = fmt.Errorf("%w: household already has a budget for this period and category", ErrConflict)
+	ErrEmptyReceipt          = fmt.Errorf("%w: receipt text must not be empty", ErrInvalid)
+	ErrInvalidDateRange      = fmt.Errorf("%w: date range must run forwards and span at most 62 days", ErrInvalid)
+	ErrInvalidGTIN           = fmt.Errorf("%w: barcode", ErrInvalid)
+	ErrInvalidLimit          = fmt.Errorf("%w: limit must be between 1 and 500", ErrInvalid)
+	ErrInvalidOrder          = fmt.Errorf("%w: order must name every item on the list once", ErrInvalid)
+	ErrNothingToUndo         = fmt.Errorf("%w: nothing to undo", ErrConflict)
+	ErrInvalidClientID       = fmt.Errorf("%w: client_id must be set and not be \"server\"", ErrInvalid)
+	ErrInvalidSyncOp         = fmt.Errorf("%w: sync operation", ErrInvalid)
+	ErrInvalidCursor         = fmt.Errorf("%w: cursor", ErrInvalid)
+	ErrCursorExpired         = fmt.Errorf("cursor %w: sync again without one", ErrGone)
+	ErrInvalidAssignee       = fmt.Errorf("%w: items can only be assigned to household members", ErrInvalid)
+	ErrItemChecked           = fmt.Errorf("%w: item is already checked off", ErrConflict)
+	ErrAlreadyClaimed        = fmt.Errorf("%w: someone else is buying this item", ErrConflict)
+	ErrInvalidTripLists      = fmt.Errorf("%w: a trip needs at least one list of the store's household", ErrInvalid)
+	ErrTripInProgress        = fmt.Errorf("%w: finish your current trip first", ErrConflict)
+	ErrTripFinished          = fmt.Errorf("%w: trip is already finished", ErrConflict)
+	ErrInvalidMerge          = fmt.Errorf("%w: an item cannot be merged into itself", ErrInvalid)
+	ErrIncompatibleItems     = fmt.Errorf("%w: the items' quantities cannot be added up", ErrConflict)
+	ErrInvalidSearch         = fmt.Errorf("%w: search needs a query of at most 200 characters", ErrInvalid)
+	ErrInvalidLanguage       = fmt.Errorf("%w: language must be english or simple", ErrInvalid)
+	ErrInvalidBatch          = fmt.Errorf("%w: a batch needs between 1 and 500 operations", ErrInvalid)
+	ErrInvalidBatchOp        = fmt.Errorf("%w: operations are add with an item, or update, check, uncheck or delete with an item_id", ErrInvalid)
+)
@@ -0,0 +1,51 @@
+package shopping_list
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventMemberJoined     EventType = "member_joined"
+	EventBudgetThreshold  EventType = "budget_threshold_reached"
+	EventItemClaimed      EventType = "item_claimed"
+	EventItemReleased     EventType = "item_released"
+	EventTripStarted      EventType = "trip_started"
+	EventTripItemChecked  EventType = "trip_item_checked"
+	EventTripItemNotFound EventType = "trip_item_not_found"
+	EventTripFinished     EventType = "trip_finished"
+)
+
+// Event is a real-time notification pushed to household members.
+type Event struct {
+	Type        EventType `json:"type"`
+	HouseholdID uuid.UUID `json:"household_id"`
+	ActorID     uuid.UUID `json:"actor_id"`
+	Data        any       `json:"data,omitempty"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// EventPublisher delivers events to the given users. Delivery is best effort:
+// users that are not connected simply miss the event.
+type EventPublisher interface {
+	Publish(ctx context.Context, recipients []uuid.UUID, event Event)
+}
+
+// notifyHousehold publishes event to every member of its household.
+func notifyHousehold(ctx context.Context, households HouseholdStorage, publisher EventPublisher, event Event) error {
+	members, err := households.Members(ctx, event.HouseholdID)
+	if err != nil {
+		return err
+	}
+
+	recipients := make([]uuid.UUID, 0, len(members))
+	for _, m := range members {
+		recipients = append(recipients, m.UserID)
+	}
+	publisher.Publish(ctx, recipients, event)
+
+	return nil
+}
@@ -0,0 +1,371 @@
+package shopping_list
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+// The storages below keep their rows in memory for the services under test.
+// Each embeds the interface it stands in for, so a method a test does not
+// reach is left unimplemented and panics when called.
+
+type memoryLists struct {
+	ListStorage
+	lists map[uuid.UUID]List
+	items map[uuid.UUID]Item
+	trash map[uuid.UUID]Item
+	// deleted holds the lists in the trash, whose items stay in items.
+	deleted map[uuid.UUID]List
+	// created counts the items stored through CreateItem and CreateItems.
+	created int
+}
+
+func newMemoryLists() *memoryLists {
+	return &memoryLists{
+		lists:   make(map[uuid.UUID]List),
+		items:   make(map[uuid.UUID]Item),
+		trash:   make(map[uuid.UUID]Item),
+		deleted: make(map[uuid.UUID]List),
+	}
+}
+
+func (m *memoryLists) GetList(_ context.Context, id uuid.UUID) (List, error) {
+	list, ok := m.lists[id]
+	if !ok {
+		return List{}, ErrListNotFound
+	}
+	return list, nil
+}
+
+func (m *memoryLists) CreateList(_ context.Context, list List) error {
+	m.lists[list.ID] = list
+	return nil
+}
+
+func (m *memoryLists) UpdateList(_ context.Context, list List) error {
+	m.lists[list.ID] = list
+	return nil
+}
+
+func (m *memoryLists) DeleteList(_ context.Context, id uuid.UUID) error {
+	if list, ok := m.lists[id]; ok {
+		delete(m.lists, id)
+		m.deleted[id] = list
+	}
+	return nil
+}
+
+func (m *memoryLists) RestoreList(_ context.Context, id uuid.UUID) error {
+	list, ok := m.deleted[id]
+	if !ok {
+		return ErrListNotFound
+	}
+	delete(m.deleted, id)
+	m.lists[id] = list
+	return nil
+}
+
+// ListItems returns the list's items by position.
+func (m *memoryLists) ListItems(_ context.Context, listID uuid.UUID) ([]Item, error) {
+	var items []Item
+	for _, item := range m.items {
+		if item.ListID == listID {
+			items = append(items, item)
+		}
+	}
+	slices.SortFunc(items, func(a, b Item) int {
+		return cmp.Compare(a.Position, b.Position)
+	})
+	return items, nil
+}
+
+func (m *memoryLists) GetItem(_ context.Context, listID, itemID uuid.UUID) (Item, error) {
+	item, ok := m.items[itemID]
+	if !ok || item.ListID != listID {
+		return Item{}, ErrItemNotFound
+	}
+	return item, nil
+}
+
+func (m *memoryLists) ItemExists(_ context.Context, itemID uuid.UUID) (bool, error) {
+	_, ok := m.items[itemID]
+	_, trashed := m.trash[itemID]
+	return ok || trashed, nil
+}
+
+func (m *memoryLists) OpenItemsByName(ctx context.Context, listID uuid.UUID, name string) ([]Item, error) {
+	return m.OpenItemsByNames(ctx, listID, []string{name})
+}
+
+func (m *memoryLists) OpenItemsByNames(ctx context.Context, listID uuid.UUID, names []string) ([]Item, error) {
+	items, _ := m.ListItems(ctx, listID)
+	return slices.DeleteFunc(items, func(item Item) bool {
+		return item.Checked || !slices.ContainsFunc(names, func(name string) bool {
+			return strings.EqualFold(name, item.Name)
+		})
+	}), nil
+}
+
+func (m *memoryLists) SimilarOpenItems(context.Context, uuid.UUID, string, float64) ([]Item, error) {
+	return nil, nil
+}
+
+func (m *memoryLists) NextItemPosition(ctx context.Context, listID uuid.UUID) (int, error) {
+	items, _ := m.ListItems(ctx, listID)
+	if len(items) == 0 {
+		return 0, nil
+	}
+	return items[len(items)-1].Position + 1, nil
+}
+
+// CreateItem fails like the primary key would for an ID that is taken.
+func (m *memoryLists) CreateItem(ctx context.Context, item Item) error {
+	if taken, _ := m.ItemExists(ctx, item.ID); taken {
+		return errors.New("duplicate key value violates unique constraint")
+	}
+	m.items[item.ID] = item
+	m.created++
+	return nil
+}
+
+func (m *memoryLists) CreateItems(ctx context.Context, items []Item) error {
+	for _, item := range items {
+		if err := m.CreateItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryLists) UpdateItem(_ context.Context, item Item) error {
+	if _, ok := m.items[item.ID]; !ok {
+		return ErrItemNotFound
+	}
+	m.items[item.ID] = item
+	return nil
+}
+
+func (m *memoryLists) DeleteItem(_ context.Context, listID, itemID uuid.UUID) error {
+	item, ok := m.items[itemID]
+	if !ok || item.ListID != listID {
+		return ErrItemNotFound
+	}
+	delete(m.items, itemID)
+	m.trash[itemID] = item
+	return nil
+}
+
+func (m *memoryLists) RestoreItem(_ context.Context, listID, itemID uuid.UUID) error {
+	item, ok := m.trash[itemID]
+	if !ok || item.ListID != listID {
+		return ErrItemNotFound
+	}
+	delete(m.trash, itemID)
+	m.items[itemID] = item
+	return nil
+}
+
+type memoryHouseholds struct {
+	HouseholdStorage
+	members []Member
+}
+
+func (m *memoryHouseholds) Members(_ context.Context, householdID uuid.UUID) ([]Member, error) {
+	var members []Member
+	for _, member := range m.members {
+		if member.HouseholdID == householdID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m *memoryHouseholds) MemberRole(_ context.Context, householdID, userID uuid.UUID) (Role, error) {
+	for _, member := range m.members {
+		if member.HouseholdID == householdID && member.UserID == userID {
+			return member.Role, nil
+		}
+	}
+	return "", ErrNotMember
+}
+
+func (m *memoryHouseholds) SaveMember(ctx context.Context, member Member) error {
+	_ = m.DeleteMember(ctx, member.HouseholdID, member.UserID)
+	m.members = append(m.members, member)
+	return nil
+}
+
+func (m *memoryHouseholds) DeleteMember(_ context.Context, householdID, userID uuid.UUID) error {
+	m.members = slices.DeleteFunc(m.members, func(member Member) bool {
+		return member.HouseholdID == householdID && member.UserID == userID
+	})
+	return nil
+}
+
+// memoryHistory numbers events from 1 in the order they are appended.
+type memoryHistory struct {
+	ListHistoryStorage
+	events []ListEvent
+}
+
+func (m *memoryHistory) AppendListEvent(_ context.Context, event ListEvent) (int64, error) {
+	event.Seq = int64(len(m.events) + 1)
+	m.events = append(m.events, event)
+	return event.Seq, nil
+}
+
+func (m *memoryHistory) AppendListEvents(ctx context.Context, events []ListEvent) error {
+	for _, event := range events {
+		if _, err := m.AppendListEvent(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryHistory) ListEvents(_ context.Context, listID uuid.UUID, limit int) ([]ListEvent, error) {
+	var events []ListEvent
+	for i := len(m.events) - 1; i >= 0 && len(events) < limit; i-- {
+		if m.events[i].ListID == listID {
+			events = append(events, m.events[i])
+		}
+	}
+	return events, nil
+}
+
+func (m *memoryHistory) GetListEvent(_ context.Context, listID, id uuid.UUID) (ListEvent, error) {
+	for _, event := range m.events {
+		if event.ListID == listID && event.ID == id {
+			return event, nil
+		}
+	}
+	return ListEvent{}, ErrListEventNotFound
+}
+
+func (m *memoryHistory) ListEventsAfter(_ context.Context, listID uuid.UUID, seq int64) ([]ListEvent, error) {
+	var events []ListEvent
+	for _, event := range m.events {
+		if event.ListID == listID && event.Seq > seq {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (m *memoryHistory) LastUndoableEvent(_ context.Context, listID, actor uuid.UUID) (ListEvent, error) {
+	for i := len(m.events) - 1; i >= 0; i-- {
+		event := m.events[i]
+		if event.ListID != listID || event.ActorID == nil || *event.ActorID != actor || event.Reverts != nil {
+			continue
+		}
+		if !slices.ContainsFunc(m.events, func(e ListEvent) bool { return e.Reverts != nil && *e.Reverts == event.ID }) {
+			return event, nil
+		}
+	}
+	return ListEvent{}, ErrNothingToUndo
+}
+
+type memoryCategories struct {
+	chosen map[string]string
+}
+
+func (m *memoryCategories) HouseholdCategory(_ context.Context, householdID uuid.UUID, name string) (string, error) {
+	return m.chosen[householdID.String()+"/"+name], nil
+}
+
+func (m *memoryCategories) HouseholdCategories(_ context.Context, householdID uuid.UUID, names []string) (map[string]string, error) {
+	chosen := make(map[string]string)
+	for _, name := range names {
+		if category, ok := m.chosen[householdID.String()+"/"+name]; ok {
+			chosen[name] = category
+		}
+	}
+	return chosen, nil
+}
+
+func (m *memoryCategories) SaveHouseholdCategory(_ context.Context, householdID uuid.UUID, name, category string, _ time.Time) error {
+	m.chosen[householdID.String()+"/"+name] = category
+	return nil
+}
+
+type memoryPantry struct {
+	PantryStorage
+	items []PantryItem
+}
+
+func (m *memoryPantry) PantryItemsByName(_ context.Context, householdID uuid.UUID, name string) ([]PantryItem, error) {
+	var items []PantryItem
+	for _, item := range m.items {
+		if item.HouseholdID == householdID && strings.EqualFold(item.Name, name) {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (m *memoryPantry) CreatePantryItem(_ context.Context, item PantryItem) error {
+	m.items = append(m.items, item)
+	return nil
+}
+
+func (m *memoryPantry) UpdatePantryItem(_ context.Context, item PantryItem) error {
+	for i := range m.items {
+		if m.items[i].ID == item.ID {
+			m.items[i] = item
+		}
+	}
+	return nil
+}
+
+// household is a household with a member of each role and one list, served
+// by a ListService over memory storage.
+type household struct {
+	id                    uuid.UUID
+	owner, editor, viewer uuid.UUID
+	list                  List
+	lists                 *memoryLists
+	members               *memoryHouseholds
+	history               *memoryHistory
+	pantry                *memoryPantry
+	service               *ListService
+}
+
+func newHousehold(t *testing.T) *household {
+	t.Helper()
+	h := &household{
+		id:      uuid.New(),
+		owner:   uuid.New(),
+		editor:  uuid.New(),
+		viewer:  uuid.New(),
+		lists:   newMemoryLists(),
+		members: &memoryHouseholds{},
+		history: &memoryHistory{},
+		pantry:  &memoryPantry{},
+	}
+	for user, role := range map[uuid.UUID]Role{h.owner: RoleOwner, h.editor: RoleEditor, h.viewer: RoleViewer} {
+		h.members.members = append(h.members.members, Member{HouseholdID: h.id, UserID: user, Role: role})
+	}
+	h.list = List{ID: uuid.New(), HouseholdID: h.id, Name: "Groceries"}
+	h.lists.lists[h.list.ID] = h.list
+
+	categorizer := NewCategorizer(categories.Default(), &memoryCategories{chosen: make(map[string]string)})
+	h.service = NewListService(h.lists, h.members, nil, h.pantry, nil, h.history, categorizer)
+	return h
+}
+
+// add puts an item on the household's list as its editor.
+func (h *household) add(t *testing.T, name string) Item {
+	t.Helper()
+	added, err := h.service.AddItem(context.Background(), h.editor, h.list.ID, NewItem{Name: name}, 1)
+	if err != nil {
+		t.Fatalf("AddItem(%q) = %v", name, err)
+	}
+	return added.Item
+}
@@ -0,0 +1,264 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// ListHistoryStorage keeps the append-only log of changes to list items.
+type ListHistoryStorage interface {
+	// AppendListEvent stores the event and returns its sequence number.
+	AppendListEvent(ctx context.Context, event ListEvent) (int64, error)
+	// AppendListEvents stores the events in order.
+	AppendListEvents(ctx context.Context, events []ListEvent) error
+	// ListEvents returns up to limit of the list's events, newest first.
+	ListEvents(ctx context.Context, listID uuid.UUID, limit int) ([]ListEvent, error)
+	GetListEvent(ctx context.Context, listID, id uuid.UUID) (ListEvent, error)
+	// ListEventsAfter returns the list's events that came after seq, oldest first.
+	ListEventsAfter(ctx context.Context, listID uuid.UUID, seq int64) ([]ListEvent, error)
+	// LastUndoableEvent returns the actor's newest event on the list that
+	// neither undoes another event nor has been undone itself.
+	LastUndoableEvent(ctx context.Context, listID, actor uuid.UUID) (ListEvent, error)
+}
+
+// History returns the list's most recent changes, newest first.
+func (s *ListService) History(ctx context.Context, actor, listID uuid.UUID, limit int) ([]ListEvent, error) {
+	if limit < 1 || limit > MaxHistoryLimit {
+		return nil, ErrInvalidLimit
+	}
+	if _, err := s.authorize(ctx, actor, listID, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return s.history.ListEvents(ctx, listID, limit)
+}
+
+// Revert undoes every change made to the list after the given event, newest
+// first, which brings the items back to how they were right after it. The
+// undoing is recorded as new events, which are returned.
+func (s *ListService) Revert(ctx context.Context, actor, listID, to uuid.UUID) ([]ListEvent, error) {
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := s.history.GetListEvent(ctx, listID, to)
+	if err != nil {
+		return nil, err
+	}
+	events, err := s.history.ListEventsAfter(ctx, listID, target.Seq)
+	if err != nil {
+		return nil, err
+	}
+
+	reverted := make([]ListEvent, 0, len(events))
+	for i := len(events) - 1; i >= 0; i-- {
+		event, err := s.undo(ctx, actor, list, events[i])
+		if err != nil {
+			return nil, err
+		}
+		reverted = append(reverted, event)
+	}
+
+	return reverted, nil
+}
+
+// Undo undoes the actor's last change to the list that is not undone yet.
+// Undoing repeatedly walks further back through the actor's changes.
+func (s *ListService) Undo(ctx context.Context, actor, listID uuid.UUID) (ListEvent, error) {
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return ListEvent{}, err
+	}
+
+	event, err := s.history.LastUndoableEvent(ctx, listID, actor)
+	if err != nil {
+		return ListEvent{}, err
+	}
+
+	return s.undo(ctx, actor, list, event)
+}
+
+// ReorderItems moves the list's items into the given order.
+func (s *ListService) ReorderItems(ctx context.Context, actor, listID uuid.UUID, in ReorderInput) ([]Item, error) {
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.storage.ListItems(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+	if len(in.ItemIDs) != len(items) {
+		return nil, ErrInvalidOrder
+	}
+	named := make(map[uuid.UUID]bool, len(in.ItemIDs))
+	for _, id := range in.ItemIDs {
+		named[id] = true
+	}
+	for _, item := range items {
+		if !named[item.ID] {
+			return nil, ErrInvalidOrder
+		}
+	}
+
+	order, items, err := s.arrange(ctx, items, in.ItemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.record(ctx, actor, list, ListEvent{Type: ItemsReordered, Order: &order}); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// arrange gives the items, which are in list order, the positions of the
+// given order. Items the order does not name keep their relative order after
+// the named ones.
+func (s *ListService) arrange(ctx context.Context, items []Item, ids []uuid.UUID) (ItemOrder, []Item, error) {
+	rank := make(map[uuid.UUID]int, len(ids))
+	for i, id := range ids {
+		rank[id] = i
+	}
+
+	order := ItemOrder{Before: make([]uuid.UUID, 0, len(items)), After: make([]uuid.UUID, 0, len(items))}
+	for _, item := range items {
+		order.Before = append(order.Before, item.ID)
+	}
+	sort.SliceStable(items, func(a, b int) bool {
+		ra, okA := rank[items[a].ID]
+		rb, okB := rank[items[b].ID]
+		if okA && okB {
+			return ra < rb
+		}
+		return okA && !okB
+	})
+
+	now := time.Now()
+	for i := range items {
+		order.After = append(order.After, items[i].ID)
+		if items[i].Position == i {
+			continue
+		}
+		items[i].Position = i
+		items[i].UpdatedAt = now
+		if err := s.storage.UpdateItem(ctx, items[i]); err != nil {
+			return ItemOrder{}, nil, err
+		}
+	}
+
+	return order, items, nil
+}
+
+// undo applies the opposite of the event to the list as it is now and records
+// that as a new event. A change whose item is gone has nothing left to undo;
+// it is still recorded so that it is not undone again.
+func (s *ListService) undo(ctx context.Context, actor uuid.UUID, list List, event ListEvent) (ListEvent, error) {
+	inverse, err := s.invert(ctx, list, event)
+	if err != nil {
+		return ListEvent{}, err
+	}
+	if inverse.ItemID == nil {
+		inverse.ItemID = event.ItemID
+	}
+	inverse.Reverts = &event.ID
+
+	return s.record(ctx, actor, list, inverse)
+}
+
+func (s *ListService) invert(ctx context.Context, list List, event ListEvent) (ListEvent, error) {
+	if event.Type == ItemsReordered {
+		items, err := s.storage.ListItems(ctx, list.ID)
+		if err != nil {
+			return ListEvent{}, err
+		}
+		order, _, err := s.arrange(ctx, items, event.Order.Before)
+		if err != nil {
+			return ListEvent{}, err
+		}
+		return ListEvent{Type: ItemsReordered, Order: &order}, nil
+	}
+
+	if event.Type == ItemRemoved {
+		// An item that is back already has nothing to restore.
+		if _, err := s.storage.GetItem(ctx, list.ID, *event.ItemID); !errors.Is(err, ErrItemNotFound) {
+			return ListEvent{Type: ItemAdded}, err
+		}
+		item := *event.Before
+		item.UpdatedAt = time.Now()
+		if err := s.putBack(ctx, item); err != nil {
+			return ListEvent{}, err
+		}
+		return itemEvent(ItemAdded, nil, &item), nil
+	}
+
+	item, err := s.storage.GetItem(ctx, list.ID, *event.ItemID)
+	if errors.Is(err, ErrItemNotFound) {
+		return ListEvent{Type: opposite(event.Type)}, nil
+	}
+	if err != nil {
+		return ListEvent{}, err
+	}
+	before := item
+
+	switch event.Type {
+	case ItemAdded:
+		if err := s.storage.DeleteItem(ctx, list.ID, item.ID); err != nil {
+			return ListEvent{}, err
+		}
+		return itemEvent(ItemRemoved, &before, nil), nil
+	case ItemChecked, ItemUnchecked:
+		if item, err = s.check(ctx, list, item, event.Type == ItemUnchecked); err != nil {
+			return ListEvent{}, err
+		}
+	default:
+		item.Name = event.Before.Name
+		item.Quantity = event.Before.Quantity
+		item.Note = event.Before.Note
+		item.Category = event.Before.Category
+		item.UpdatedAt = time.Now()
+		if err := s.storage.UpdateItem(ctx, item); err != nil {
+			return ListEvent{}, err
+		}
+	}
+
+	return itemEvent(opposite(event.Type), &before, &item), nil
+}
+
+func opposite(eventType ListEventType) ListEventType {
+	switch eventType {
+	case ItemAdded:
+		return ItemRemoved
+	case ItemRemoved:
+		return ItemAdded
+	case ItemChecked:
+		return ItemUnchecked
+	case ItemUnchecked:
+		return ItemChecked
+	}
+	return eventType
+}
+
+// record appends a change to the list's history. The nil actor stands for
+// the scheduler.
+func (s *ListService) record(ctx context.Context, actor uuid.UUID, list List, event ListEvent) (ListEvent, error) {
+	event.ID = uuid.New()
+	event.ListID = list.ID
+	if actor != uuid.Nil {
+		event.ActorID = &actor
+	}
+	event.OccurredAt = time.Now()
+
+	seq, err := s.history.AppendListEvent(ctx, event)
+	if err != nil {
+		return ListEvent{}, err
+	}
+	event.Seq = seq
+
+	return event, nil
+}
@@ -0,0 +1,153 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestUndoWalksBackThroughActorChanges(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	if _, err := h.service.CheckItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+	note := "semi-skimmed"
+	if _, err := h.service.EditItem(ctx, h.editor, h.list.ID, milk.ID, ItemUpdate{Note: &note}); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		undone ListEventType
+		want   ListEventType
+		check  func(item Item, found bool) bool
+	}{
+		{ItemEdited, ItemEdited, func(item Item, found bool) bool { return found && item.Note == "" && item.Checked }},
+		{ItemChecked, ItemUnchecked, func(item Item, found bool) bool { return found && !item.Checked && item.CheckedAt == nil }},
+		{ItemAdded, ItemRemoved, func(_ Item, found bool) bool { return !found }},
+	}
+	for _, step := range steps {
+		event, err := h.service.Undo(ctx, h.editor, h.list.ID)
+		if err != nil {
+			t.Fatalf("Undo() of %s = %v", step.undone, err)
+		}
+		if event.Type != step.want || event.Reverts == nil {
+			t.Errorf("Undo() of %s recorded %s reverting %v, want %s", step.undone, event.Type, event.Reverts, step.want)
+		}
+		reverted, _ := h.history.GetListEvent(ctx, h.list.ID, *event.Reverts)
+		if reverted.Type != step.undone {
+			t.Errorf("Undo() reverted a %s event, want %s", reverted.Type, step.undone)
+		}
+		item, found := h.lists.items[milk.ID]
+		if !step.check(item, found) {
+			t.Errorf("after undoing %s the item is %+v (on the list: %v)", step.undone, item, found)
+		}
+	}
+
+	if _, err := h.service.Undo(ctx, h.editor, h.list.ID); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() with every change undone = %v, want ErrNothingToUndo", err)
+	}
+}
+
+// Checking an item off stocks the pantry; undoing it takes the stock back out.
+func TestUndoCheckReturnsPantryStock(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	if _, err := h.service.CheckItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+	if len(h.pantry.items) != 1 || h.pantry.items[0].Quantity.Value != 1 {
+		t.Fatalf("pantry = %+v, want one milk", h.pantry.items)
+	}
+
+	if _, err := h.service.Undo(ctx, h.editor, h.list.ID); err != nil {
+		t.Fatal(err)
+	}
+	if h.pantry.items[0].Quantity.Value != 0 {
+		t.Errorf("pantry milk = %v after undoing the check-off, want 0", h.pantry.items[0].Quantity)
+	}
+}
+
+func TestUndoRemovePutsItemBack(t *testing.T) {
+	for _, purged := range []bool{false, true} {
+		ctx := context.Background()
+		h := newHousehold(t)
+		milk := h.add(t, "Milk")
+		if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+			t.Fatal(err)
+		}
+		if purged {
+			delete(h.lists.trash, milk.ID)
+		}
+
+		event, err := h.service.Undo(ctx, h.editor, h.list.ID)
+		if err != nil {
+			t.Fatalf("Undo() with the trash purged: %v = %v", purged, err)
+		}
+		if event.Type != ItemAdded || event.After == nil || event.After.ID != milk.ID {
+			t.Errorf("Undo() with the trash purged: %v recorded %+v, want milk added", purged, event)
+		}
+		if item, ok := h.lists.items[milk.ID]; !ok || item.Name != "Milk" {
+			t.Errorf("with the trash purged: %v the item is %+v (on the list: %v)", purged, item, ok)
+		}
+	}
+}
+
+// Members undo their own changes, not the ones others made since.
+func TestUndoSkipsOtherActors(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	if _, err := h.service.CheckItem(ctx, h.owner, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := h.service.Undo(ctx, h.editor, h.list.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != ItemRemoved {
+		t.Errorf("Undo() recorded %s, want the editor's add undone", event.Type)
+	}
+	if _, err := h.service.Undo(ctx, h.editor, h.list.ID); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Undo() = %v, want ErrNothingToUndo", err)
+	}
+}
+
+func TestRevertRestoresStateAfterEvent(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	added := h.history.events[len(h.history.events)-1]
+	bread := h.add(t, "Bread")
+	if _, err := h.service.CheckItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+	name := "Oat milk"
+	if _, err := h.service.EditItem(ctx, h.owner, h.list.ID, milk.ID, ItemUpdate{Name: &name}); err != nil {
+		t.Fatal(err)
+	}
+
+	reverted, err := h.service.Revert(ctx, h.editor, h.list.ID, added.ID)
+	if err != nil {
+		t.Fatalf("Revert() = %v", err)
+	}
+
+	want := []ListEventType{ItemEdited, ItemUnchecked, ItemRemoved}
+	if len(reverted) != len(want) {
+		t.Fatalf("Revert() recorded %d events, want %d", len(reverted), len(want))
+	}
+	for i, event := range reverted {
+		if event.Type != want[i] {
+			t.Errorf("event %d is %s, want %s", i, event.Type, want[i])
+		}
+	}
+	if item := h.lists.items[milk.ID]; item.Name != "Milk" || item.Checked {
+		t.Errorf("milk = %+v, want unchecked and named Milk again", item)
+	}
+	if _, ok := h.lists.items[bread.ID]; ok {
+		t.Error("bread is still on the list")
+	}
+}
@@ -0,0 +1,68 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ListEventType string
+
+const (
+	ItemAdded      ListEventType = "item_added"
+	ItemEdited     ListEventType = "item_edited"
+	ItemChecked    ListEventType = "item_checked"
+	ItemUnchecked  ListEventType = "item_unchecked"
+	ItemRemoved    ListEventType = "item_removed"
+	ItemsReordered ListEventType = "items_reordered"
+)
+
+// DefaultHistoryLimit and MaxHistoryLimit bound how many events a history
+// request returns.
+const (
+	DefaultHistoryLimit = 100
+	MaxHistoryLimit     = 500
+)
+
+// ListEvent is one change to a list's items, kept in an append-only log. Before
+// and After are the item as it was around the change; Before is nil when the
+// item was added and After when it was removed. A reorder has Order instead.
+// Undoing or reverting changes appends events whose Reverts names the event
+// they undid; ActorID is nil for changes made by the scheduler.
+type ListEvent struct {
+	ID         uuid.UUID     `json:"id"`
+	Seq        int64         `json:"seq"`
+	ListID     uuid.UUID     `json:"list_id"`
+	ActorID    *uuid.UUID    `json:"actor_id"`
+	Type       ListEventType `json:"type"`
+	ItemID     *uuid.UUID    `json:"item_id,omitempty"`
+	Before     *Item         `json:"before,omitempty"`
+	After      *Item         `json:"after,omitempty"`
+	Order      *ItemOrder    `json:"order,omitempty"`
+	Reverts    *uuid.UUID    `json:"reverts,omitempty"`
+	OccurredAt time.Time     `json:"occurred_at"`
+}
+
+// ItemOrder is the order of a list's item IDs before and after a reorder.
+type ItemOrder struct {
+	Before []uuid.UUID `json:"before"`
+	After  []uuid.UUID `json:"after"`
+}
+
+// ReorderInput is the new order of a list's items; it must name every item
+// on the list exactly once.
+type ReorderInput struct {
+	ItemIDs []uuid.UUID `json:"item_ids"`
+}
+
+func itemEvent(eventType ListEventType, before, after *Item) ListEvent {
+	event := ListEvent{Type: eventType, Before: before, After: after}
+	item := after
+	if item == nil {
+		item = before
+	}
+	if item != nil {
+		id := item.ID
+		event.ListID, event.ItemID = item.ListID, &id
+	}
+	return event
+}
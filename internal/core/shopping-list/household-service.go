@@ -0,0 +1,193 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// HouseholdStorage persists households and their memberships.
+type HouseholdStorage interface {
+	UserHouseholds(ctx context.Context, userID uuid.UUID) ([]Household, error)
+	GetHousehold(ctx context.Context, id uuid.UUID) (Household, error)
+	CreateHousehold(ctx context.Context, household Household) error
+
+	Members(ctx context.Context, householdID uuid.UUID) ([]Member, error)
+	// MemberRole returns ErrNotMember when the user does not belong to the household.
+	MemberRole(ctx context.Context, householdID, userID uuid.UUID) (Role, error)
+	SaveMember(ctx context.Context, member Member) error
+	DeleteMember(ctx context.Context, householdID, userID uuid.UUID) error
+}
+
+type HouseholdService struct {
+	storage   HouseholdStorage
+	publisher EventPublisher
+}
+
+func NewHouseholdService(storage HouseholdStorage, publisher EventPublisher) *HouseholdService {
+	return &HouseholdService{
+		storage:   storage,
+		publisher: publisher,
+	}
+}
+
+// requireRole fails unless actor is a member of the household with at least the required role.
+func requireRole(ctx context.Context, storage HouseholdStorage, householdID, actor uuid.UUID, required Role) error {
+	role, err := storage.MemberRole(ctx, householdID, actor)
+	if err != nil {
+		return err
+	}
+	if !role.Allows(required) {
+		return fmt.Errorf("%w: %s role required", ErrForbidden, required)
+	}
+	return nil
+}
+
+func (s *HouseholdService) Households(ctx context.Context, actor uuid.UUID) ([]Household, error) {
+	return s.storage.UserHouseholds(ctx, actor)
+}
+
+// Household returns the household with its members.
+func (s *HouseholdService) Household(ctx context.Context, actor, id uuid.UUID) (Household, error) {
+	if err := requireRole(ctx, s.storage, id, actor, RoleViewer); err != nil {
+		return Household{}, err
+	}
+
+	household, err := s.storage.GetHousehold(ctx, id)
+	if err != nil {
+		return Household{}, err
+	}
+
+	household.Members, err = s.storage.Members(ctx, id)
+	if err != nil {
+		return Household{}, err
+	}
+
+	return household, nil
+}
+
+// CreateHousehold creates a household owned by actor.
+func (s *HouseholdService) CreateHousehold(ctx context.Context, actor uuid.UUID, name string) (Household, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Household{}, ErrEmptyName
+	}
+
+	now := time.Now()
+	household := Household{
+		ID:        uuid.New(),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.storage.CreateHousehold(ctx, household); err != nil {
+		return Household{}, err
+	}
+
+	owner := Member{
+		HouseholdID: household.ID,
+		UserID:      actor,
+		Role:        RoleOwner,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.storage.SaveMember(ctx, owner); err != nil {
+		return Household{}, err
+	}
+	household.Members = []Member{owner}
+
+	return household, nil
+}
+
+// AddMember adds userID to the household, or changes their role if they already belong to it.
+func (s *HouseholdService) AddMember(ctx context.Context, actor, householdID, userID uuid.UUID, role Role) (Member, error) {
+	if !role.Valid() {
+		return Member{}, ErrInvalidRole
+	}
+	if err := requireRole(ctx, s.storage, householdID, actor, RoleOwner); err != nil {
+		return Member{}, err
+	}
+
+	current, err := s.storage.MemberRole(ctx, householdID, userID)
+	joined := errors.Is(err, ErrNotMember)
+	switch {
+	case err == nil:
+		if current == RoleOwner && role != RoleOwner {
+			if err := s.ensureOtherOwner(ctx, householdID, userID); err != nil {
+				return Member{}, err
+			}
+		}
+	case !joined:
+		return Member{}, err
+	}
+
+	now := time.Now()
+	member := Member{
+		HouseholdID: householdID,
+		UserID:      userID,
+		Role:        role,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.storage.SaveMember(ctx, member); err != nil {
+		return Member{}, err
+	}
+
+	if joined {
+		err = notifyHousehold(ctx, s.storage, s.publisher, Event{
+			Type:        EventMemberJoined,
+			HouseholdID: householdID,
+			ActorID:     actor,
+			Data:        member,
+			OccurredAt:  now,
+		})
+		if err != nil {
+			return Member{}, err
+		}
+	}
+
+	return member, nil
+}
+
+// RemoveMember removes userID from the household. Owners can remove anyone;
+// other members can only remove themselves.
+func (s *HouseholdService) RemoveMember(ctx context.Context, actor, householdID, userID uuid.UUID) error {
+	required := RoleOwner
+	if actor == userID {
+		required = RoleViewer
+	}
+	if err := requireRole(ctx, s.storage, householdID, actor, required); err != nil {
+		return err
+	}
+
+	role, err := s.storage.MemberRole(ctx, householdID, userID)
+	if errors.Is(err, ErrNotMember) {
+		return ErrMemberNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if role == RoleOwner {
+		if err := s.ensureOtherOwner(ctx, householdID, userID); err != nil {
+			return err
+		}
+	}
+
+	return s.storage.DeleteMember(ctx, householdID, userID)
+}
+
+func (s *HouseholdService) ensureOtherOwner(ctx context.Context, householdID, userID uuid.UUID) error {
+	members, err := s.storage.Members(ctx, householdID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.Role == RoleOwner && m.UserID != userID {
+			return nil
+		}
+	}
+	return ErrLastOwner
+}
@@ -0,0 +1,65 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+)
+
+type discardPublisher struct{}
+
+func (discardPublisher) Publish(context.Context, []uuid.UUID, Event) {}
+
+func TestHouseholdServiceMembership(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name string
+		do   func(s *HouseholdService, h *household) error
+		want error
+	}{
+		{"owner adds a member", func(s *HouseholdService, h *household) error {
+			_, err := s.AddMember(ctx, h.owner, h.id, uuid.New(), RoleEditor)
+			return err
+		}, nil},
+		{"editor adds a member", func(s *HouseholdService, h *household) error {
+			_, err := s.AddMember(ctx, h.editor, h.id, uuid.New(), RoleViewer)
+			return err
+		}, ErrForbidden},
+		{"non-member adds a member", func(s *HouseholdService, h *household) error {
+			_, err := s.AddMember(ctx, uuid.New(), h.id, uuid.New(), RoleViewer)
+			return err
+		}, ErrNotMember},
+		{"owner adds an unknown role", func(s *HouseholdService, h *household) error {
+			_, err := s.AddMember(ctx, h.owner, h.id, uuid.New(), "admin")
+			return err
+		}, ErrInvalidRole},
+		{"owner demotes the last owner", func(s *HouseholdService, h *household) error {
+			_, err := s.AddMember(ctx, h.owner, h.id, h.owner, RoleEditor)
+			return err
+		}, ErrLastOwner},
+		{"owner removes a member", func(s *HouseholdService, h *household) error {
+			return s.RemoveMember(ctx, h.owner, h.id, h.editor)
+		}, nil},
+		{"viewer leaves", func(s *HouseholdService, h *household) error {
+			return s.RemoveMember(ctx, h.viewer, h.id, h.viewer)
+		}, nil},
+		{"editor removes a member", func(s *HouseholdService, h *household) error {
+			return s.RemoveMember(ctx, h.editor, h.id, h.viewer)
+		}, ErrForbidden},
+		{"last owner leaves", func(s *HouseholdService, h *household) error {
+			return s.RemoveMember(ctx, h.owner, h.id, h.owner)
+		}, ErrLastOwner},
+		{"owner removes a non-member", func(s *HouseholdService, h *household) error {
+			return s.RemoveMember(ctx, h.owner, h.id, uuid.New())
+		}, ErrMemberNotFound},
+	}
+
+	for _, tt := range tests {
+		h := newHousehold(t)
+		s := NewHouseholdService(h.members, discardPublisher{})
+		if err := tt.do(s, h); !errors.Is(err, tt.want) || (tt.want == nil && err != nil) {
+			t.Errorf("%s = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
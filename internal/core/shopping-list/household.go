@@ -0,0 +1,47 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Role is a member's permission level within a household.
+type Role string
+
+const (
+	RoleOwner  Role = "owner"
+	RoleEditor Role = "editor"
+	RoleViewer Role = "viewer"
+)
+
+var roleRank = map[Role]int{
+	RoleViewer: 1,
+	RoleEditor: 2,
+	RoleOwner:  3,
+}
+
+func (r Role) Valid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// Allows reports whether r grants at least the permissions of required.
+func (r Role) Allows(required Role) bool {
+	return roleRank[r] >= roleRank[required]
+}
+
+type Household struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Members   []Member  `json:"members,omitempty" db:"-"`
+}
+
+type Member struct {
+	HouseholdID uuid.UUID `json:"household_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Role        Role      `json:"role"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,192 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/signedtoken"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	DefaultInviteTTL = 7 * 24 * time.Hour
+	MaxInviteTTL     = 30 * 24 * time.Hour
+
+	// DefaultInviteUses is how many times an invite can be redeemed when it
+	// is created without max_uses.
+	DefaultInviteUses = 1
+)
+
+// InviteStorage persists invites and their redemptions.
+type InviteStorage interface {
+	HouseholdInvites(ctx context.Context, householdID uuid.UUID) ([]Invite, error)
+	GetInvite(ctx context.Context, id uuid.UUID) (Invite, error)
+	CreateInvite(ctx context.Context, invite Invite) error
+	RevokeInvite(ctx context.Context, householdID, id uuid.UUID, at time.Time) error
+	// UseInvite atomically counts one redemption. It reports false when the
+	// invite is revoked, expired or has no uses left at the given time.
+	UseInvite(ctx context.Context, id uuid.UUID, at time.Time) (bool, error)
+	SaveRedemption(ctx context.Context, inviteID, userID uuid.UUID, at time.Time) error
+}
+
+type InviteService struct {
+	storage    InviteStorage
+	households HouseholdStorage
+	lists      ListStorage
+	publisher  EventPublisher
+	signer     *signedtoken.Signer
+}
+
+func NewInviteService(storage InviteStorage, households HouseholdStorage, lists ListStorage, publisher EventPublisher, signer *signedtoken.Signer) *InviteService {
+	return &InviteService{
+		storage:    storage,
+		households: households,
+		lists:      lists,
+		publisher:  publisher,
+		signer:     signer,
+	}
+}
+
+// Invites returns the household's invites, including expired and revoked ones.
+func (s *InviteService) Invites(ctx context.Context, actor, householdID uuid.UUID) ([]Invite, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleOwner); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.storage.HouseholdInvites(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range invites {
+		invites[i].Token = s.signer.Sign(invites[i].ID)
+	}
+
+	return invites, nil
+}
+
+// CreateInvite issues a signed invite token for the household. Only owners can invite.
+func (s *InviteService) CreateInvite(ctx context.Context, actor, householdID uuid.UUID, in NewInvite) (Invite, error) {
+	if !in.Role.Valid() {
+		return Invite{}, ErrInvalidRole
+	}
+	maxUses := DefaultInviteUses
+	if in.MaxUses != nil {
+		maxUses = *in.MaxUses
+	}
+	if maxUses < 0 {
+		return Invite{}, ErrInvalidMaxUses
+	}
+	ttl := DefaultInviteTTL
+	if in.ExpiresIn != 0 {
+		ttl = time.Duration(in.ExpiresIn) * time.Second
+	}
+	if ttl <= 0 || ttl > MaxInviteTTL {
+		return Invite{}, ErrInvalidExpiry
+	}
+
+	if err := requireRole(ctx, s.households, householdID, actor, RoleOwner); err != nil {
+		return Invite{}, err
+	}
+	if in.ListID != nil {
+		list, err := s.lists.GetList(ctx, *in.ListID)
+		if err != nil {
+			return Invite{}, err
+		}
+		if list.HouseholdID != householdID {
+			return Invite{}, ErrListNotFound
+		}
+	}
+
+	now := time.Now()
+	invite := Invite{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		ListID:      in.ListID,
+		Role:        in.Role,
+		CreatedBy:   actor,
+		MaxUses:     maxUses,
+		ExpiresAt:   now.Add(ttl),
+		CreatedAt:   now,
+	}
+	if err := s.storage.CreateInvite(ctx, invite); err != nil {
+		return Invite{}, err
+	}
+	invite.Token = s.signer.Sign(invite.ID)
+
+	return invite, nil
+}
+
+func (s *InviteService) RevokeInvite(ctx context.Context, actor, householdID, inviteID uuid.UUID) error {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleOwner); err != nil {
+		return err
+	}
+	return s.storage.RevokeInvite(ctx, householdID, inviteID, time.Now())
+}
+
+// Accept redeems the invite token for actor. Members who already hold the
+// invite's role or a higher one keep their role and do not use up the invite.
+func (s *InviteService) Accept(ctx context.Context, actor uuid.UUID, token string) (AcceptedInvite, error) {
+	id, err := s.signer.Verify(token)
+	if err != nil {
+		return AcceptedInvite{}, ErrInviteNotFound
+	}
+
+	invite, err := s.storage.GetInvite(ctx, id)
+	if err != nil {
+		return AcceptedInvite{}, err
+	}
+
+	now := time.Now()
+	switch {
+	case invite.RevokedAt != nil:
+		return AcceptedInvite{}, ErrInviteRevoked
+	case !now.Before(invite.ExpiresAt):
+		return AcceptedInvite{}, ErrInviteExpired
+	}
+
+	current, err := s.households.MemberRole(ctx, invite.HouseholdID, actor)
+	switch {
+	case err == nil && current.Allows(invite.Role):
+		return AcceptedInvite{
+			Member: Member{HouseholdID: invite.HouseholdID, UserID: actor, Role: current},
+			ListID: invite.ListID,
+		}, nil
+	case err != nil && !errors.Is(err, ErrNotMember):
+		return AcceptedInvite{}, err
+	}
+
+	ok, err := s.storage.UseInvite(ctx, invite.ID, now)
+	if err != nil {
+		return AcceptedInvite{}, err
+	}
+	if !ok {
+		return AcceptedInvite{}, ErrInviteUsedUp
+	}
+
+	member := Member{
+		HouseholdID: invite.HouseholdID,
+		UserID:      actor,
+		Role:        invite.Role,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.households.SaveMember(ctx, member); err != nil {
+		return AcceptedInvite{}, err
+	}
+	if err := s.storage.SaveRedemption(ctx, invite.ID, actor, now); err != nil {
+		return AcceptedInvite{}, err
+	}
+
+	err = notifyHousehold(ctx, s.households, s.publisher, Event{
+		Type:        EventMemberJoined,
+		HouseholdID: invite.HouseholdID,
+		ActorID:     actor,
+		Data:        member,
+		OccurredAt:  now,
+	})
+	if err != nil {
+		return AcceptedInvite{}, err
+	}
+
+	return AcceptedInvite{Member: member, ListID: invite.ListID}, nil
+}
@@ -0,0 +1,51 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/signedtoken"
+	"github.com/google/uuid"
+	"testing"
+)
+
+type memoryInvites struct {
+	InviteStorage
+	invites map[uuid.UUID]Invite
+}
+
+func (m *memoryInvites) CreateInvite(_ context.Context, invite Invite) error {
+	m.invites[invite.ID] = invite
+	return nil
+}
+
+// An invite is single-use unless it says otherwise, and only unlimited when
+// it asks to be.
+func TestCreateInviteMaxUses(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	invites := &memoryInvites{invites: make(map[uuid.UUID]Invite)}
+	s := NewInviteService(invites, h.members, h.lists, discardPublisher{}, signedtoken.New([]byte("test")))
+	uses := func(n int) *int { return &n }
+
+	tests := []struct {
+		name    string
+		maxUses *int
+		want    int
+		err     error
+	}{
+		{"omitted", nil, 1, nil},
+		{"0", uses(0), 0, nil},
+		{"5", uses(5), 5, nil},
+		{"-1", uses(-1), 0, ErrInvalidMaxUses},
+	}
+	for _, tt := range tests {
+		invite, err := s.CreateInvite(ctx, h.owner, h.id, NewInvite{Role: RoleEditor, MaxUses: tt.maxUses})
+		if !errors.Is(err, tt.err) || (tt.err == nil && err != nil) {
+			t.Errorf("CreateInvite() with max uses %s = %v, want %v", tt.name, err, tt.err)
+			continue
+		}
+		if err == nil && (invite.MaxUses != tt.want || invites.invites[invite.ID].MaxUses != tt.want) {
+			t.Errorf("CreateInvite() with max uses %s = %d uses, stored %d, want %d", tt.name, invite.MaxUses, invites.invites[invite.ID].MaxUses, tt.want)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Invite lets its holder join a household with a preset role. MaxUses of zero
+// means the invite can be redeemed any number of times until it expires.
+type Invite struct {
+	ID          uuid.UUID  `json:"id"`
+	HouseholdID uuid.UUID  `json:"household_id"`
+	ListID      *uuid.UUID `json:"list_id,omitempty"`
+	Role        Role       `json:"role"`
+	CreatedBy   uuid.UUID  `json:"created_by"`
+	MaxUses     int        `json:"max_uses"`
+	Uses        int        `json:"uses"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	RevokedAt   *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	Token       string     `json:"token,omitempty" db:"-"`
+}
+
+type NewInvite struct {
+	ListID    *uuid.UUID `json:"list_id"`
+	Role      Role       `json:"role"`
+	MaxUses   *int       `json:"max_uses"`   // DefaultInviteUses when omitted, 0 for unlimited
+	ExpiresIn int        `json:"expires_in"` // seconds
+}
+
+// AcceptedInvite is the result of redeeming an invite. ListID is set when the
+// invite was shared from a specific list.
+type AcceptedInvite struct {
+	Member Member     `json:"member"`
+	ListID *uuid.UUID `json:"list_id,omitempty"`
+}
@@ -1,4 +1,717 @@
 package shopping_list
 
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/fuzzy"
+	"github.com/PocketPalCo/shopping-service/pkg/itemparser"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ListStorage persists lists and their items.
+type ListStorage interface {
+	UserLists(ctx context.Context, userID uuid.UUID) ([]List, error)
+	GetList(ctx context.Context, id uuid.UUID) (List, error)
+	// LatestHouseholdList returns the household's most recently created list.
+	LatestHouseholdList(ctx context.Context, householdID uuid.UUID) (List, error)
+	CreateList(ctx context.Context, list List) error
+	UpdateList(ctx context.Context, list List) error
+	// DeleteList moves the list to its household's trash.
+	DeleteList(ctx context.Context, id uuid.UUID) error
+	// RestoreList takes the list out of the trash; ErrListNotFound when it is
+	// not there.
+	RestoreList(ctx context.Context, id uuid.UUID) error
+
+	ListItems(ctx context.Context, listID uuid.UUID) ([]Item, error)
+	// CheckedItems returns the items of the household's lists checked off
+	// since the given time, most recently checked first.
+	CheckedItems(ctx context.Context, householdID uuid.UUID, since time.Time) ([]Item, error)
+	GetItem(ctx context.Context, listID, itemID uuid.UUID) (Item, error)
+	// ItemExists reports whether there is an item with the ID on any list,
+	// including in the trash.
+	ItemExists(ctx context.Context, itemID uuid.UUID) (bool, error)
+	// OpenItemsByName returns the unchecked items whose name matches case-insensitively.
+	OpenItemsByName(ctx context.Context, listID uuid.UUID, name string) ([]Item, error)
+	// OpenItemsByNames is OpenItemsByName for many names at once, in list
+	// order.
+	OpenItemsByNames(ctx context.Context, listID uuid.UUID, names []string) ([]Item, error)
+	// SimilarOpenItems returns the unchecked items whose lower-cased name has
+	// a trigram similarity of at least threshold with name's, most similar
+	// first.
+	SimilarOpenItems(ctx context.Context, listID uuid.UUID, name string, threshold float64) ([]Item, error)
+	NextItemPosition(ctx context.Context, listID uuid.UUID) (int, error)
+	CreateItem(ctx context.Context, item Item) error
+	// CreateItems stores new items in one go.
+	CreateItems(ctx context.Context, items []Item) error
+	UpdateItem(ctx context.Context, item Item) error
+	// DeleteItem moves the item to its household's trash.
+	DeleteItem(ctx context.Context, listID, itemID uuid.UUID) error
+	// RestoreItem takes the item out of the trash as it was deleted;
+	// ErrItemNotFound when it is not there.
+	RestoreItem(ctx context.Context, listID, itemID uuid.UUID) error
+}
+
+// ListService manages lists on behalf of an actor, enforcing the actor's role
+// in the household that owns each list: viewers can read, editors can change
+// lists and items, and only owners can delete a list.
 type ListService struct {
+	storage     ListStorage
+	households  HouseholdStorage
+	stores      StoreStorage
+	pantry      PantryStorage
+	prices      PriceStorage
+	history     ListHistoryStorage
+	categorizer *Categorizer
+}
+
+func NewListService(storage ListStorage, households HouseholdStorage, stores StoreStorage, pantry PantryStorage, prices PriceStorage, history ListHistoryStorage, categorizer *Categorizer) *ListService {
+	return &ListService{
+		storage:     storage,
+		households:  households,
+		stores:      stores,
+		pantry:      pantry,
+		prices:      prices,
+		history:     history,
+		categorizer: categorizer,
+	}
+}
+
+// authorize loads the list and checks actor's role in its household.
+func (s *ListService) authorize(ctx context.Context, actor, listID uuid.UUID, required Role) (List, error) {
+	list, err := s.storage.GetList(ctx, listID)
+	if err != nil {
+		return List{}, err
+	}
+	if err := requireRole(ctx, s.households, list.HouseholdID, actor, required); err != nil {
+		return List{}, err
+	}
+	return list, nil
+}
+
+// targetList picks the household list something is added to on actor's
+// behalf: the given list, or the household's newest one when listID is nil.
+func (s *ListService) targetList(ctx context.Context, actor, householdID uuid.UUID, listID *uuid.UUID) (List, error) {
+	if listID == nil {
+		if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+			return List{}, err
+		}
+		return s.storage.LatestHouseholdList(ctx, householdID)
+	}
+
+	list, err := s.authorize(ctx, actor, *listID, RoleEditor)
+	if err != nil {
+		return List{}, err
+	}
+	if list.HouseholdID != householdID {
+		return List{}, ErrListNotFound
+	}
+	return list, nil
+}
+
+// Lists returns the lists of every household actor belongs to.
+func (s *ListService) Lists(ctx context.Context, actor uuid.UUID) ([]List, error) {
+	return s.storage.UserLists(ctx, actor)
+}
+
+// List returns the list with its items grouped by category, in display
+// order within each category. With a store, the categories are ordered along
+// that store's walking route and the list is priced from what the household
+// last paid there; the store must belong to the list's household.
+func (s *ListService) List(ctx context.Context, actor, id uuid.UUID, storeID *uuid.UUID) (ListView, error) {
+	list, err := s.authorize(ctx, actor, id, RoleViewer)
+	if err != nil {
+		return ListView{}, err
+	}
+
+	items, err := s.storage.ListItems(ctx, id)
+	if err != nil {
+		return ListView{}, err
+	}
+	view := ListView{List: list, Groups: s.categorizer.Group(items)}
+
+	if storeID != nil {
+		store, err := s.stores.GetStore(ctx, *storeID)
+		if err != nil {
+			return ListView{}, err
+		}
+		if store.HouseholdID != list.HouseholdID {
+			return ListView{}, ErrStoreNotFound
+		}
+		view.StoreID = &store.ID
+		view.Groups = store.Route(view.Groups)
+
+		cost, err := estimate(ctx, s.prices, store.ID, items)
+		if err != nil {
+			return ListView{}, err
+		}
+		view.Estimate = &cost
+	}
+
+	return view, nil
+}
+
+func (s *ListService) CreateList(ctx context.Context, actor, householdID uuid.UUID, name string) (List, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return List{}, ErrEmptyName
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return List{}, err
+	}
+
+	now := time.Now()
+	list := List{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		Name:        name,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.storage.CreateList(ctx, list); err != nil {
+		return List{}, err
+	}
+
+	return list, nil
+}
+
+func (s *ListService) RenameList(ctx context.Context, actor, id uuid.UUID, name string) (List, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return List{}, ErrEmptyName
+	}
+
+	list, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return List{}, err
+	}
+
+	list.Name = name
+	list.UpdatedAt = time.Now()
+	if err := s.storage.UpdateList(ctx, list); err != nil {
+		return List{}, err
+	}
+
+	return list, nil
+}
+
+func (s *ListService) DeleteList(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleOwner); err != nil {
+		return err
+	}
+	return s.storage.DeleteList(ctx, id)
+}
+
+// AddItem adds an item to the end of the list. If the list already has an
+// unchecked item with the same name and a quantity of the same dimension, the
+// amounts are merged into that item instead. An explicit category is
+// remembered for the household; otherwise the item is categorized by name.
+// An added item is returned with the other unchecked items whose names are at
+// least threshold similar to its own, such as "Tomatoes" for "tomatos".
+func (s *ListService) AddItem(ctx context.Context, actor, listID uuid.UUID, in NewItem, threshold float64) (AddedItem, error) {
+	entry, err := resolveItem(in)
+	if err != nil {
+		return AddedItem{}, err
+	}
+
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return AddedItem{}, err
+	}
+
+	if entry.category != "" {
+		if err := s.categorizer.Remember(ctx, list.HouseholdID, entry.name, entry.category); err != nil {
+			return AddedItem{}, err
+		}
+	}
+
+	added, err := s.mergeItem(ctx, actor, list, entry)
+	if err != nil || added.Merged {
+		return added, err
+	}
+	if added.Duplicates, err = s.duplicates(ctx, added.Item, threshold); err != nil {
+		return AddedItem{}, err
+	}
+	return added, nil
+}
+
+// duplicates finds the unchecked items that the item likely repeats. Folding
+// case, accents and plurals only brings names closer, so candidates are
+// fetched at half the threshold on their names as written and then compared
+// folded.
+func (s *ListService) duplicates(ctx context.Context, item Item, threshold float64) ([]LikelyDuplicate, error) {
+	candidates, err := s.storage.SimilarOpenItems(ctx, item.ListID, item.Name, threshold/2)
+	if err != nil {
+		return nil, err
+	}
+
+	var duplicates []LikelyDuplicate
+	for _, candidate := range candidates {
+		if candidate.ID == item.ID {
+			continue
+		}
+		similarity := fuzzy.Similarity(candidate.Name, item.Name)
+		if similarity < threshold {
+			continue
+		}
+
+		actions := []DuplicateAction{KeepBothDuplicates}
+		if _, err := candidate.Quantity.Add(item.Quantity); err == nil {
+			actions = []DuplicateAction{MergeDuplicate, KeepBothDuplicates}
+		}
+		duplicates = append(duplicates, LikelyDuplicate{Item: candidate, Similarity: similarity, Actions: actions})
+	}
+	slices.SortStableFunc(duplicates, func(a, b LikelyDuplicate) int {
+		return cmp.Compare(b.Similarity, a.Similarity)
+	})
+
+	return duplicates, nil
+}
+
+// MergeItems merges an unchecked item into another one of the list: the
+// other item gets both quantities and notes and the merged item goes to the
+// trash.
+func (s *ListService) MergeItems(ctx context.Context, actor, listID, itemID uuid.UUID, in MergeInput) (Item, error) {
+	if itemID == in.Into {
+		return Item{}, ErrInvalidMerge
+	}
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return Item{}, err
+	}
+
+	item, err := s.storage.GetItem(ctx, listID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	target, err := s.storage.GetItem(ctx, listID, in.Into)
+	if err != nil {
+		return Item{}, err
+	}
+	if item.Checked || target.Checked {
+		return Item{}, ErrItemChecked
+	}
+	merged, err := target.Quantity.Add(item.Quantity)
+	if errors.Is(err, quantity.ErrIncompatible) {
+		return Item{}, ErrIncompatibleItems
+	}
+	if err != nil {
+		return Item{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	before := target
+	target.Quantity = merged
+	target.Note = mergeNotes(target.Note, item.Note)
+	target.UpdatedAt = time.Now()
+	if err := s.saveItem(ctx, actor, list, before, target); err != nil {
+		return Item{}, err
+	}
+	if err := s.storage.DeleteItem(ctx, listID, item.ID); err != nil {
+		return Item{}, err
+	}
+	if _, err := s.record(ctx, actor, list, itemEvent(ItemRemoved, &item, nil)); err != nil {
+		return Item{}, err
+	}
+
+	return target, nil
+}
+
+// mergeItem adds the entry's amount to an unchecked item with the same name
+// and a compatible unit, or appends a new item when there is none.
+func (s *ListService) mergeItem(ctx context.Context, actor uuid.UUID, list List, entry itemEntry) (AddedItem, error) {
+	added, err := s.mergeExisting(ctx, actor, list, entry)
+	if err != nil || added.Merged {
+		return added, err
+	}
+
+	item, err := s.createItem(ctx, actor, list, entry, time.Now())
+	if err != nil {
+		return AddedItem{}, err
+	}
+
+	return AddedItem{Item: item}, nil
+}
+
+// mergeExisting adds the entry's amount to an unchecked item with the same
+// name and a compatible unit; the result is not Merged when there is none.
+func (s *ListService) mergeExisting(ctx context.Context, actor uuid.UUID, list List, entry itemEntry) (AddedItem, error) {
+	now := time.Now()
+	candidates, err := s.storage.OpenItemsByName(ctx, list.ID, entry.name)
+	if err != nil {
+		return AddedItem{}, err
+	}
+	for _, existing := range candidates {
+		merged, err := existing.Quantity.Add(entry.quantity)
+		if errors.Is(err, quantity.ErrIncompatible) {
+			continue
+		}
+		if err != nil {
+			return AddedItem{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+
+		before := existing
+		existing.Quantity = merged
+		existing.Note = mergeNotes(existing.Note, entry.note)
+		existing.UpdatedAt = now
+		if err := s.saveItem(ctx, actor, list, before, existing); err != nil {
+			return AddedItem{}, err
+		}
+		return AddedItem{Item: existing, Merged: true}, nil
+	}
+
+	return AddedItem{}, nil
+}
+
+// addIngredients merges ingredients into the list, less what the household's
+// pantry already has. Ingredients the pantry fully covers are skipped.
+func (s *ListService) addIngredients(ctx context.Context, actor uuid.UUID, list List, ingredients []Ingredient) (IngredientResult, error) {
+	result := IngredientResult{ListID: list.ID, Added: []AddedItem{}, Skipped: []Ingredient{}}
+	for _, ingredient := range ingredients {
+		needed, err := pantryShortfall(ctx, s.pantry, list.HouseholdID, ingredient.Name, ingredient.Quantity)
+		if err != nil {
+			return IngredientResult{}, err
+		}
+		if needed.Value == 0 {
+			result.Skipped = append(result.Skipped, ingredient)
+			continue
+		}
+
+		entry := ingredient.entry()
+		entry.quantity = needed
+		added, err := s.mergeItem(ctx, actor, list, entry)
+		if err != nil {
+			return IngredientResult{}, err
+		}
+		result.Added = append(result.Added, added)
+	}
+
+	return result, nil
+}
+
+// stockItem makes sure the list holds at least the entry's amount of an item.
+// An unchecked item with the same name and a compatible unit is raised to that
+// amount when it holds less and left alone otherwise, so applying the same
+// entries twice does not pile up quantities. Without one, a new item is added.
+func (s *ListService) stockItem(ctx context.Context, actor uuid.UUID, list List, entry itemEntry, result *ApplyResult) error {
+	now := time.Now()
+	candidates, err := s.storage.OpenItemsByName(ctx, list.ID, entry.name)
+	if err != nil {
+		return err
+	}
+	for _, existing := range candidates {
+		cmp, err := existing.Quantity.Compare(entry.quantity)
+		if errors.Is(err, quantity.ErrIncompatible) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if cmp >= 0 {
+			result.Unchanged = append(result.Unchanged, existing)
+			return nil
+		}
+
+		before := existing
+		existing.Quantity = entry.quantity
+		existing.Note = mergeNotes(existing.Note, entry.note)
+		existing.UpdatedAt = now
+		if err := s.saveItem(ctx, actor, list, before, existing); err != nil {
+			return err
+		}
+		result.Updated = append(result.Updated, existing)
+		return nil
+	}
+
+	item, err := s.createItem(ctx, actor, list, entry, now)
+	if err != nil {
+		return err
+	}
+	result.Added = append(result.Added, item)
+
+	return nil
+}
+
+// createItem appends a new item to the list.
+func (s *ListService) createItem(ctx context.Context, actor uuid.UUID, list List, entry itemEntry, now time.Time) (Item, error) {
+	position, err := s.storage.NextItemPosition(ctx, list.ID)
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := s.newItem(ctx, list, entry, position, now)
+	if err != nil {
+		return Item{}, err
+	}
+	if err := s.storage.CreateItem(ctx, item); err != nil {
+		return Item{}, err
+	}
+	if _, err := s.record(ctx, actor, list, itemEvent(ItemAdded, nil, &item)); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// newItem makes the entry into an item of the list at the given position,
+// categorizing it when the entry has no category.
+func (s *ListService) newItem(ctx context.Context, list List, entry itemEntry, position int, now time.Time) (Item, error) {
+	category := entry.category
+	if category == "" {
+		var err error
+		category, err = s.categorizer.Categorize(ctx, list.HouseholdID, entry.name)
+		if err != nil {
+			return Item{}, err
+		}
+	}
+
+	return Item{
+		ID:        uuid.New(),
+		ListID:    list.ID,
+		Name:      entry.name,
+		Quantity:  entry.quantity,
+		Note:      entry.note,
+		Category:  category,
+		Position:  position,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// saveItem stores an edited item and records the edit.
+func (s *ListService) saveItem(ctx context.Context, actor uuid.UUID, list List, before, after Item) error {
+	if err := s.storage.UpdateItem(ctx, after); err != nil {
+		return err
+	}
+	_, err := s.record(ctx, actor, list, itemEvent(ItemEdited, &before, &after))
+	return err
+}
+
+// EditItem changes an item. Setting the category re-categorizes the item and
+// remembers the choice for the household; renaming an item without setting a
+// category categorizes it again under its new name.
+func (s *ListService) EditItem(ctx context.Context, actor, listID, itemID uuid.UUID, update ItemUpdate) (Item, error) {
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return Item{}, err
+	}
+	return s.editItem(ctx, actor, list, itemID, update)
+}
+
+func (s *ListService) editItem(ctx context.Context, actor uuid.UUID, list List, itemID uuid.UUID, update ItemUpdate) (Item, error) {
+	item, err := s.storage.GetItem(ctx, list.ID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	before := item
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return Item{}, ErrEmptyName
+		}
+		item.Name = name
+	}
+	if update.Quantity != nil {
+		if err := update.Quantity.Validate(); err != nil {
+			return Item{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		item.Quantity = *update.Quantity
+	}
+	if update.Note != nil {
+		item.Note = strings.TrimSpace(*update.Note)
+	}
+	switch {
+	case update.Category != nil:
+		category := strings.TrimSpace(*update.Category)
+		if err := s.categorizer.Remember(ctx, list.HouseholdID, item.Name, category); err != nil {
+			return Item{}, err
+		}
+		item.Category = category
+	case update.Name != nil:
+		item.Category, err = s.categorizer.Categorize(ctx, list.HouseholdID, item.Name)
+		if err != nil {
+			return Item{}, err
+		}
+	}
+
+	item.UpdatedAt = time.Now()
+	if err := s.saveItem(ctx, actor, list, before, item); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// CheckItem checks an item off and adds it to the household's pantry.
+func (s *ListService) CheckItem(ctx context.Context, actor, listID, itemID uuid.UUID) (Item, error) {
+	return s.setChecked(ctx, actor, listID, itemID, true)
+}
+
+// UncheckItem puts a checked item back on the list and takes it out of the
+// pantry again.
+func (s *ListService) UncheckItem(ctx context.Context, actor, listID, itemID uuid.UUID) (Item, error) {
+	return s.setChecked(ctx, actor, listID, itemID, false)
+}
+
+func (s *ListService) setChecked(ctx context.Context, actor, listID, itemID uuid.UUID, checked bool) (Item, error) {
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return Item{}, err
+	}
+	return s.checkItem(ctx, actor, list, itemID, checked)
+}
+
+// checkItem checks the list's item off or puts it back and records it.
+func (s *ListService) checkItem(ctx context.Context, actor uuid.UUID, list List, itemID uuid.UUID, checked bool) (Item, error) {
+	item, err := s.storage.GetItem(ctx, list.ID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	if item.Checked == checked {
+		return item, nil
+	}
+
+	before := item
+	if item, err = s.check(ctx, list, item, checked); err != nil {
+		return Item{}, err
+	}
+	eventType := ItemUnchecked
+	if checked {
+		eventType = ItemChecked
+	}
+	if _, err := s.record(ctx, actor, list, itemEvent(eventType, &before, &item)); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// check checks the item off, adding it to the household's pantry, or puts it
+// back on the list, taking it out of the pantry again.
+func (s *ListService) check(ctx context.Context, list List, item Item, checked bool) (Item, error) {
+	if item.Checked == checked {
+		return item, nil
+	}
+
+	now := time.Now()
+	item.Checked = checked
+	item.CheckedAt = nil
+	if checked {
+		item.CheckedAt = &now
+	}
+	item.UpdatedAt = now
+	if err := s.storage.UpdateItem(ctx, item); err != nil {
+		return Item{}, err
+	}
+
+	var err error
+	if checked {
+		_, err = addToPantry(ctx, s.pantry, list.HouseholdID, item.Name, item.Quantity, item.Category, now)
+	} else {
+		err = takeFromPantry(ctx, s.pantry, list.HouseholdID, item.Name, item.Quantity, now)
+	}
+	if err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+func (s *ListService) RemoveItem(ctx context.Context, actor, listID, itemID uuid.UUID) error {
+	list, err := s.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return err
+	}
+	_, err = s.removeItem(ctx, actor, list, itemID)
+	return err
+}
+
+// removeItem moves the list's item to the trash, records it and returns the
+// item as it was.
+func (s *ListService) removeItem(ctx context.Context, actor uuid.UUID, list List, itemID uuid.UUID) (Item, error) {
+	item, err := s.storage.GetItem(ctx, list.ID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	if err := s.storage.DeleteItem(ctx, list.ID, itemID); err != nil {
+		return Item{}, err
+	}
+	if _, err := s.record(ctx, actor, list, itemEvent(ItemRemoved, &item, nil)); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// putBack brings a removed item back as given: out of the trash while it is
+// there, or created again once the trash has been purged.
+func (s *ListService) putBack(ctx context.Context, item Item) error {
+	err := s.storage.RestoreItem(ctx, item.ListID, item.ID)
+	if errors.Is(err, ErrItemNotFound) {
+		return s.storage.CreateItem(ctx, item)
+	}
+	if err != nil {
+		return err
+	}
+	return s.storage.UpdateItem(ctx, item)
+}
+
+// mergeNotes joins the notes of two merged items, skipping empty and repeated ones.
+func mergeNotes(existing, added string) string {
+	switch {
+	case added == "" || strings.EqualFold(existing, added):
+		return existing
+	case existing == "":
+		return added
+	}
+	return existing + "; " + added
+}
+
+// itemEntry is a validated NewItem.
+type itemEntry struct {
+	name     string
+	quantity quantity.Quantity
+	note     string
+	category string
+}
+
+// resolveItem parses the entry's free text to fill the fields that are not
+// given explicitly and validates the result.
+func resolveItem(in NewItem) (itemEntry, error) {
+	if strings.TrimSpace(in.Text) != "" {
+		parsed, err := itemparser.Parse(in.Text)
+		if err != nil {
+			return itemEntry{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		if strings.TrimSpace(in.Name) == "" {
+			in.Name = parsed.Name
+		}
+		if in.Quantity == nil {
+			in.Quantity = &parsed.Quantity
+		}
+		if strings.TrimSpace(in.Note) == "" {
+			in.Note = parsed.Note
+		}
+	}
+
+	entry := itemEntry{
+		name:     strings.TrimSpace(in.Name),
+		quantity: quantity.One,
+		note:     strings.TrimSpace(in.Note),
+		category: strings.TrimSpace(in.Category),
+	}
+	if entry.name == "" {
+		return itemEntry{}, ErrEmptyName
+	}
+	if in.Quantity != nil {
+		entry.quantity = *in.Quantity
+	}
+	if err := entry.quantity.Validate(); err != nil {
+		return itemEntry{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	return entry, nil
 }
@@ -0,0 +1,225 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestListServiceLists(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+
+	list, err := h.service.CreateList(ctx, h.editor, h.id, "  Hardware ")
+	if err != nil {
+		t.Fatalf("CreateList() = %v", err)
+	}
+	if list.Name != "Hardware" || list.HouseholdID != h.id {
+		t.Errorf("CreateList() = %+v, want Hardware in the household", list)
+	}
+	if _, err := h.service.CreateList(ctx, h.editor, h.id, " "); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("CreateList() without a name = %v, want ErrEmptyName", err)
+	}
+
+	renamed, err := h.service.RenameList(ctx, h.editor, list.ID, "DIY")
+	if err != nil {
+		t.Fatalf("RenameList() = %v", err)
+	}
+	if renamed.Name != "DIY" || h.lists.lists[list.ID].Name != "DIY" {
+		t.Errorf("RenameList() = %+v, stored %+v, want DIY", renamed, h.lists.lists[list.ID])
+	}
+
+	if err := h.service.DeleteList(ctx, h.owner, list.ID); err != nil {
+		t.Fatalf("DeleteList() = %v", err)
+	}
+	if _, err := h.service.List(ctx, h.owner, list.ID, nil); !errors.Is(err, ErrListNotFound) {
+		t.Errorf("List() after DeleteList() = %v, want ErrListNotFound", err)
+	}
+}
+
+func TestListServiceItems(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	bread := h.add(t, "Bread")
+
+	if milk.Position != 0 || bread.Position != 1 {
+		t.Errorf("positions = %d, %d, want the items appended in order", milk.Position, bread.Position)
+	}
+	if _, err := h.service.AddItem(ctx, h.editor, h.list.ID, NewItem{Name: " "}, 1); !errors.Is(err, ErrEmptyName) {
+		t.Errorf("AddItem() without a name = %v, want ErrEmptyName", err)
+	}
+
+	note := " semi-skimmed "
+	edited, err := h.service.EditItem(ctx, h.editor, h.list.ID, milk.ID, ItemUpdate{Note: &note})
+	if err != nil {
+		t.Fatalf("EditItem() = %v", err)
+	}
+	if edited.Note != "semi-skimmed" || edited.Name != "Milk" {
+		t.Errorf("EditItem() = %+v, want the note trimmed and the name kept", edited)
+	}
+
+	checked, err := h.service.CheckItem(ctx, h.editor, h.list.ID, milk.ID)
+	if err != nil {
+		t.Fatalf("CheckItem() = %v", err)
+	}
+	if !checked.Checked || checked.CheckedAt == nil {
+		t.Errorf("CheckItem() = %+v, want it checked off with a time", checked)
+	}
+	unchecked, err := h.service.UncheckItem(ctx, h.editor, h.list.ID, milk.ID)
+	if err != nil {
+		t.Fatalf("UncheckItem() = %v", err)
+	}
+	if unchecked.Checked || unchecked.CheckedAt != nil {
+		t.Errorf("UncheckItem() = %+v, want it back on the list", unchecked)
+	}
+
+	if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, bread.ID); err != nil {
+		t.Fatalf("RemoveItem() = %v", err)
+	}
+	if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, bread.ID); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("RemoveItem() twice = %v, want ErrItemNotFound", err)
+	}
+
+	view, err := h.service.List(ctx, h.viewer, h.list.ID, nil)
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	var names []string
+	for _, group := range view.Groups {
+		for _, item := range group.Items {
+			names = append(names, item.Name)
+		}
+	}
+	if len(names) != 1 || names[0] != "Milk" {
+		t.Errorf("List() has items %q, want just Milk", names)
+	}
+}
+
+func TestListServiceAuthorization(t *testing.T) {
+	ctx := context.Background()
+	note := "semi-skimmed"
+	actions := []struct {
+		name     string
+		required Role
+		do       func(h *household, actor uuid.UUID, item Item) error
+	}{
+		{"view list", RoleViewer, func(h *household, actor uuid.UUID, _ Item) error {
+			_, err := h.service.List(ctx, actor, h.list.ID, nil)
+			return err
+		}},
+		{"view history", RoleViewer, func(h *household, actor uuid.UUID, _ Item) error {
+			_, err := h.service.History(ctx, actor, h.list.ID, DefaultHistoryLimit)
+			return err
+		}},
+		{"create list", RoleEditor, func(h *household, actor uuid.UUID, _ Item) error {
+			_, err := h.service.CreateList(ctx, actor, h.id, "Hardware")
+			return err
+		}},
+		{"rename list", RoleEditor, func(h *household, actor uuid.UUID, _ Item) error {
+			_, err := h.service.RenameList(ctx, actor, h.list.ID, "Weekly shop")
+			return err
+		}},
+		{"add item", RoleEditor, func(h *household, actor uuid.UUID, _ Item) error {
+			_, err := h.service.AddItem(ctx, actor, h.list.ID, NewItem{Name: "Bread"}, 1)
+			return err
+		}},
+		{"edit item", RoleEditor, func(h *household, actor uuid.UUID, item Item) error {
+			_, err := h.service.EditItem(ctx, actor, h.list.ID, item.ID, ItemUpdate{Note: &note})
+			return err
+		}},
+		{"check item", RoleEditor, func(h *household, actor uuid.UUID, item Item) error {
+			_, err := h.service.CheckItem(ctx, actor, h.list.ID, item.ID)
+			return err
+		}},
+		{"remove item", RoleEditor, func(h *household, actor uuid.UUID, item Item) error {
+			return h.service.RemoveItem(ctx, actor, h.list.ID, item.ID)
+		}},
+		{"reorder items", RoleEditor, func(h *household, actor uuid.UUID, item Item) error {
+			_, err := h.service.ReorderItems(ctx, actor, h.list.ID, ReorderInput{ItemIDs: []uuid.UUID{item.ID}})
+			return err
+		}},
+		{"delete list", RoleOwner, func(h *household, actor uuid.UUID, _ Item) error {
+			return h.service.DeleteList(ctx, actor, h.list.ID)
+		}},
+	}
+
+	for _, action := range actions {
+		for _, role := range []Role{RoleViewer, RoleEditor, RoleOwner, ""} {
+			h := newHousehold(t)
+			item := h.add(t, "Milk")
+			actor := map[Role]uuid.UUID{RoleViewer: h.viewer, RoleEditor: h.editor, RoleOwner: h.owner, "": uuid.New()}[role]
+
+			err := action.do(h, actor, item)
+			switch {
+			case role == "":
+				if !errors.Is(err, ErrNotMember) {
+					t.Errorf("%s by a non-member = %v, want ErrNotMember", action.name, err)
+				}
+			case role.Allows(action.required):
+				if err != nil {
+					t.Errorf("%s by %s = %v, want nil", action.name, role, err)
+				}
+			default:
+				if !errors.Is(err, ErrForbidden) {
+					t.Errorf("%s by %s = %v, want ErrForbidden", action.name, role, err)
+				}
+			}
+		}
+	}
+}
+
+// A list is reached through its own household, whatever the caller's role
+// in another one.
+func TestListServiceOtherHousehold(t *testing.T) {
+	ctx := context.Background()
+	h, other := newHousehold(t), newHousehold(t)
+
+	if _, err := h.service.List(ctx, other.owner, h.list.ID, nil); !errors.Is(err, ErrNotMember) {
+		t.Errorf("List() by another household's owner = %v, want ErrNotMember", err)
+	}
+	if _, err := h.service.AddItem(ctx, h.owner, other.list.ID, NewItem{Name: "Milk"}, 1); !errors.Is(err, ErrListNotFound) {
+		t.Errorf("AddItem() to a list the storage does not have = %v, want ErrListNotFound", err)
+	}
+}
+
+func TestAddItemMergesSameName(t *testing.T) {
+	h := newHousehold(t)
+	first := h.add(t, "Milk")
+
+	added, err := h.service.AddItem(context.Background(), h.editor, h.list.ID, NewItem{Text: "2 milk"}, 1)
+	if err != nil {
+		t.Fatalf("AddItem() = %v", err)
+	}
+
+	if !added.Merged || added.ID != first.ID {
+		t.Errorf("AddItem() = %+v, want merged into %s", added, first.ID)
+	}
+	if added.Quantity.Value != 3 {
+		t.Errorf("Quantity = %v, want 3", added.Quantity)
+	}
+	if len(h.lists.items) != 1 {
+		t.Errorf("list has %d items, want 1", len(h.lists.items))
+	}
+}
+
+// A merge that would take the item to a billion or more is refused rather
+// than stored.
+func TestAddItemMergeOverflow(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	most := quantity.New(quantity.MaxValue-1, quantity.Pieces)
+	first, err := h.service.AddItem(ctx, h.editor, h.list.ID, NewItem{Name: "Milk", Quantity: &most}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := h.service.AddItem(ctx, h.editor, h.list.ID, NewItem{Text: "2 milk"}, 1); !errors.Is(err, ErrInvalid) {
+		t.Errorf("AddItem() past the largest quantity = %v, want ErrInvalid", err)
+	}
+	if got := h.lists.items[first.ID].Quantity; got != most {
+		t.Errorf("Quantity = %v, want %v kept", got, most)
+	}
+}
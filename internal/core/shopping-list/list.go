@@ -0,0 +1,113 @@
+package shopping_list
+
+import (
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"time"
+)
+
+type List struct {
+	ID          uuid.UUID `json:"id"`
+	HouseholdID uuid.UUID `json:"household_id"`
+	Name        string    `json:"name"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ListView is a list with its items grouped by category. When StoreID is set
+// the groups follow that store's walking route and Estimate is what the list
+// is expected to cost there.
+type ListView struct {
+	List
+	StoreID  *uuid.UUID      `json:"store_id,omitempty"`
+	Estimate *Estimate       `json:"estimate,omitempty"`
+	Groups   []CategoryGroup `json:"groups"`
+}
+
+type CategoryGroup struct {
+	Category categories.Category `json:"category"`
+	Aisle    string              `json:"aisle,omitempty"`
+	Items    []Item              `json:"items"`
+}
+
+type Item struct {
+	ID        uuid.UUID         `json:"id"`
+	ListID    uuid.UUID         `json:"list_id"`
+	Name      string            `json:"name"`
+	Quantity  quantity.Quantity `json:"quantity"`
+	Note      string            `json:"note"`
+	Category  string            `json:"category"`
+	Checked   bool              `json:"checked"`
+	CheckedAt *time.Time        `json:"checked_at,omitempty"`
+	Position  int               `json:"position"`
+	Claim     *ItemClaim        `json:"claim,omitempty"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// NewItem is the input for adding an item to a list. A missing quantity means
+// one piece. Text is a free-form entry such as "2x 1.5l milk"; it is parsed to
+// fill whichever of name, quantity and note are not given explicitly. Without
+// a category the item is categorized automatically.
+type NewItem struct {
+	Text     string             `json:"text"`
+	Name     string             `json:"name"`
+	Quantity *quantity.Quantity `json:"quantity"`
+	Note     string             `json:"note"`
+	Category string             `json:"category"`
+}
+
+// ItemUpdate carries the fields of an item to edit; nil fields are left as is.
+type ItemUpdate struct {
+	Name     *string            `json:"name"`
+	Quantity *quantity.Quantity `json:"quantity"`
+	Note     *string            `json:"note"`
+	Category *string            `json:"category"`
+}
+
+// AddedItem is the result of adding an item. Merged is set when the amount was
+// added to an existing unchecked item with the same name instead. Duplicates
+// are other unchecked items on the list whose names look like the added one's.
+type AddedItem struct {
+	Item
+	Merged     bool              `json:"merged"`
+	Duplicates []LikelyDuplicate `json:"duplicates,omitempty"`
+}
+
+type DuplicateAction string
+
+const (
+	// MergeDuplicate merges the added item into the duplicate. It is only
+	// offered when their quantities add up.
+	MergeDuplicate DuplicateAction = "merge"
+	// KeepBothDuplicates leaves both items on the list.
+	KeepBothDuplicates DuplicateAction = "keep_both"
+)
+
+// LikelyDuplicate is an item that an added item probably repeats, how similar
+// their names are and what can be done about it.
+type LikelyDuplicate struct {
+	Item       Item              `json:"item"`
+	Similarity float64           `json:"similarity"`
+	Actions    []DuplicateAction `json:"actions"`
+}
+
+// MergeInput merges an item into another item of the same list.
+type MergeInput struct {
+	Into uuid.UUID `json:"into"`
+}
+
+// ApplyResult reports what applying a batch of entries, such as a template,
+// did to a list: items that were added, items whose quantity was raised and
+// unchecked items that already covered an entry.
+type ApplyResult struct {
+	ListID    uuid.UUID `json:"list_id"`
+	Added     []Item    `json:"added"`
+	Updated   []Item    `json:"updated"`
+	Unchanged []Item    `json:"unchanged"`
+}
+
+func newApplyResult(listID uuid.UUID) ApplyResult {
+	return ApplyResult{ListID: listID, Added: []Item{}, Updated: []Item{}, Unchanged: []Item{}}
+}
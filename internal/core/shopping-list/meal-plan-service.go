@@ -0,0 +1,199 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// MaxMealPlanDays bounds the date range of meal plan queries and shopping lists.
+const MaxMealPlanDays = 62
+
+// MealPlanStorage persists a household's meal plan.
+type MealPlanStorage interface {
+	// HouseholdMealPlan returns the entries from one day to another, both
+	// included, by date and meal.
+	HouseholdMealPlan(ctx context.Context, householdID uuid.UUID, from, to time.Time) ([]MealPlanEntry, error)
+	GetMealPlanEntry(ctx context.Context, id uuid.UUID) (MealPlanEntry, error)
+	CreateMealPlanEntry(ctx context.Context, entry MealPlanEntry) error
+	UpdateMealPlanEntry(ctx context.Context, entry MealPlanEntry) error
+	DeleteMealPlanEntry(ctx context.Context, id uuid.UUID) error
+}
+
+// MealPlanService plans a household's meals and turns the plan into a
+// shopping list. Members can view the plan and editors can change it.
+type MealPlanService struct {
+	storage    MealPlanStorage
+	recipes    RecipeStorage
+	households HouseholdStorage
+	lists      *ListService
+}
+
+func NewMealPlanService(storage MealPlanStorage, recipes RecipeStorage, households HouseholdStorage, lists *ListService) *MealPlanService {
+	return &MealPlanService{
+		storage:    storage,
+		recipes:    recipes,
+		households: households,
+		lists:      lists,
+	}
+}
+
+func (s *MealPlanService) authorize(ctx context.Context, actor, entryID uuid.UUID, required Role) (MealPlanEntry, error) {
+	entry, err := s.storage.GetMealPlanEntry(ctx, entryID)
+	if err != nil {
+		return MealPlanEntry{}, err
+	}
+	if err := requireRole(ctx, s.households, entry.HouseholdID, actor, required); err != nil {
+		return MealPlanEntry{}, err
+	}
+	return entry, nil
+}
+
+// MealPlan returns the household's planned meals between two days, both included.
+func (s *MealPlanService) MealPlan(ctx context.Context, actor, householdID uuid.UUID, from, to string) ([]MealPlanEntry, error) {
+	start, end, err := parseDateRange(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.HouseholdMealPlan(ctx, householdID, start, end)
+}
+
+func (s *MealPlanService) PlanMeal(ctx context.Context, actor, householdID uuid.UUID, in MealPlanInput) (MealPlanEntry, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return MealPlanEntry{}, err
+	}
+
+	now := time.Now()
+	entry := MealPlanEntry{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		CreatedAt:   now,
+	}
+	if err := s.fill(ctx, &entry, in, now); err != nil {
+		return MealPlanEntry{}, err
+	}
+	if err := s.storage.CreateMealPlanEntry(ctx, entry); err != nil {
+		return MealPlanEntry{}, err
+	}
+
+	return entry, nil
+}
+
+// UpdateMealPlanEntry moves a planned meal or changes its recipe or servings.
+func (s *MealPlanService) UpdateMealPlanEntry(ctx context.Context, actor, id uuid.UUID, in MealPlanInput) (MealPlanEntry, error) {
+	entry, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return MealPlanEntry{}, err
+	}
+
+	if err := s.fill(ctx, &entry, in, time.Now()); err != nil {
+		return MealPlanEntry{}, err
+	}
+	if err := s.storage.UpdateMealPlanEntry(ctx, entry); err != nil {
+		return MealPlanEntry{}, err
+	}
+
+	return entry, nil
+}
+
+func (s *MealPlanService) DeleteMealPlanEntry(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleEditor); err != nil {
+		return err
+	}
+	return s.storage.DeleteMealPlanEntry(ctx, id)
+}
+
+// ShoppingList puts everything the meals planned between two days need on a
+// list: the given one, or the household's newest. Each recipe is scaled to its
+// planned servings, the same ingredient across recipes is combined into one
+// amount, and what the pantry has in stock is subtracted before the rest is
+// merged into the list.
+func (s *MealPlanService) ShoppingList(ctx context.Context, actor, householdID uuid.UUID, from, to string, listID *uuid.UUID) (IngredientResult, error) {
+	start, end, err := parseDateRange(from, to)
+	if err != nil {
+		return IngredientResult{}, err
+	}
+
+	list, err := s.lists.targetList(ctx, actor, householdID, listID)
+	if err != nil {
+		return IngredientResult{}, err
+	}
+
+	entries, err := s.storage.HouseholdMealPlan(ctx, householdID, start, end)
+	if err != nil {
+		return IngredientResult{}, err
+	}
+
+	recipes := make(map[uuid.UUID]Recipe)
+	var ingredients []Ingredient
+	for _, entry := range entries {
+		recipe, ok := recipes[entry.RecipeID]
+		if !ok {
+			recipe, err = s.recipes.GetRecipe(ctx, entry.RecipeID)
+			if err != nil {
+				return IngredientResult{}, err
+			}
+			recipes[entry.RecipeID] = recipe
+		}
+		scaled, err := recipe.Scale(entry.Servings)
+		if err != nil {
+			return IngredientResult{}, fmt.Errorf("%s on %s: %w", recipe.Name, entry.Date.Format(time.DateOnly), err)
+		}
+		ingredients = append(ingredients, scaled...)
+	}
+
+	return s.lists.addIngredients(ctx, actor, list, combineIngredients(ingredients))
+}
+
+// fill validates the input and copies it onto the entry.
+func (s *MealPlanService) fill(ctx context.Context, entry *MealPlanEntry, in MealPlanInput, now time.Time) error {
+	date, err := time.Parse(time.DateOnly, in.Date)
+	if err != nil {
+		return ErrInvalidDate
+	}
+	if !in.Meal.Valid() {
+		return ErrInvalidMeal
+	}
+	if in.Servings < 0 || in.Servings > MaxServings {
+		return ErrInvalidServings
+	}
+
+	recipe, err := s.recipes.GetRecipe(ctx, in.RecipeID)
+	if err != nil {
+		return err
+	}
+	if recipe.HouseholdID != entry.HouseholdID {
+		return ErrRecipeNotFound
+	}
+
+	entry.Date = date
+	entry.Meal = in.Meal
+	entry.RecipeID = recipe.ID
+	entry.RecipeName = recipe.Name
+	entry.Servings = in.Servings
+	if entry.Servings == 0 {
+		entry.Servings = recipe.Servings
+	}
+	entry.UpdatedAt = now
+
+	return nil
+}
+
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	start, err := time.Parse(time.DateOnly, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDate
+	}
+	end, err := time.Parse(time.DateOnly, to)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDate
+	}
+	if end.Before(start) || end.Sub(start) >= MaxMealPlanDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, end, nil
+}
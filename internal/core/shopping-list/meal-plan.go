@@ -0,0 +1,78 @@
+package shopping_list
+
+import (
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/google/uuid"
+	"time"
+)
+
+type Meal string
+
+const (
+	MealBreakfast Meal = "breakfast"
+	MealLunch     Meal = "lunch"
+	MealDinner    Meal = "dinner"
+	MealSnack     Meal = "snack"
+)
+
+func (m Meal) Valid() bool {
+	switch m {
+	case MealBreakfast, MealLunch, MealDinner, MealSnack:
+		return true
+	}
+	return false
+}
+
+// MealPlanEntry puts a recipe on a household's plan for one meal of a day.
+type MealPlanEntry struct {
+	ID          uuid.UUID `json:"id"`
+	HouseholdID uuid.UUID `json:"household_id"`
+	Date        time.Time `json:"date"`
+	Meal        Meal      `json:"meal"`
+	RecipeID    uuid.UUID `json:"recipe_id"`
+	RecipeName  string    `json:"recipe_name"`
+	Servings    int       `json:"servings"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// MealPlanInput is the input for planning a meal. Date is a calendar day such
+// as "2024-05-06"; without servings the recipe's own servings are planned.
+type MealPlanInput struct {
+	Date     string    `json:"date"`
+	Meal     Meal      `json:"meal"`
+	RecipeID uuid.UUID `json:"recipe_id"`
+	Servings int       `json:"servings"`
+}
+
+// combineIngredients sums ingredients with the same name and a compatible
+// unit, so 200 g of flour for one recipe and 1 kg for another become 1.2 kg.
+// The first occurrence of each ingredient keeps its place in the result.
+func combineIngredients(ingredients []Ingredient) []Ingredient {
+	var combined []Ingredient
+	byName := make(map[string][]int)
+
+	for _, ingredient := range ingredients {
+		key := categories.Normalize(ingredient.Name)
+		merged := false
+		for _, i := range byName[key] {
+			total, err := combined[i].Quantity.Add(ingredient.Quantity)
+			if err != nil {
+				continue
+			}
+			combined[i].Quantity = total
+			combined[i].Note = mergeNotes(combined[i].Note, ingredient.Note)
+			if combined[i].Category == "" {
+				combined[i].Category = ingredient.Category
+			}
+			merged = true
+			break
+		}
+		if !merged {
+			byName[key] = append(byName[key], len(combined))
+			combined = append(combined, ingredient)
+		}
+	}
+
+	return combined
+}
@@ -0,0 +1,250 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// PantryStorage persists household pantries.
+type PantryStorage interface {
+	HouseholdPantry(ctx context.Context, householdID uuid.UUID) ([]PantryItem, error)
+	// ExpiringPantryItems returns the items in stock that expire before the
+	// given time, soonest first.
+	ExpiringPantryItems(ctx context.Context, householdID uuid.UUID, before time.Time) ([]PantryItem, error)
+	GetPantryItem(ctx context.Context, id uuid.UUID) (PantryItem, error)
+	// PantryItemsByName returns the pantry items whose name matches case-insensitively.
+	PantryItemsByName(ctx context.Context, householdID uuid.UUID, name string) ([]PantryItem, error)
+	CreatePantryItem(ctx context.Context, item PantryItem) error
+	UpdatePantryItem(ctx context.Context, item PantryItem) error
+	DeletePantryItem(ctx context.Context, id uuid.UUID) error
+}
+
+// PantryService manages what a household has at home. Members can view the
+// pantry and editors can change it. Checking items off a shopping list adds
+// them to the pantry through ListService.
+type PantryService struct {
+	storage    PantryStorage
+	households HouseholdStorage
+	lists      *ListService
+}
+
+func NewPantryService(storage PantryStorage, households HouseholdStorage, lists *ListService) *PantryService {
+	return &PantryService{
+		storage:    storage,
+		households: households,
+		lists:      lists,
+	}
+}
+
+func (s *PantryService) authorize(ctx context.Context, actor, itemID uuid.UUID, required Role) (PantryItem, error) {
+	item, err := s.storage.GetPantryItem(ctx, itemID)
+	if err != nil {
+		return PantryItem{}, err
+	}
+	if err := requireRole(ctx, s.households, item.HouseholdID, actor, required); err != nil {
+		return PantryItem{}, err
+	}
+	return item, nil
+}
+
+func (s *PantryService) Pantry(ctx context.Context, actor, householdID uuid.UUID) ([]PantryItem, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.HouseholdPantry(ctx, householdID)
+}
+
+// Expiring returns the items in stock that expire within the given number of
+// days, including those that have already expired.
+func (s *PantryService) Expiring(ctx context.Context, actor, householdID uuid.UUID, days int) ([]PantryItem, error) {
+	if days < 0 {
+		return nil, ErrInvalidDays
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.ExpiringPantryItems(ctx, householdID, time.Now().AddDate(0, 0, days))
+}
+
+// AddStock adds to the pantry. A threshold or expiry date given with the stock
+// replaces the item's current one.
+func (s *PantryService) AddStock(ctx context.Context, actor, householdID uuid.UUID, in NewPantryItem) (PantryItem, error) {
+	entry, err := resolveItem(in.NewItem)
+	if err != nil {
+		return PantryItem{}, err
+	}
+	if err := validateThreshold(entry.quantity, in.Threshold); err != nil {
+		return PantryItem{}, err
+	}
+	if entry.category != "" && !s.lists.categorizer.Known(entry.category) {
+		return PantryItem{}, ErrUnknownCategory
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return PantryItem{}, err
+	}
+
+	if entry.category == "" {
+		entry.category, err = s.lists.categorizer.Categorize(ctx, householdID, entry.name)
+		if err != nil {
+			return PantryItem{}, err
+		}
+	}
+
+	item, err := addToPantry(ctx, s.storage, householdID, entry.name, entry.quantity, entry.category, time.Now())
+	if err != nil {
+		return PantryItem{}, err
+	}
+	if in.Threshold == nil && in.ExpiresAt == nil {
+		return item, nil
+	}
+
+	if in.Threshold != nil {
+		item.Threshold = in.Threshold
+	}
+	if in.ExpiresAt != nil {
+		item.ExpiresAt = in.ExpiresAt
+	}
+	if err := s.storage.UpdatePantryItem(ctx, item); err != nil {
+		return PantryItem{}, err
+	}
+
+	return item, nil
+}
+
+// UpdatePantryItem edits a pantry item. When the stock ends up below the
+// threshold, the item is put back on the shopping list.
+func (s *PantryService) UpdatePantryItem(ctx context.Context, actor, id uuid.UUID, update PantryUpdate) (Consumption, error) {
+	item, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return Consumption{}, err
+	}
+
+	if update.Name != nil {
+		name := strings.TrimSpace(*update.Name)
+		if name == "" {
+			return Consumption{}, ErrEmptyName
+		}
+		item.Name = name
+	}
+	if update.Quantity != nil {
+		if err := validateStock(*update.Quantity); err != nil {
+			return Consumption{}, err
+		}
+		item.Quantity = *update.Quantity
+	}
+	if update.Threshold != nil {
+		item.Threshold = update.Threshold
+	}
+	if update.ExpiresAt != nil {
+		item.ExpiresAt = update.ExpiresAt
+	}
+	if err := validateThreshold(item.Quantity, item.Threshold); err != nil {
+		return Consumption{}, err
+	}
+
+	item.UpdatedAt = time.Now()
+	if err := s.storage.UpdatePantryItem(ctx, item); err != nil {
+		return Consumption{}, err
+	}
+
+	return s.restock(ctx, actor, item)
+}
+
+// Consume takes an amount out of the pantry. When the stock falls below the
+// threshold, the item is put back on the shopping list.
+func (s *PantryService) Consume(ctx context.Context, actor, id uuid.UUID, amount quantity.Quantity) (Consumption, error) {
+	if err := amount.Validate(); err != nil {
+		return Consumption{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+
+	item, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return Consumption{}, err
+	}
+
+	left, err := item.Quantity.Sub(amount)
+	if errors.Is(err, quantity.ErrIncompatible) {
+		return Consumption{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	if err != nil {
+		return Consumption{}, err
+	}
+	item.Quantity = left
+	if left.Value == 0 {
+		item.ExpiresAt = nil
+	}
+
+	item.UpdatedAt = time.Now()
+	if err := s.storage.UpdatePantryItem(ctx, item); err != nil {
+		return Consumption{}, err
+	}
+
+	return s.restock(ctx, actor, item)
+}
+
+func (s *PantryService) DeletePantryItem(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleEditor); err != nil {
+		return err
+	}
+	return s.storage.DeletePantryItem(ctx, id)
+}
+
+// restock puts an item that ran low on the household's newest list, asking for
+// its threshold amount. An unchecked list item that already asks for that
+// much is left as is. Households without a list are skipped.
+func (s *PantryService) restock(ctx context.Context, actor uuid.UUID, item PantryItem) (Consumption, error) {
+	consumption := Consumption{PantryItem: item}
+	if !item.low() {
+		return consumption, nil
+	}
+
+	list, err := s.lists.storage.LatestHouseholdList(ctx, item.HouseholdID)
+	if errors.Is(err, ErrListNotFound) {
+		return consumption, nil
+	}
+	if err != nil {
+		return Consumption{}, err
+	}
+
+	result := newApplyResult(list.ID)
+	entry := itemEntry{name: item.Name, quantity: *item.Threshold, category: item.Category}
+	if err := s.lists.stockItem(ctx, actor, list, entry, &result); err != nil {
+		return Consumption{}, err
+	}
+	for _, items := range [][]Item{result.Added, result.Updated, result.Unchanged} {
+		if len(items) > 0 {
+			consumption.ListItem = &items[0]
+		}
+	}
+
+	return consumption, nil
+}
+
+// validateStock accepts any amount that is not negative, since stock can run out.
+func validateStock(q quantity.Quantity) error {
+	if q.Value == 0 && q.Unit.Valid() {
+		return nil
+	}
+	if err := q.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	return nil
+}
+
+func validateThreshold(stock quantity.Quantity, threshold *quantity.Quantity) error {
+	if threshold == nil {
+		return nil
+	}
+	if err := threshold.Validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidThreshold, err)
+	}
+	if !stock.Compatible(*threshold) {
+		return fmt.Errorf("%w: %s and %s measure different things", ErrInvalidThreshold, stock.Unit, threshold.Unit)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"time"
+)
+
+// PantryItem is something a household has at home. Its quantity drops to zero
+// rather than going away when it is used up, so its threshold keeps applying:
+// once the stock falls below the threshold the item is put back on the
+// household's shopping list.
+type PantryItem struct {
+	ID          uuid.UUID          `json:"id"`
+	HouseholdID uuid.UUID          `json:"household_id"`
+	Name        string             `json:"name"`
+	Quantity    quantity.Quantity  `json:"quantity"`
+	Category    string             `json:"category"`
+	Threshold   *quantity.Quantity `json:"threshold,omitempty"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty"`
+	CreatedAt   time.Time          `json:"created_at"`
+	UpdatedAt   time.Time          `json:"updated_at"`
+}
+
+// NewPantryItem is the input for adding stock. Stock of an item the pantry
+// already has in a compatible unit is added to it.
+type NewPantryItem struct {
+	NewItem
+	Threshold *quantity.Quantity `json:"threshold"`
+	ExpiresAt *time.Time         `json:"expires_at"`
+}
+
+// PantryUpdate carries the fields of a pantry item to edit; nil fields are
+// left as is. Quantity sets the stock rather than adding to it.
+type PantryUpdate struct {
+	Name      *string            `json:"name"`
+	Quantity  *quantity.Quantity `json:"quantity"`
+	Threshold *quantity.Quantity `json:"threshold"`
+	ExpiresAt *time.Time         `json:"expires_at"`
+}
+
+// Consumption is a pantry item after some of it was used. ListItem is the
+// shopping list item it was put back on when the stock fell below its threshold.
+type Consumption struct {
+	PantryItem
+	ListItem *Item `json:"list_item,omitempty"`
+}
+
+// low reports whether the stock is below the item's threshold.
+func (p PantryItem) low() bool {
+	if p.Threshold == nil {
+		return false
+	}
+	cmp, err := p.Quantity.Compare(*p.Threshold)
+	return err == nil && cmp < 0
+}
+
+// findPantryItem returns the household's pantry item with the name whose unit
+// is compatible with qty.
+func findPantryItem(ctx context.Context, storage PantryStorage, householdID uuid.UUID, name string, qty quantity.Quantity) (PantryItem, bool, error) {
+	candidates, err := storage.PantryItemsByName(ctx, householdID, name)
+	if err != nil {
+		return PantryItem{}, false, err
+	}
+	for _, candidate := range candidates {
+		if candidate.Quantity.Compatible(qty) {
+			return candidate, true, nil
+		}
+	}
+	return PantryItem{}, false, nil
+}
+
+// pantryShortfall returns how much of the needed amount the household's
+// pantry does not cover, in the needed amount's unit.
+func pantryShortfall(ctx context.Context, storage PantryStorage, householdID uuid.UUID, name string, needed quantity.Quantity) (quantity.Quantity, error) {
+	item, found, err := findPantryItem(ctx, storage, householdID, name, needed)
+	if err != nil || !found {
+		return needed, err
+	}
+	return needed.Sub(item.Quantity)
+}
+
+// addToPantry adds qty of the named item to the household's pantry, creating
+// a pantry item when there is none with a compatible unit.
+func addToPantry(ctx context.Context, storage PantryStorage, householdID uuid.UUID, name string, qty quantity.Quantity, category string, now time.Time) (PantryItem, error) {
+	item, found, err := findPantryItem(ctx, storage, householdID, name, qty)
+	if err != nil {
+		return PantryItem{}, err
+	}
+
+	if !found {
+		item = PantryItem{
+			ID:          uuid.New(),
+			HouseholdID: householdID,
+			Name:        name,
+			Quantity:    qty,
+			Category:    category,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		return item, storage.CreatePantryItem(ctx, item)
+	}
+
+	if item.Quantity.Value == 0 {
+		// The old stock is gone, and with it its expiry date.
+		item.Quantity, item.ExpiresAt = qty, nil
+	} else {
+		total, err := item.Quantity.Add(qty)
+		if err != nil {
+			return PantryItem{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		item.Quantity = total
+	}
+	item.UpdatedAt = now
+
+	return item, storage.UpdatePantryItem(ctx, item)
+}
+
+// takeFromPantry removes up to qty of the named item from the household's
+// pantry. It does nothing when the pantry has no such item.
+func takeFromPantry(ctx context.Context, storage PantryStorage, householdID uuid.UUID, name string, qty quantity.Quantity, now time.Time) error {
+	item, found, err := findPantryItem(ctx, storage, householdID, name, qty)
+	if err != nil || !found {
+		return err
+	}
+
+	left, err := item.Quantity.Sub(qty)
+	if err != nil {
+		return err
+	}
+	item.Quantity = left
+	item.UpdatedAt = now
+
+	return storage.UpdatePantryItem(ctx, item)
+}
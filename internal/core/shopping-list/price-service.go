@@ -0,0 +1,193 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PriceStorage persists the prices households paid at their stores.
+type PriceStorage interface {
+	RecordPrice(ctx context.Context, price Price) error
+	// PriceHistory returns the household's prices for a name, matched
+	// case-insensitively, at all of its stores, newest first.
+	PriceHistory(ctx context.Context, householdID uuid.UUID, name string) ([]Price, error)
+	// LatestPrices returns the store's newest price for each of the names and
+	// units it has prices in, newest first.
+	LatestPrices(ctx context.Context, storeID uuid.UUID, names []string) ([]Price, error)
+	// Spend sums the household's prices in the currency paid from one time up
+	// to another, in one category or, when category is empty, in all of them
+	// together with the unitemized part of the trips finished meanwhile.
+	Spend(ctx context.Context, householdID uuid.UUID, category, currency string, from, to time.Time) (int64, error)
+}
+
+// PriceService records what households pay at their stores and uses it to
+// estimate what a list will cost. Members can view prices and editors can
+// record them. Recorded prices are what household budgets track.
+type PriceService struct {
+	storage    PriceStorage
+	households HouseholdStorage
+	stores     StoreStorage
+	lists      *ListService
+	budgets    *BudgetService
+}
+
+func NewPriceService(storage PriceStorage, households HouseholdStorage, stores StoreStorage, lists *ListService, budgets *BudgetService) *PriceService {
+	return &PriceService{
+		storage:    storage,
+		households: households,
+		stores:     stores,
+		lists:      lists,
+		budgets:    budgets,
+	}
+}
+
+// RecordPrice records what was paid for an item at the store and alerts the
+// household when that takes spending past a budget threshold.
+func (s *PriceService) RecordPrice(ctx context.Context, actor, storeID uuid.UUID, in NewPrice) (Price, error) {
+	price, err := s.newPrice(in)
+	if err != nil {
+		return Price{}, err
+	}
+
+	store, err := s.stores.GetStore(ctx, storeID)
+	if err != nil {
+		return Price{}, err
+	}
+	if err := requireRole(ctx, s.households, store.HouseholdID, actor, RoleEditor); err != nil {
+		return Price{}, err
+	}
+
+	return s.record(ctx, actor, store, price)
+}
+
+// newPrice validates the input and builds a price from it that is not yet
+// tied to a store.
+func (s *PriceService) newPrice(in NewPrice) (Price, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return Price{}, ErrEmptyName
+	}
+	qty := quantity.One
+	if in.Quantity != nil {
+		if err := in.Quantity.Validate(); err != nil {
+			return Price{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+		}
+		qty = *in.Quantity
+	}
+	if in.Amount < 0 {
+		return Price{}, ErrInvalidAmount
+	}
+	currency, err := parseCurrency(in.Currency)
+	if err != nil {
+		return Price{}, err
+	}
+	if in.Category != "" && !s.lists.categorizer.Known(in.Category) {
+		return Price{}, fmt.Errorf("%w %q", ErrUnknownCategory, in.Category)
+	}
+
+	now := time.Now()
+	price := Price{
+		ID:        uuid.New(),
+		Name:      name,
+		Quantity:  qty,
+		Category:  in.Category,
+		Amount:    in.Amount,
+		Currency:  currency,
+		PaidAt:    now,
+		CreatedAt: now,
+	}
+	if in.PaidAt != nil {
+		price.PaidAt = *in.PaidAt
+	}
+
+	return price, nil
+}
+
+// record saves a price paid at the store, categorizing it when it has no
+// category, and tracks it against the household's budgets.
+func (s *PriceService) record(ctx context.Context, actor uuid.UUID, store Store, price Price) (Price, error) {
+	price.HouseholdID = store.HouseholdID
+	price.StoreID = store.ID
+	price.StoreName = store.Name
+	if price.Category == "" {
+		category, err := s.lists.categorizer.Categorize(ctx, store.HouseholdID, price.Name)
+		if err != nil {
+			return Price{}, err
+		}
+		price.Category = category
+	}
+
+	if err := s.storage.RecordPrice(ctx, price); err != nil {
+		return Price{}, err
+	}
+	if err := s.budgets.recordSpend(ctx, actor, price); err != nil {
+		return Price{}, err
+	}
+
+	return price, nil
+}
+
+// PriceHistory returns what the household paid for an item at each of its
+// stores, newest first.
+func (s *PriceService) PriceHistory(ctx context.Context, actor, householdID uuid.UUID, name string) ([]Price, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.PriceHistory(ctx, householdID, name)
+}
+
+// CompareStores estimates the list at each of the household's stores, best
+// first: stores that price more of the list come before those that price
+// less, and among those the cheapest comes first.
+func (s *PriceService) CompareStores(ctx context.Context, actor, listID uuid.UUID) ([]StoreEstimate, error) {
+	list, err := s.lists.authorize(ctx, actor, listID, RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.lists.storage.ListItems(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+	stores, err := s.stores.HouseholdStores(ctx, list.HouseholdID)
+	if err != nil {
+		return nil, err
+	}
+
+	estimates := make([]StoreEstimate, 0, len(stores))
+	for _, store := range stores {
+		result, err := estimate(ctx, s.storage, store.ID, items)
+		if err != nil {
+			return nil, err
+		}
+		estimates = append(estimates, StoreEstimate{StoreID: store.ID, StoreName: store.Name, Estimate: result})
+	}
+	sort.SliceStable(estimates, func(i, j int) bool {
+		return cheaper(estimates[i], estimates[j])
+	})
+
+	return estimates, nil
+}
+
+// parseCurrency accepts a three-letter ISO 4217 code in either case.
+func parseCurrency(s string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(s))
+	if len(code) != 3 {
+		return "", ErrInvalidCurrency
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", ErrInvalidCurrency
+		}
+	}
+	return code, nil
+}
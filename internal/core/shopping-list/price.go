@@ -0,0 +1,140 @@
+package shopping_list
+
+import (
+	"context"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"math"
+	"sort"
+	"time"
+)
+
+// Price is what a household paid for an amount of an item at one of its
+// stores. Amount is in the currency's minor unit, such as cents.
+type Price struct {
+	ID          uuid.UUID         `json:"id"`
+	HouseholdID uuid.UUID         `json:"household_id"`
+	StoreID     uuid.UUID         `json:"store_id"`
+	StoreName   string            `json:"store_name"`
+	Name        string            `json:"name"`
+	Quantity    quantity.Quantity `json:"quantity"`
+	Category    string            `json:"category"`
+	Amount      int64             `json:"amount"`
+	Currency    string            `json:"currency"`
+	PaidAt      time.Time         `json:"paid_at"`
+	CreatedAt   time.Time         `json:"created_at"`
+}
+
+// NewPrice is the input for recording a price. A missing quantity means one
+// piece and a missing time means now. Currency is an ISO 4217 code such as
+// "EUR". Without a category the item is categorized like a list item.
+type NewPrice struct {
+	Name     string             `json:"name"`
+	Quantity *quantity.Quantity `json:"quantity"`
+	Category string             `json:"category"`
+	Amount   int64              `json:"amount"`
+	Currency string             `json:"currency"`
+	PaidAt   *time.Time         `json:"paid_at"`
+}
+
+type Money struct {
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// Estimate is what a list is expected to cost at a store, from the latest
+// prices paid there. Totals has one entry per currency the prices were paid
+// in. Unpriced names the items the store has no price for.
+type Estimate struct {
+	Totals   []Money  `json:"totals"`
+	Priced   int      `json:"priced"`
+	Unpriced []string `json:"unpriced"`
+}
+
+// StoreEstimate is a list's estimate at one of the household's stores.
+type StoreEstimate struct {
+	StoreID   uuid.UUID `json:"store_id"`
+	StoreName string    `json:"store_name"`
+	Estimate
+}
+
+// cost is what qty of the item costs at the price, scaled from the amount
+// the price was paid for. It fails when the units measure different things.
+func (p Price) cost(qty quantity.Quantity) (int64, error) {
+	converted, err := qty.Convert(p.Quantity.Unit)
+	if err != nil {
+		return 0, err
+	}
+	return int64(math.Round(float64(p.Amount) * converted.Value / p.Quantity.Value)), nil
+}
+
+// estimate prices the items at the store. Each item uses the newest price
+// recorded for its name in a compatible unit; LatestPrices returns the
+// newest first.
+func estimate(ctx context.Context, storage PriceStorage, storeID uuid.UUID, items []Item) (Estimate, error) {
+	result := Estimate{Totals: []Money{}, Unpriced: []string{}}
+	if len(items) == 0 {
+		return result, nil
+	}
+
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	prices, err := storage.LatestPrices(ctx, storeID, names)
+	if err != nil {
+		return Estimate{}, err
+	}
+
+	byName := make(map[string][]Price)
+	for _, price := range prices {
+		key := categories.Normalize(price.Name)
+		byName[key] = append(byName[key], price)
+	}
+
+	totals := make(map[string]int64)
+	for _, item := range items {
+		var latest Price
+		found := false
+		for _, price := range byName[categories.Normalize(item.Name)] {
+			if price.Quantity.Compatible(item.Quantity) {
+				latest, found = price, true
+				break
+			}
+		}
+		if !found {
+			result.Unpriced = append(result.Unpriced, item.Name)
+			continue
+		}
+
+		cost, err := latest.cost(item.Quantity)
+		if err != nil {
+			return Estimate{}, err
+		}
+		totals[latest.Currency] += cost
+		result.Priced++
+	}
+
+	for currency, amount := range totals {
+		result.Totals = append(result.Totals, Money{Amount: amount, Currency: currency})
+	}
+	sort.Slice(result.Totals, func(i, j int) bool {
+		return result.Totals[i].Currency < result.Totals[j].Currency
+	})
+
+	return result, nil
+}
+
+// cheaper reports whether a is the better store to shop the list at than b:
+// it prices more of the list, or as much of it for less. Totals in different
+// currencies are not compared.
+func cheaper(a, b StoreEstimate) bool {
+	if a.Priced != b.Priced {
+		return a.Priced > b.Priced
+	}
+	if len(a.Totals) == 1 && len(b.Totals) == 1 && a.Totals[0].Currency == b.Totals[0].Currency {
+		return a.Totals[0].Amount < b.Totals[0].Amount
+	}
+	return false
+}
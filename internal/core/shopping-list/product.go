@@ -0,0 +1,23 @@
+package shopping_list
+
+import (
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"time"
+)
+
+// Product is a catalog entry for a barcode. Quantity is what one scan adds to
+// a list, such as 1 l for a carton of milk.
+type Product struct {
+	GTIN      string            `json:"gtin"`
+	Name      string            `json:"name"`
+	Brand     string            `json:"brand"`
+	Category  string            `json:"category"`
+	Quantity  quantity.Quantity `json:"quantity"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ScanInput is a barcode read by a client: EAN-13, UPC-A or EAN-8.
+type ScanInput struct {
+	GTIN string `json:"gtin"`
+}
@@ -0,0 +1,112 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/receipt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// ReceiptService reads receipts into price history. Editors of the store's
+// household can submit receipts.
+type ReceiptService struct {
+	households HouseholdStorage
+	stores     StoreStorage
+	lists      *ListService
+	prices     *PriceService
+}
+
+func NewReceiptService(households HouseholdStorage, stores StoreStorage, lists *ListService, prices *PriceService) *ReceiptService {
+	return &ReceiptService{
+		households: households,
+		stores:     stores,
+		lists:      lists,
+		prices:     prices,
+	}
+}
+
+// Ingest reads a receipt and matches its lines against the items the household
+// checked off from a day before the receipt was paid on. Each matched line's
+// amount is recorded as the price of its item, for the amount on the receipt
+// or, when the receipt does not say, the item's quantity.
+func (s *ReceiptService) Ingest(ctx context.Context, actor uuid.UUID, in ReceiptInput) (ReceiptResult, error) {
+	if strings.TrimSpace(in.Text) == "" {
+		return ReceiptResult{}, ErrEmptyReceipt
+	}
+	name := in.Format
+	if name == "" {
+		name = receipt.Generic
+	}
+	format, err := receipt.Lookup(name)
+	if err != nil {
+		return ReceiptResult{}, fmt.Errorf("%w: %v", ErrInvalid, err)
+	}
+	currency, err := parseCurrency(in.Currency)
+	if err != nil {
+		return ReceiptResult{}, err
+	}
+	paidAt := time.Now()
+	if in.PaidAt != nil {
+		paidAt = *in.PaidAt
+	}
+
+	store, err := s.stores.GetStore(ctx, in.StoreID)
+	if err != nil {
+		return ReceiptResult{}, err
+	}
+	if err := requireRole(ctx, s.households, store.HouseholdID, actor, RoleEditor); err != nil {
+		return ReceiptResult{}, err
+	}
+
+	parsed := format.Parse(in.Text)
+	items, err := s.lists.storage.CheckedItems(ctx, store.HouseholdID, paidAt.Add(-receiptWindow))
+	if err != nil {
+		return ReceiptResult{}, err
+	}
+	matches := matchReceipt(parsed.Lines, items)
+
+	result := ReceiptResult{
+		StoreID:   store.ID,
+		Matched:   []ReceiptMatch{},
+		Unmatched: []receipt.Line{},
+		Ignored:   parsed.Ignored,
+		Total:     parsed.Total,
+	}
+	for l, line := range parsed.Lines {
+		i, ok := matches[l]
+		if !ok {
+			result.Unmatched = append(result.Unmatched, line)
+			continue
+		}
+
+		item := items[i]
+		bought := item.Quantity
+		if line.Quantity != nil {
+			bought = *line.Quantity
+		}
+		category := item.Category
+		if !s.lists.categorizer.Known(category) {
+			category = ""
+		}
+		price, err := s.prices.newPrice(NewPrice{
+			Name:     item.Name,
+			Quantity: &bought,
+			Category: category,
+			Amount:   line.Amount,
+			Currency: currency,
+			PaidAt:   &paidAt,
+		})
+		if err != nil {
+			return ReceiptResult{}, err
+		}
+		price, err = s.prices.record(ctx, actor, store, price)
+		if err != nil {
+			return ReceiptResult{}, err
+		}
+		result.Matched = append(result.Matched, ReceiptMatch{Line: line, Item: item, Price: price})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package shopping_list
+
+import (
+	"github.com/PocketPalCo/shopping-service/pkg/fuzzy"
+	"github.com/PocketPalCo/shopping-service/pkg/receipt"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// ReceiptMatchThreshold is the trigram similarity a receipt line's name needs
+// with a checked-off item's name to be taken for it.
+const ReceiptMatchThreshold = 0.3
+
+// receiptWindow is how long before a receipt was paid an item may have been
+// checked off to be matched against it.
+const receiptWindow = 24 * time.Hour
+
+// ReceiptInput is a receipt's text, such as the OCR output of a photo, and
+// where it is from. Format names the receipt layout, "generic" by default; a
+// missing time means now.
+type ReceiptInput struct {
+	StoreID  uuid.UUID  `json:"store_id"`
+	Text     string     `json:"text"`
+	Format   string     `json:"format"`
+	Currency string     `json:"currency"`
+	PaidAt   *time.Time `json:"paid_at"`
+}
+
+// ReceiptMatch is a receipt line taken for a checked-off item, with the price
+// recorded for it.
+type ReceiptMatch struct {
+	Line  receipt.Line `json:"line"`
+	Item  Item         `json:"item"`
+	Price Price        `json:"price"`
+}
+
+// ReceiptResult is what reading a receipt did. Unmatched lines are items that
+// match nothing checked off; their prices can be recorded by hand. Ignored
+// lines are not items at all.
+type ReceiptResult struct {
+	StoreID   uuid.UUID      `json:"store_id"`
+	Matched   []ReceiptMatch `json:"matched"`
+	Unmatched []receipt.Line `json:"unmatched"`
+	Ignored   []string       `json:"ignored"`
+	Total     *int64         `json:"total,omitempty"`
+}
+
+// matchReceipt pairs receipt lines with checked-off items by name, each item
+// with at most one line. The most similar pairs are taken first. It returns
+// the index of the item each line was matched with.
+func matchReceipt(lines []receipt.Line, items []Item) map[int]int {
+	type pair struct {
+		line, item int
+		score      float64
+	}
+
+	var pairs []pair
+	for l, line := range lines {
+		for i, item := range items {
+			if score := fuzzy.Trigram(line.Name, item.Name); score >= ReceiptMatchThreshold {
+				pairs = append(pairs, pair{line: l, item: i, score: score})
+			}
+		}
+	}
+	sort.SliceStable(pairs, func(a, b int) bool {
+		return pairs[a].score > pairs[b].score
+	})
+
+	matches := make(map[int]int)
+	taken := make(map[int]bool)
+	for _, p := range pairs {
+		if _, ok := matches[p.line]; ok || taken[p.item] {
+			continue
+		}
+		matches[p.line] = p.item
+		taken[p.item] = true
+	}
+	return matches
+}
@@ -0,0 +1,168 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// RecipeStorage persists a household's recipes.
+type RecipeStorage interface {
+	HouseholdRecipes(ctx context.Context, householdID uuid.UUID) ([]Recipe, error)
+	GetRecipe(ctx context.Context, id uuid.UUID) (Recipe, error)
+	CreateRecipe(ctx context.Context, recipe Recipe) error
+	UpdateRecipe(ctx context.Context, recipe Recipe) error
+	DeleteRecipe(ctx context.Context, id uuid.UUID) error
+}
+
+// RecipeService manages a household's recipes and puts their ingredients on
+// lists. Members can view recipes, editors can create and change them and
+// owners can delete them.
+type RecipeService struct {
+	storage    RecipeStorage
+	households HouseholdStorage
+	lists      *ListService
+}
+
+func NewRecipeService(storage RecipeStorage, households HouseholdStorage, lists *ListService) *RecipeService {
+	return &RecipeService{
+		storage:    storage,
+		households: households,
+		lists:      lists,
+	}
+}
+
+func (s *RecipeService) authorize(ctx context.Context, actor, recipeID uuid.UUID, required Role) (Recipe, error) {
+	recipe, err := s.storage.GetRecipe(ctx, recipeID)
+	if err != nil {
+		return Recipe{}, err
+	}
+	if err := requireRole(ctx, s.households, recipe.HouseholdID, actor, required); err != nil {
+		return Recipe{}, err
+	}
+	return recipe, nil
+}
+
+func (s *RecipeService) Recipes(ctx context.Context, actor, householdID uuid.UUID) ([]Recipe, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.HouseholdRecipes(ctx, householdID)
+}
+
+func (s *RecipeService) Recipe(ctx context.Context, actor, id uuid.UUID) (Recipe, error) {
+	return s.authorize(ctx, actor, id, RoleViewer)
+}
+
+func (s *RecipeService) CreateRecipe(ctx context.Context, actor, householdID uuid.UUID, in RecipeInput) (Recipe, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return Recipe{}, err
+	}
+
+	now := time.Now()
+	recipe := Recipe{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		CreatedAt:   now,
+	}
+	if err := s.fill(&recipe, in, now); err != nil {
+		return Recipe{}, err
+	}
+	if err := s.storage.CreateRecipe(ctx, recipe); err != nil {
+		return Recipe{}, err
+	}
+
+	return recipe, nil
+}
+
+// UpdateRecipe replaces the recipe's name, servings, ingredients and instructions.
+func (s *RecipeService) UpdateRecipe(ctx context.Context, actor, id uuid.UUID, in RecipeInput) (Recipe, error) {
+	recipe, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return Recipe{}, err
+	}
+
+	if err := s.fill(&recipe, in, time.Now()); err != nil {
+		return Recipe{}, err
+	}
+	if err := s.storage.UpdateRecipe(ctx, recipe); err != nil {
+		return Recipe{}, err
+	}
+
+	return recipe, nil
+}
+
+func (s *RecipeService) DeleteRecipe(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleOwner); err != nil {
+		return err
+	}
+	return s.storage.DeleteRecipe(ctx, id)
+}
+
+// AddToList scales the recipe to the given servings, or its own servings when
+// zero, and merges the ingredients into a list of the same household: the
+// given one, or the household's newest. Ingredients the pantry covers are
+// skipped, and partly covered ones are added for the missing amount.
+func (s *RecipeService) AddToList(ctx context.Context, actor, recipeID uuid.UUID, listID *uuid.UUID, servings int) (IngredientResult, error) {
+	if servings < 0 || servings > MaxServings {
+		return IngredientResult{}, ErrInvalidServings
+	}
+
+	recipe, err := s.authorize(ctx, actor, recipeID, RoleViewer)
+	if err != nil {
+		return IngredientResult{}, err
+	}
+	if servings == 0 {
+		servings = recipe.Servings
+	}
+
+	list, err := s.lists.targetList(ctx, actor, recipe.HouseholdID, listID)
+	if err != nil {
+		return IngredientResult{}, err
+	}
+
+	ingredients, err := recipe.Scale(servings)
+	if err != nil {
+		return IngredientResult{}, err
+	}
+
+	return s.lists.addIngredients(ctx, actor, list, ingredients)
+}
+
+// fill validates the input and copies it onto the recipe.
+func (s *RecipeService) fill(recipe *Recipe, in RecipeInput, now time.Time) error {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	if in.Servings <= 0 || in.Servings > MaxServings {
+		return ErrInvalidServings
+	}
+
+	ingredients := make([]Ingredient, 0, len(in.Ingredients))
+	for _, newItem := range in.Ingredients {
+		entry, err := resolveItem(newItem)
+		if err != nil {
+			return err
+		}
+		if entry.category != "" && !s.lists.categorizer.Known(entry.category) {
+			return fmt.Errorf("%w %q", ErrUnknownCategory, entry.category)
+		}
+		ingredients = append(ingredients, Ingredient{
+			Name:     entry.name,
+			Quantity: entry.quantity,
+			Note:     entry.note,
+			Category: entry.category,
+		})
+	}
+
+	recipe.Name = name
+	recipe.Servings = in.Servings
+	recipe.Ingredients = ingredients
+	recipe.Instructions = strings.TrimSpace(in.Instructions)
+	recipe.UpdatedAt = now
+
+	return nil
+}
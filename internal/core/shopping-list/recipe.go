@@ -0,0 +1,78 @@
+package shopping_list
+
+import (
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"math"
+	"time"
+)
+
+// MaxServings is the most servings a recipe is written or scaled for.
+const MaxServings = 1000
+
+// Recipe lists the ingredients for its base number of servings.
+type Recipe struct {
+	ID           uuid.UUID    `json:"id"`
+	HouseholdID  uuid.UUID    `json:"household_id"`
+	Name         string       `json:"name"`
+	Servings     int          `json:"servings"`
+	Ingredients  []Ingredient `json:"ingredients"`
+	Instructions string       `json:"instructions"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+}
+
+type Ingredient struct {
+	Name     string            `json:"name"`
+	Quantity quantity.Quantity `json:"quantity"`
+	Note     string            `json:"note,omitempty"`
+	Category string            `json:"category,omitempty"`
+}
+
+// RecipeInput is the input for creating or replacing a recipe. Ingredients
+// accept the same fields as adding an item to a list, including free text.
+type RecipeInput struct {
+	Name         string    `json:"name"`
+	Servings     int       `json:"servings"`
+	Ingredients  []NewItem `json:"ingredients"`
+	Instructions string    `json:"instructions"`
+}
+
+// IngredientResult reports how ingredients were put on a list: the list items
+// they were added or merged into, and the ingredients the pantry covered.
+type IngredientResult struct {
+	ListID  uuid.UUID    `json:"list_id"`
+	Added   []AddedItem  `json:"added"`
+	Skipped []Ingredient `json:"skipped"`
+}
+
+// Scale returns the ingredients for the given number of servings. Pieces and
+// packs are rounded up, since half an egg cannot be bought. It fails with
+// ErrInvalidServings when the servings are out of range or an ingredient
+// scales to an amount a quantity cannot hold, such as a pinch of salt that
+// rounds to nothing.
+func (r Recipe) Scale(servings int) ([]Ingredient, error) {
+	if servings <= 0 || servings > MaxServings {
+		return nil, ErrInvalidServings
+	}
+	factor := float64(servings) / float64(r.Servings)
+
+	scaled := make([]Ingredient, 0, len(r.Ingredients))
+	for _, ingredient := range r.Ingredients {
+		ingredient.Quantity = ingredient.Quantity.Scale(factor)
+		if dimension := ingredient.Quantity.Unit.Dimension(); dimension == quantity.Count || dimension == quantity.Packs {
+			ingredient.Quantity.Value = math.Ceil(ingredient.Quantity.Value)
+		}
+		if err := ingredient.Quantity.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %s for %d: %v", ErrInvalidServings, ingredient.Name, servings, err)
+		}
+		scaled = append(scaled, ingredient)
+	}
+
+	return scaled, nil
+}
+
+func (i Ingredient) entry() itemEntry {
+	return itemEntry{name: i.Name, quantity: i.Quantity, note: i.Note, category: i.Category}
+}
@@ -0,0 +1,43 @@
+package shopping_list
+
+import (
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"testing"
+)
+
+func TestRecipeScale(t *testing.T) {
+	recipe := Recipe{Name: "Pancakes", Servings: 4, Ingredients: []Ingredient{
+		{Name: "Flour", Quantity: quantity.New(250, quantity.Gram)},
+		{Name: "Eggs", Quantity: quantity.New(2, quantity.Pieces)},
+	}}
+
+	scaled, err := recipe.Scale(3)
+	if err != nil {
+		t.Fatalf("Scale() = %v", err)
+	}
+	want := []quantity.Quantity{quantity.New(187.5, quantity.Gram), quantity.New(2, quantity.Pieces)}
+	for i, ingredient := range scaled {
+		if ingredient.Quantity != want[i] {
+			t.Errorf("%s = %v, want %v", ingredient.Name, ingredient.Quantity, want[i])
+		}
+	}
+	if recipe.Ingredients[0].Quantity.Value != 250 {
+		t.Error("Scale() changed the recipe")
+	}
+}
+
+func TestRecipeScaleInvalid(t *testing.T) {
+	recipe := Recipe{Name: "Soup", Servings: 4, Ingredients: []Ingredient{
+		{Name: "Salt", Quantity: quantity.New(0.001, quantity.Kilogram)},
+		{Name: "Water", Quantity: quantity.New(5e6, quantity.Liter)},
+	}}
+
+	// 1 serving needs 0.00025 kg of salt, which rounds to nothing, and 1000
+	// servings a billion litres of water.
+	for _, servings := range []int{-1, 0, 1, MaxServings, MaxServings + 1} {
+		if _, err := recipe.Scale(servings); !errors.Is(err, ErrInvalidServings) {
+			t.Errorf("Scale(%d) = %v, want ErrInvalidServings", servings, err)
+		}
+	}
+}
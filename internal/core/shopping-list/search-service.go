@@ -0,0 +1,49 @@
+package shopping_list
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// SearchStorage searches what a user can see.
+type SearchStorage interface {
+	// Search matches the query against the lists and items of the user's
+	// households, their recipes and the product catalog. Lists and items in
+	// the trash are left out.
+	Search(ctx context.Context, userID uuid.UUID, query SearchQuery) (SearchResults, error)
+}
+
+// SearchService searches everything a user has access to at once.
+type SearchService struct {
+	storage SearchStorage
+}
+
+func NewSearchService(storage SearchStorage) *SearchService {
+	return &SearchService{
+		storage: storage,
+	}
+}
+
+// Search finds the lists, items, recipes and products matching text, read in
+// the given language or, without one, in every one of SearchLanguages.
+func (s *SearchService) Search(ctx context.Context, actor uuid.UUID, text, language string, limit int) (SearchResults, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > MaxSearchLength {
+		return SearchResults{}, ErrInvalidSearch
+	}
+	if limit < 1 || limit > MaxSearchLimit {
+		return SearchResults{}, ErrInvalidLimit
+	}
+	languages := SearchLanguages
+	if language != "" {
+		if !slices.Contains(SearchLanguages, language) {
+			return SearchResults{}, ErrInvalidLanguage
+		}
+		languages = []string{language}
+	}
+
+	return s.storage.Search(ctx, actor, SearchQuery{Text: text, Languages: languages, Limit: limit})
+}
@@ -0,0 +1,48 @@
+package shopping_list
+
+import "github.com/google/uuid"
+
+// DefaultSearchLimit and MaxSearchLimit bound how many results of each type a
+// search returns.
+const (
+	DefaultSearchLimit = 10
+	MaxSearchLimit     = 500
+)
+
+// MaxSearchLength is the longest query searched for, in characters.
+const MaxSearchLength = 200
+
+// SearchLanguages are the text search configurations documents are indexed
+// with. A search uses all of them unless it names one: english matches other
+// forms of a word and simple only the word as written, which is all there is
+// for languages without a stemmer, such as Ukrainian.
+var SearchLanguages = []string{"english", "simple"}
+
+// SearchQuery is a validated search: Languages are the configurations the
+// text is read with and Limit applies to each type of result.
+type SearchQuery struct {
+	Text      string
+	Languages []string
+	Limit     int
+}
+
+// SearchHit is a list, item, recipe or product matching a search, best first
+// by Rank. Highlight is its text with the matched words wrapped in <mark>
+// tags. ID is a product's GTIN; ListID is set for items and HouseholdID for
+// everything but products.
+type SearchHit struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Highlight   string     `json:"highlight"`
+	Rank        float64    `json:"rank"`
+	ListID      *uuid.UUID `json:"list_id,omitempty"`
+	HouseholdID *uuid.UUID `json:"household_id,omitempty"`
+}
+
+// SearchResults are a search's hits grouped by type.
+type SearchResults struct {
+	Lists    []SearchHit `json:"lists"`
+	Items    []SearchHit `json:"items"`
+	Recipes  []SearchHit `json:"recipes"`
+	Products []SearchHit `json:"products"`
+}
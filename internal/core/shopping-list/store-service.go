@@ -0,0 +1,135 @@
+package shopping_list
+
+import (
+	"context"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// StoreStorage persists store profiles.
+type StoreStorage interface {
+	HouseholdStores(ctx context.Context, householdID uuid.UUID) ([]Store, error)
+	GetStore(ctx context.Context, id uuid.UUID) (Store, error)
+	CreateStore(ctx context.Context, store Store) error
+	UpdateStore(ctx context.Context, store Store) error
+	DeleteStore(ctx context.Context, id uuid.UUID) error
+}
+
+// StoreService manages a household's store profiles. Members can view them,
+// editors can create and change them and owners can delete them.
+type StoreService struct {
+	storage    StoreStorage
+	households HouseholdStorage
+	dictionary *categories.Dictionary
+}
+
+func NewStoreService(storage StoreStorage, households HouseholdStorage, dictionary *categories.Dictionary) *StoreService {
+	return &StoreService{
+		storage:    storage,
+		households: households,
+		dictionary: dictionary,
+	}
+}
+
+func (s *StoreService) authorize(ctx context.Context, actor, storeID uuid.UUID, required Role) (Store, error) {
+	store, err := s.storage.GetStore(ctx, storeID)
+	if err != nil {
+		return Store{}, err
+	}
+	if err := requireRole(ctx, s.households, store.HouseholdID, actor, required); err != nil {
+		return Store{}, err
+	}
+	return store, nil
+}
+
+func (s *StoreService) Stores(ctx context.Context, actor, householdID uuid.UUID) ([]Store, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.HouseholdStores(ctx, householdID)
+}
+
+func (s *StoreService) Store(ctx context.Context, actor, id uuid.UUID) (Store, error) {
+	return s.authorize(ctx, actor, id, RoleViewer)
+}
+
+func (s *StoreService) CreateStore(ctx context.Context, actor, householdID uuid.UUID, in StoreInput) (Store, error) {
+	name, sections, err := s.validate(in)
+	if err != nil {
+		return Store{}, err
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return Store{}, err
+	}
+
+	now := time.Now()
+	store := Store{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		Name:        name,
+		Sections:    sections,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := s.storage.CreateStore(ctx, store); err != nil {
+		return Store{}, err
+	}
+
+	return store, nil
+}
+
+// UpdateStore replaces the store's name and sections.
+func (s *StoreService) UpdateStore(ctx context.Context, actor, id uuid.UUID, in StoreInput) (Store, error) {
+	name, sections, err := s.validate(in)
+	if err != nil {
+		return Store{}, err
+	}
+
+	store, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return Store{}, err
+	}
+
+	store.Name = name
+	store.Sections = sections
+	store.UpdatedAt = time.Now()
+	if err := s.storage.UpdateStore(ctx, store); err != nil {
+		return Store{}, err
+	}
+
+	return store, nil
+}
+
+func (s *StoreService) DeleteStore(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleOwner); err != nil {
+		return err
+	}
+	return s.storage.DeleteStore(ctx, id)
+}
+
+func (s *StoreService) validate(in StoreInput) (string, []StoreSection, error) {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return "", nil, ErrEmptyName
+	}
+
+	sections := make([]StoreSection, 0, len(in.Sections))
+	seen := make(map[string]bool, len(in.Sections))
+	for _, section := range in.Sections {
+		section.Category = strings.TrimSpace(section.Category)
+		section.Aisle = strings.TrimSpace(section.Aisle)
+		if _, ok := s.dictionary.Category(section.Category); !ok {
+			return "", nil, fmt.Errorf("%w %q", ErrUnknownCategory, section.Category)
+		}
+		if seen[section.Category] {
+			return "", nil, ErrDuplicateSection
+		}
+		seen[section.Category] = true
+		sections = append(sections, section)
+	}
+
+	return name, sections, nil
+}
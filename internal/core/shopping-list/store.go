@@ -0,0 +1,56 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// Store is a shop a household visits. Sections list the store's categories in
+// the order a shopper walks past them, optionally with the aisle they are in.
+type Store struct {
+	ID          uuid.UUID      `json:"id"`
+	HouseholdID uuid.UUID      `json:"household_id"`
+	Name        string         `json:"name"`
+	Sections    []StoreSection `json:"sections"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+type StoreSection struct {
+	Category string `json:"category"`
+	Aisle    string `json:"aisle,omitempty"`
+}
+
+// StoreInput is the input for creating or editing a store.
+type StoreInput struct {
+	Name     string         `json:"name"`
+	Sections []StoreSection `json:"sections"`
+}
+
+// Route orders category groups along the store's walking route. Categories
+// the store has no section for come last, in their original order.
+func (s Store) Route(groups []CategoryGroup) []CategoryGroup {
+	stops := make(map[string]int, len(s.Sections))
+	for i, section := range s.Sections {
+		stops[section.Category] = i
+	}
+	stop := func(group CategoryGroup) int {
+		if i, ok := stops[group.Category.ID]; ok {
+			return i
+		}
+		return len(s.Sections)
+	}
+
+	routed := append([]CategoryGroup(nil), groups...)
+	for i := range routed {
+		if j, ok := stops[routed[i].Category.ID]; ok {
+			routed[i].Aisle = s.Sections[j].Aisle
+		}
+	}
+	sort.SliceStable(routed, func(i, j int) bool {
+		return stop(routed[i]) < stop(routed[j])
+	})
+
+	return routed
+}
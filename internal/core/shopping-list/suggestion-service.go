@@ -0,0 +1,107 @@
+package shopping_list
+
+import (
+	"cmp"
+	"context"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/repurchase"
+	"github.com/google/uuid"
+	"math"
+	"slices"
+	"time"
+)
+
+// SuggestionStorage reads what a household bought.
+type SuggestionStorage interface {
+	// Purchases returns the items checked off the household's lists since the
+	// given time, oldest first, leaving out check-offs that were undone.
+	Purchases(ctx context.Context, householdID uuid.UUID, since time.Time) ([]Purchase, error)
+	// OpenItemNames returns the names of the unchecked items on the
+	// household's lists.
+	OpenItemNames(ctx context.Context, householdID uuid.UUID) ([]string, error)
+}
+
+// SuggestionService suggests what a household probably needs from how often
+// it checked items off before. Members can see the suggestions.
+type SuggestionService struct {
+	storage    SuggestionStorage
+	households HouseholdStorage
+}
+
+func NewSuggestionService(storage SuggestionStorage, households HouseholdStorage) *SuggestionService {
+	return &SuggestionService{
+		storage:    storage,
+		households: households,
+	}
+}
+
+// Suggestions estimates for each item the household bought, by normalized
+// name, how long it goes between buying it, and returns those that are likely
+// due, surest first. Items already on one of its lists are left out.
+func (s *SuggestionService) Suggestions(ctx context.Context, actor, householdID uuid.UUID, limit int) ([]Suggestion, error) {
+	if limit < 1 || limit > MaxSuggestionLimit {
+		return nil, ErrInvalidLimit
+	}
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	purchases, err := s.storage.Purchases(ctx, householdID, now.Add(-SuggestionHistory))
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.storage.OpenItemNames(ctx, householdID)
+	if err != nil {
+		return nil, err
+	}
+	onList := make(map[string]bool, len(open))
+	for _, name := range open {
+		onList[categories.Normalize(name)] = true
+	}
+
+	// The latest purchase names the item, as it was last written.
+	times := make(map[string][]time.Time)
+	latest := make(map[string]Purchase)
+	for _, purchase := range purchases {
+		key := categories.Normalize(purchase.Name)
+		if key == "" || onList[key] {
+			continue
+		}
+		times[key] = append(times[key], purchase.CheckedAt)
+		latest[key] = purchase
+	}
+
+	suggestions := make([]Suggestion, 0)
+	for key, checkedAt := range times {
+		habit, ok := repurchase.Estimate(checkedAt)
+		if !ok {
+			continue
+		}
+		confidence := habit.Confidence(now)
+		if confidence < MinSuggestionConfidence {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Name:       latest[key].Name,
+			Category:   latest[key].Category,
+			LastBought: habit.Last,
+			Interval:   math.Round(habit.Interval.Hours()/24*10) / 10,
+			DueAt:      habit.Due(),
+			Purchases:  habit.Purchases,
+			Confidence: math.Round(confidence*1000) / 1000,
+		})
+	}
+
+	slices.SortFunc(suggestions, func(a, b Suggestion) int {
+		return cmp.Or(
+			cmp.Compare(b.Confidence, a.Confidence),
+			a.DueAt.Compare(b.DueAt),
+			cmp.Compare(a.Name, b.Name),
+		)
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
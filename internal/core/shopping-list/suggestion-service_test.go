@@ -0,0 +1,99 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+type memorySuggestions struct {
+	purchases []Purchase
+	open      []string
+}
+
+func (m *memorySuggestions) Purchases(_ context.Context, _ uuid.UUID, since time.Time) ([]Purchase, error) {
+	var purchases []Purchase
+	for _, purchase := range m.purchases {
+		if !purchase.CheckedAt.Before(since) {
+			purchases = append(purchases, purchase)
+		}
+	}
+	return purchases, nil
+}
+
+func (m *memorySuggestions) OpenItemNames(context.Context, uuid.UUID) ([]string, error) {
+	return m.open, nil
+}
+
+func TestSuggestions(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	now := time.Now()
+	daysAgo := func(name string, days ...int) []Purchase {
+		var purchases []Purchase
+		for _, d := range days {
+			purchases = append(purchases, Purchase{Name: name, Category: "dairy", CheckedAt: now.Add(-time.Duration(d) * 24 * time.Hour)})
+		}
+		return purchases
+	}
+	storage := &memorySuggestions{open: []string{"Apples"}}
+	for _, purchases := range [][]Purchase{
+		daysAgo("milk", 400, 28, 21, 14),
+		daysAgo("Milk", 7),
+		daysAgo("Bread", 9, 6, 3),
+		// bought too recently to be due yet
+		daysAgo("Coffee", 14, 7, 1),
+		// bought once
+		daysAgo("Eggs", 5),
+		// due, but already on a list
+		daysAgo("apples", 21, 14, 7),
+	} {
+		storage.purchases = append(storage.purchases, purchases...)
+	}
+	s := NewSuggestionService(storage, h.members)
+
+	suggestions, err := s.Suggestions(ctx, h.viewer, h.id, DefaultSuggestionLimit)
+	if err != nil {
+		t.Fatalf("Suggestions() = %v", err)
+	}
+
+	want := []struct {
+		name       string
+		purchases  int
+		confidence float64
+	}{{"Milk", 4, 0.6}, {"Bread", 3, 0.5}}
+	if len(suggestions) != len(want) {
+		t.Fatalf("Suggestions() = %+v, want %d suggestions", suggestions, len(want))
+	}
+	for i, w := range want {
+		got := suggestions[i]
+		if got.Name != w.name || got.Purchases != w.purchases || got.Confidence != w.confidence {
+			t.Errorf("suggestion %d = %+v, want %s from %d purchases at %v", i, got, w.name, w.purchases, w.confidence)
+		}
+	}
+	if milk := suggestions[0]; milk.Interval != 7 || !milk.DueAt.Equal(now) || !milk.LastBought.Equal(now.Add(-7 * 24 * time.Hour)) {
+		t.Errorf("milk = %+v, want it due a week after it was last bought", milk)
+	}
+
+	limited, err := s.Suggestions(ctx, h.viewer, h.id, 1)
+	if err != nil || len(limited) != 1 || limited[0].Name != "Milk" {
+		t.Errorf("Suggestions() limited to 1 = %+v, %v, want just milk", limited, err)
+	}
+}
+
+func TestSuggestionsInvalid(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	s := NewSuggestionService(&memorySuggestions{}, h.members)
+
+	for _, limit := range []int{0, MaxSuggestionLimit + 1} {
+		if _, err := s.Suggestions(ctx, h.viewer, h.id, limit); !errors.Is(err, ErrInvalidLimit) {
+			t.Errorf("Suggestions() limited to %d = %v, want ErrInvalidLimit", limit, err)
+		}
+	}
+	if _, err := s.Suggestions(ctx, uuid.New(), h.id, DefaultSuggestionLimit); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Suggestions() by a non-member = %v, want ErrNotMember", err)
+	}
+}
@@ -0,0 +1,38 @@
+package shopping_list
+
+import "time"
+
+// DefaultSuggestionLimit and MaxSuggestionLimit bound how many suggestions a
+// request returns.
+const (
+	DefaultSuggestionLimit = 20
+	MaxSuggestionLimit     = 500
+)
+
+const (
+	// SuggestionHistory is how far back purchases are looked at.
+	SuggestionHistory = 365 * 24 * time.Hour
+	// MinSuggestionConfidence leaves out items too unlikely to be needed.
+	MinSuggestionConfidence = 0.1
+)
+
+// Purchase is an item checked off one of a household's lists, as it was
+// checked off.
+type Purchase struct {
+	Name      string
+	Category  string
+	CheckedAt time.Time
+}
+
+// Suggestion is an item the household probably needs, going by how often it
+// bought it before. Interval is the time it usually goes between buying it, in
+// days; Confidence, from 0 to 1, how likely it is to be needed now.
+type Suggestion struct {
+	Name       string    `json:"name"`
+	Category   string    `json:"category"`
+	LastBought time.Time `json:"last_bought"`
+	Interval   float64   `json:"interval"`
+	DueAt      time.Time `json:"due_at"`
+	Purchases  int       `json:"purchases"`
+	Confidence float64   `json:"confidence"`
+}
@@ -0,0 +1,369 @@
+package shopping_list
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/crdt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// ReplicaStorage persists the replicated state of lists.
+type ReplicaStorage interface {
+	// GetListReplica returns the list's replica, with a nil Document when the
+	// list was never synced. It locks the list until the transaction ends so
+	// that syncs of the same list run one after another.
+	GetListReplica(ctx context.Context, listID uuid.UUID) (ListReplica, error)
+	SaveListReplica(ctx context.Context, replica ListReplica) error
+}
+
+// SyncService merges the offline edits of mobile clients into lists. Viewers
+// can fetch the canonical state, editors can send operations.
+type SyncService struct {
+	storage ReplicaStorage
+	lists   *ListService
+}
+
+func NewSyncService(storage ReplicaStorage, lists *ListService) *SyncService {
+	return &SyncService{
+		storage: storage,
+		lists:   lists,
+	}
+}
+
+// Sync merges a client's operations into the list and returns the result.
+// Changes made through the rest of the API since the last sync are taken into
+// the replica first, as operations of the server. The merged state is then
+// written back to the list's items, recording each change in the list
+// history, and items checked off by the merge go into the pantry as usual.
+func (s *SyncService) Sync(ctx context.Context, actor, listID uuid.UUID, in SyncInput) (SyncResult, error) {
+	if in.ClientID == "" || in.ClientID == serverClient {
+		return SyncResult{}, ErrInvalidClientID
+	}
+	required := RoleViewer
+	if len(in.Ops) > 0 {
+		required = RoleEditor
+	}
+	list, err := s.lists.authorize(ctx, actor, listID, required)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	for i := range in.Ops {
+		in.Ops[i].Client = in.ClientID
+		if err := normalizeSyncOp(&in.Ops[i], s.lists.categorizer); err != nil {
+			return SyncResult{}, err
+		}
+	}
+
+	replica, err := s.storage.GetListReplica(ctx, list.ID)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	if replica.Document == nil {
+		replica = ListReplica{ListID: list.ID, Document: crdt.New()}
+	}
+	doc := replica.Document
+
+	items, err := s.lists.storage.ListItems(ctx, list.ID)
+	if err != nil {
+		return SyncResult{}, err
+	}
+	server := &serverOps{doc: doc}
+	if err := server.mirror(items); err != nil {
+		return SyncResult{}, err
+	}
+	for _, op := range in.Ops {
+		if err := doc.Apply(op); err != nil {
+			return SyncResult{}, fmt.Errorf("%w: %v", ErrInvalidSyncOp, err)
+		}
+	}
+	if err := s.fillDefaults(ctx, list, server); err != nil {
+		return SyncResult{}, err
+	}
+
+	synced, err := s.materialize(ctx, actor, list, doc, items)
+	if err != nil {
+		return SyncResult{}, err
+	}
+
+	if len(in.Ops) > 0 || server.applied > 0 || replica.Version == 0 {
+		replica.Version++
+		replica.UpdatedAt = time.Now()
+		if err := s.storage.SaveListReplica(ctx, replica); err != nil {
+			return SyncResult{}, err
+		}
+	}
+
+	return SyncResult{
+		ListID: list.ID,
+		Cursor: strconv.FormatInt(replica.Version, 10),
+		Clock:  doc.Clock,
+		Items:  synced,
+	}, nil
+}
+
+// fillDefaults gives items added without a quantity one piece and items
+// added without a category the one the list would pick for their name.
+func (s *SyncService) fillDefaults(ctx context.Context, list List, server *serverOps) error {
+	for _, id := range server.doc.Order() {
+		var item Item
+		if err := documentItem(server.doc, id, &item); err != nil {
+			return err
+		}
+
+		fields := make(map[string]any)
+		if _, ok := server.doc.Field(id, fieldQuantity); !ok {
+			fields[fieldQuantity] = quantity.One
+		}
+		if _, ok := server.doc.Field(id, fieldCategory); !ok {
+			category, err := s.lists.categorizer.Categorize(ctx, list.HouseholdID, item.Name)
+			if err != nil {
+				return err
+			}
+			fields[fieldCategory] = category
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		op := server.op(crdt.Set, id)
+		for name, value := range fields {
+			op.Fields[name] = mustEncode(value)
+		}
+		if err := server.apply(op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// materialize writes the document's items to the list: items that are alive
+// are created or updated and put in the document's order, the others are
+// removed. An item added again after it was removed comes back out of the
+// list's trash; an item to create whose ID is taken otherwise makes the sync
+// invalid. It returns the items in order.
+func (s *SyncService) materialize(ctx context.Context, actor uuid.UUID, list List, doc *crdt.Document, items []Item) ([]SyncedItem, error) {
+	existing := make(map[uuid.UUID]Item, len(items))
+	for _, item := range items {
+		existing[item.ID] = item
+	}
+	position, err := s.lists.storage.NextItemPosition(ctx, list.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	order := doc.Order()
+	ids := make([]uuid.UUID, 0, len(order))
+	merged := make([]Item, 0, len(order))
+	for _, key := range order {
+		id := uuid.MustParse(key)
+		item, ok := existing[id]
+		created := false
+		if ok {
+			delete(existing, id)
+		} else {
+			// A new item's ID is the client's choice and must not be taken,
+			// except by an item removed from this list that is added again.
+			taken, err := s.lists.storage.ItemExists(ctx, id)
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				if item, err = s.restore(ctx, actor, list, id); err != nil {
+					return nil, err
+				}
+			} else {
+				now := time.Now()
+				item = Item{ID: id, ListID: list.ID, Position: position, CreatedAt: now, UpdatedAt: now}
+				created = true
+				position++
+			}
+		}
+
+		want := item
+		if err := documentItem(doc, key, &want); err != nil {
+			return nil, err
+		}
+		if item, err = s.write(ctx, actor, list, item, want, created); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+		merged = append(merged, item)
+	}
+
+	for _, item := range existing {
+		if err := s.lists.storage.DeleteItem(ctx, list.ID, item.ID); err != nil {
+			return nil, err
+		}
+		if _, err := s.lists.record(ctx, actor, list, itemEvent(ItemRemoved, &item, nil)); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(merged, func(a, b int) bool {
+		return merged[a].Position < merged[b].Position
+	})
+	arrangement, merged, err := s.lists.arrange(ctx, merged, ids)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Equal(arrangement.Before, arrangement.After) {
+		if _, err := s.lists.record(ctx, actor, list, ListEvent{Type: ItemsReordered, Order: &arrangement}); err != nil {
+			return nil, err
+		}
+	}
+
+	synced := make([]SyncedItem, 0, len(merged))
+	for _, item := range merged {
+		key := item.ID.String()
+		synced = append(synced, SyncedItem{Item: item, Slot: doc.Slot(key), Tags: doc.Tags(key)})
+	}
+	return synced, nil
+}
+
+// restore takes an item that was removed from the list out of its trash and
+// records it as added again, as it was removed; write then gives it the
+// document's fields. An ID that is not in the list's trash belongs to another
+// list and makes the sync invalid.
+func (s *SyncService) restore(ctx context.Context, actor uuid.UUID, list List, id uuid.UUID) (Item, error) {
+	err := s.lists.storage.RestoreItem(ctx, list.ID, id)
+	if errors.Is(err, ErrItemNotFound) {
+		return Item{}, fmt.Errorf("%w: item %s already exists", ErrInvalidSyncOp, id)
+	}
+	if err != nil {
+		return Item{}, err
+	}
+	item, err := s.lists.storage.GetItem(ctx, list.ID, id)
+	if err != nil {
+		return Item{}, err
+	}
+	if _, err := s.lists.record(ctx, actor, list, itemEvent(ItemAdded, nil, &item)); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// write brings an item to the wanted state the way the rest of the API would:
+// a new item is created, changed fields are saved as an edit and a changed
+// checked state goes through checking off, which moves pantry stock.
+func (s *SyncService) write(ctx context.Context, actor uuid.UUID, list List, item, want Item, created bool) (Item, error) {
+	if created {
+		item.Name, item.Quantity, item.Note, item.Category = want.Name, want.Quantity, want.Note, want.Category
+		if err := s.lists.storage.CreateItem(ctx, item); err != nil {
+			return Item{}, err
+		}
+		if _, err := s.lists.record(ctx, actor, list, itemEvent(ItemAdded, nil, &item)); err != nil {
+			return Item{}, err
+		}
+	} else if item.Name != want.Name || item.Quantity != want.Quantity || item.Note != want.Note || item.Category != want.Category {
+		before := item
+		item.Name, item.Quantity, item.Note, item.Category = want.Name, want.Quantity, want.Note, want.Category
+		item.UpdatedAt = time.Now()
+		if err := s.lists.saveItem(ctx, actor, list, before, item); err != nil {
+			return Item{}, err
+		}
+	}
+
+	if item.Checked == want.Checked {
+		return item, nil
+	}
+	before := item
+	item, err := s.lists.check(ctx, list, item, want.Checked)
+	if err != nil {
+		return Item{}, err
+	}
+	eventType := ItemUnchecked
+	if want.Checked {
+		eventType = ItemChecked
+	}
+	if _, err := s.lists.record(ctx, actor, list, itemEvent(eventType, &before, &item)); err != nil {
+		return Item{}, err
+	}
+
+	return item, nil
+}
+
+// serverOps makes operations on behalf of the server, each with a clock above
+// every operation the document has seen.
+type serverOps struct {
+	doc     *crdt.Document
+	applied int
+}
+
+func (s *serverOps) op(opType crdt.OpType, item string) crdt.Op {
+	return crdt.Op{
+		ID:     uuid.NewString(),
+		Type:   opType,
+		Item:   item,
+		Clock:  s.doc.Clock + 1,
+		Client: serverClient,
+		Fields: make(map[string]json.RawMessage),
+	}
+}
+
+func (s *serverOps) apply(op crdt.Op) error {
+	s.applied++
+	return s.doc.Apply(op)
+}
+
+// mirror makes the document match the list's items, which are in list order,
+// so that changes made outside sync are merged like any other.
+func (s *serverOps) mirror(items []Item) error {
+	present := make(map[string]bool, len(items))
+	after := ""
+	for _, item := range items {
+		key := item.ID.String()
+		present[key] = true
+		fields := itemFields(item)
+
+		switch changed := changedFields(s.doc, key, fields); {
+		case !s.doc.Alive(key):
+			op := s.op(crdt.Add, key)
+			op.Fields, op.After = fields, after
+			if err := s.apply(op); err != nil {
+				return err
+			}
+		case len(changed) > 0:
+			op := s.op(crdt.Set, key)
+			op.Fields = changed
+			if err := s.apply(op); err != nil {
+				return err
+			}
+		}
+		after = s.doc.Slot(key)
+	}
+
+	for _, key := range s.doc.Order() {
+		if present[key] {
+			continue
+		}
+		op := s.op(crdt.Remove, key)
+		op.Observed = s.doc.Tags(key)
+		if err := s.apply(op); err != nil {
+			return err
+		}
+	}
+
+	order := s.doc.Order()
+	if len(order) == len(items) && slices.EqualFunc(order, items, func(key string, item Item) bool {
+		return key == item.ID.String()
+	}) {
+		return nil
+	}
+	after = ""
+	for _, item := range items {
+		op := s.op(crdt.Move, item.ID.String())
+		op.After = after
+		if err := s.apply(op); err != nil {
+			return err
+		}
+		after = op.ID
+	}
+	return nil
+}
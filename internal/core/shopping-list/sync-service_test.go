@@ -0,0 +1,182 @@
+package shopping_list
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/crdt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"testing"
+)
+
+type memoryReplicas struct {
+	replicas map[uuid.UUID]ListReplica
+}
+
+func (m *memoryReplicas) GetListReplica(_ context.Context, listID uuid.UUID) (ListReplica, error) {
+	return m.replicas[listID], nil
+}
+
+func (m *memoryReplicas) SaveListReplica(_ context.Context, replica ListReplica) error {
+	m.replicas[replica.ListID] = replica
+	return nil
+}
+
+func newSyncService(h *household) *SyncService {
+	return NewSyncService(&memoryReplicas{replicas: make(map[uuid.UUID]ListReplica)}, h.service)
+}
+
+func addOp(id uuid.UUID, name string) crdt.Op {
+	return crdt.Op{
+		ID:     uuid.NewString(),
+		Type:   crdt.Add,
+		Item:   id.String(),
+		Clock:  1,
+		Fields: map[string]json.RawMessage{fieldName: mustEncode(name)},
+	}
+}
+
+func TestSyncCreatesClientItems(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	bread := h.add(t, "Bread")
+	s := newSyncService(h)
+	milk := uuid.New()
+
+	result, err := s.Sync(ctx, h.editor, h.list.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{addOp(milk, "Milk")}})
+	if err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+
+	if len(result.Items) != 2 {
+		t.Fatalf("Sync() returned %d items, want 2", len(result.Items))
+	}
+	item, ok := h.lists.items[milk]
+	if !ok {
+		t.Fatal("the client's item was not created with its ID")
+	}
+	if item.Name != "Milk" || item.Quantity != quantity.One || item.Category != "dairy" {
+		t.Errorf("created %+v, want one piece of Milk in dairy", item)
+	}
+	if _, ok := h.lists.items[bread.ID]; !ok {
+		t.Error("the item added through the API is gone")
+	}
+	last := h.history.events[len(h.history.events)-1]
+	if last.Type != ItemAdded || *last.ItemID != milk || *last.ActorID != h.editor {
+		t.Errorf("last event = %+v, want the editor adding milk", last)
+	}
+	if result.Cursor != "1" {
+		t.Errorf("Cursor = %q, want 1", result.Cursor)
+	}
+}
+
+// A client picks the IDs of the items it adds, and must not be able to take
+// over an item of another list, or one in another list's trash, by reusing
+// its ID.
+func TestSyncRejectsTakenItemIDs(t *testing.T) {
+	ctx := context.Background()
+	for _, trashed := range []bool{false, true} {
+		h := newHousehold(t)
+		taken := h.add(t, "Milk")
+		if trashed {
+			if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, taken.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		other := List{ID: uuid.New(), HouseholdID: h.id, Name: "Pharmacy"}
+		h.lists.lists[other.ID] = other
+		created := h.lists.created
+
+		_, err := newSyncService(h).Sync(ctx, h.editor, other.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{addOp(taken.ID, "Plasters")}})
+		if !errors.Is(err, ErrInvalidSyncOp) {
+			t.Errorf("Sync() adding the ID of another list's item (trashed: %v) = %v, want ErrInvalidSyncOp", trashed, err)
+		}
+		if h.lists.created != created {
+			t.Errorf("Sync() adding the ID of another list's item (trashed: %v) stored an item", trashed)
+		}
+		if item, ok := h.lists.items[taken.ID]; ok && item.ListID != h.list.ID {
+			t.Errorf("Sync() moved the item to the other list")
+		}
+	}
+}
+
+// Adding an item again after removing it is how an add-wins merge brings it
+// back, so the item comes out of the list's trash with the fields it is
+// added with.
+func TestSyncAddsRemovedItemAgain(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	s := newSyncService(h)
+	milk := uuid.New()
+
+	added, err := s.Sync(ctx, h.editor, h.list.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{addOp(milk, "Milk")}})
+	if err != nil {
+		t.Fatalf("Sync() adding = %v", err)
+	}
+	remove := crdt.Op{ID: uuid.NewString(), Type: crdt.Remove, Item: milk.String(), Clock: added.Clock + 1, Observed: added.Items[0].Tags}
+	if _, err := s.Sync(ctx, h.editor, h.list.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{remove}}); err != nil {
+		t.Fatalf("Sync() removing = %v", err)
+	}
+	if _, ok := h.lists.trash[milk]; !ok {
+		t.Fatal("the removed item is not in the trash")
+	}
+
+	again := addOp(milk, "Oat milk")
+	again.Clock = added.Clock + 2
+	result, err := s.Sync(ctx, h.editor, h.list.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{again}})
+	if err != nil {
+		t.Fatalf("Sync() adding again = %v", err)
+	}
+
+	if len(result.Items) != 1 || result.Items[0].ID != milk {
+		t.Fatalf("Sync() returned %+v, want the item back", result.Items)
+	}
+	if item, ok := h.lists.items[milk]; !ok || item.Name != "Oat milk" {
+		t.Errorf("item = %+v (on the list: %v), want it restored as Oat milk", item, ok)
+	}
+	if _, ok := h.lists.trash[milk]; ok {
+		t.Error("the item is still in the trash")
+	}
+	events := h.history.events[len(h.history.events)-2:]
+	if events[0].Type != ItemAdded || events[1].Type != ItemEdited {
+		t.Errorf("last events = %s, %s, want the item added again and renamed", events[0].Type, events[1].Type)
+	}
+}
+
+func TestSyncRemovesObservedItems(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	s := newSyncService(h)
+
+	fetched, err := s.Sync(ctx, h.viewer, h.list.ID, SyncInput{ClientID: "phone"})
+	if err != nil {
+		t.Fatalf("Sync() without operations = %v", err)
+	}
+	if len(fetched.Items) != 1 || fetched.Items[0].ID != milk.ID {
+		t.Fatalf("Sync() returned %+v, want just milk", fetched.Items)
+	}
+
+	remove := crdt.Op{ID: uuid.NewString(), Type: crdt.Remove, Item: milk.ID.String(), Clock: fetched.Clock + 1, Observed: fetched.Items[0].Tags}
+	if _, err := s.Sync(ctx, h.viewer, h.list.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{remove}}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("Sync() with operations by a viewer = %v, want ErrForbidden", err)
+	}
+	result, err := s.Sync(ctx, h.editor, h.list.ID, SyncInput{ClientID: "phone", Ops: []crdt.Op{remove}})
+	if err != nil {
+		t.Fatalf("Sync() = %v", err)
+	}
+
+	if len(result.Items) != 0 {
+		t.Errorf("Sync() returned %d items, want none", len(result.Items))
+	}
+	if _, ok := h.lists.trash[milk.ID]; !ok {
+		t.Error("the removed item is not in the trash")
+	}
+	if last := h.history.events[len(h.history.events)-1]; last.Type != ItemRemoved {
+		t.Errorf("last event = %s, want %s", last.Type, ItemRemoved)
+	}
+	if result.Cursor != "2" {
+		t.Errorf("Cursor = %q, want 2", result.Cursor)
+	}
+}
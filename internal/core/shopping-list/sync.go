@@ -0,0 +1,188 @@
+package shopping_list
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/crdt"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// serverClient is the client ID of the operations the server makes itself,
+// such as mirroring changes made through the rest of the API.
+const serverClient = "server"
+
+// Item fields that sync operations can write.
+const (
+	fieldName     = "name"
+	fieldQuantity = "quantity"
+	fieldNote     = "note"
+	fieldCategory = "category"
+	fieldChecked  = "checked"
+)
+
+// SyncInput is a batch of operations a client made offline, in any order.
+// ClientID identifies the device; it is stamped on every operation.
+type SyncInput struct {
+	ClientID string    `json:"client_id"`
+	Ops      []crdt.Op `json:"ops"`
+}
+
+// SyncedItem is an item with what a client needs to make operations on it:
+// the slot to put other items behind and the tags a remove must observe.
+type SyncedItem struct {
+	Item
+	Slot string   `json:"slot"`
+	Tags []string `json:"tags"`
+}
+
+// SyncResult is the canonical state of a list after a sync. Clock is the
+// highest clock the list has seen; the client's next operations should carry
+// larger ones. Cursor changes whenever the state does.
+type SyncResult struct {
+	ListID uuid.UUID    `json:"list_id"`
+	Cursor string       `json:"cursor"`
+	Clock  uint64       `json:"clock"`
+	Items  []SyncedItem `json:"items"`
+}
+
+// ListReplica is the replicated state of a list's items. Version counts the
+// syncs that changed it.
+type ListReplica struct {
+	ListID    uuid.UUID
+	Document  *crdt.Document
+	Version   int64
+	UpdatedAt time.Time
+}
+
+// itemFields encodes an item's fields the way sync operations carry them.
+func itemFields(item Item) map[string]json.RawMessage {
+	return map[string]json.RawMessage{
+		fieldName:     mustEncode(item.Name),
+		fieldQuantity: mustEncode(item.Quantity),
+		fieldNote:     mustEncode(item.Note),
+		fieldCategory: mustEncode(item.Category),
+		fieldChecked:  mustEncode(item.Checked),
+	}
+}
+
+func mustEncode(v any) json.RawMessage {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return data
+}
+
+// changedFields returns the fields whose value differs from the document's.
+func changedFields(doc *crdt.Document, id string, fields map[string]json.RawMessage) map[string]json.RawMessage {
+	changed := make(map[string]json.RawMessage)
+	for name, value := range fields {
+		if current, ok := doc.Field(id, name); !ok || !bytes.Equal(current, value) {
+			changed[name] = value
+		}
+	}
+	return changed
+}
+
+// documentItem reads the fields of an item from the document into item.
+func documentItem(doc *crdt.Document, id string, item *Item) error {
+	targets := map[string]any{
+		fieldName:     &item.Name,
+		fieldQuantity: &item.Quantity,
+		fieldNote:     &item.Note,
+		fieldCategory: &item.Category,
+		fieldChecked:  &item.Checked,
+	}
+	for name, target := range targets {
+		value, ok := doc.Field(id, name)
+		if !ok {
+			continue
+		}
+		if err := json.Unmarshal(value, target); err != nil {
+			return fmt.Errorf("item %s field %s: %w", id, name, err)
+		}
+	}
+	return nil
+}
+
+// normalizeSyncOp validates a client's operation and rewrites its IDs and
+// field values in the form the server writes them, so equal values compare
+// equal: uuid.Parse also takes upper case, braces and URNs.
+func normalizeSyncOp(op *crdt.Op, categorizer *Categorizer) error {
+	var ok bool
+	if op.ID, ok = canonicalUUID(op.ID); !ok {
+		return fmt.Errorf("%w: id must be a UUID", ErrInvalidSyncOp)
+	}
+	if op.Item, ok = canonicalUUID(op.Item); !ok {
+		return fmt.Errorf("%w: item must be a UUID", ErrInvalidSyncOp)
+	}
+	if op.After != "" {
+		if op.After, ok = canonicalUUID(op.After); !ok {
+			return fmt.Errorf("%w: after must be a slot", ErrInvalidSyncOp)
+		}
+	}
+	for i, tag := range op.Observed {
+		if op.Observed[i], ok = canonicalUUID(tag); !ok {
+			return fmt.Errorf("%w: observed must list tags", ErrInvalidSyncOp)
+		}
+	}
+	if op.Type == crdt.Add {
+		if _, ok := op.Fields[fieldName]; !ok {
+			return ErrEmptyName
+		}
+	}
+
+	for name, raw := range op.Fields {
+		var value any
+		var err error
+		switch name {
+		case fieldName:
+			var v string
+			if err = json.Unmarshal(raw, &v); err == nil && strings.TrimSpace(v) == "" {
+				return ErrEmptyName
+			}
+			value = strings.TrimSpace(v)
+		case fieldQuantity:
+			var v quantity.Quantity
+			if err = json.Unmarshal(raw, &v); err == nil {
+				err = v.Validate()
+			}
+			value = v
+		case fieldNote:
+			var v string
+			err = json.Unmarshal(raw, &v)
+			value = strings.TrimSpace(v)
+		case fieldCategory:
+			var v string
+			if err = json.Unmarshal(raw, &v); err == nil && !categorizer.Known(v) {
+				return ErrUnknownCategory
+			}
+			value = v
+		case fieldChecked:
+			var v bool
+			err = json.Unmarshal(raw, &v)
+			value = v
+		default:
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidSyncOp, name)
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidSyncOp, name, err)
+		}
+		op.Fields[name] = mustEncode(value)
+	}
+
+	return nil
+}
+
+// canonicalUUID returns the UUID in s in its lower-case hyphenated form.
+func canonicalUUID(s string) (string, bool) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	return id.String(), true
+}
@@ -0,0 +1,78 @@
+package shopping_list
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/crdt"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeSyncOpCanonicalizesIDs(t *testing.T) {
+	op := crdt.Op{
+		ID:       "{6F9619FF-8B86-D011-B42D-00C04FC964FF}",
+		Type:     crdt.Remove,
+		Item:     "A1B2C3D4-0000-4000-8000-00000000000A",
+		After:    "urn:uuid:0E8A4C9B-1111-4000-8000-000000000001",
+		Observed: []string{"0E8A4C9B-2222-4000-8000-000000000002"},
+	}
+	if err := normalizeSyncOp(&op, nil); err != nil {
+		t.Fatalf("normalizeSyncOp() = %v", err)
+	}
+
+	if op.ID != "6f9619ff-8b86-d011-b42d-00c04fc964ff" {
+		t.Errorf("ID = %q", op.ID)
+	}
+	if op.Item != "a1b2c3d4-0000-4000-8000-00000000000a" {
+		t.Errorf("Item = %q", op.Item)
+	}
+	if op.After != "0e8a4c9b-1111-4000-8000-000000000001" {
+		t.Errorf("After = %q", op.After)
+	}
+	if !slices.Equal(op.Observed, []string{"0e8a4c9b-2222-4000-8000-000000000002"}) {
+		t.Errorf("Observed = %q", op.Observed)
+	}
+}
+
+// An item written in another form than the server writes it must still be
+// the same element of the document, not a second one.
+func TestNormalizeSyncOpTargetsExistingItem(t *testing.T) {
+	const key = "a1b2c3d4-0000-4000-8000-00000000000a"
+	doc := crdt.New()
+	add := crdt.Op{ID: "0e8a4c9b-0000-4000-8000-000000000000", Type: crdt.Add, Item: key, Clock: 1, Client: serverClient,
+		Fields: map[string]json.RawMessage{fieldName: mustEncode("Milk")}}
+	if err := doc.Apply(add); err != nil {
+		t.Fatal(err)
+	}
+
+	set := crdt.Op{ID: "0e8a4c9b-0000-4000-8000-000000000001", Type: crdt.Set, Item: "A1B2C3D4-0000-4000-8000-00000000000A",
+		Clock: 2, Client: "phone", Fields: map[string]json.RawMessage{fieldName: json.RawMessage(`"Oat milk"`)}}
+	if err := normalizeSyncOp(&set, nil); err != nil {
+		t.Fatalf("normalizeSyncOp() = %v", err)
+	}
+	if err := doc.Apply(set); err != nil {
+		t.Fatal(err)
+	}
+
+	if order := doc.Order(); !slices.Equal(order, []string{key}) {
+		t.Fatalf("Order() = %q, want just %q", order, key)
+	}
+	if name, _ := doc.Field(key, fieldName); string(name) != `"Oat milk"` {
+		t.Errorf("name = %s, want \"Oat milk\"", name)
+	}
+}
+
+func TestNormalizeSyncOpRejectsInvalidIDs(t *testing.T) {
+	valid := "a1b2c3d4-0000-4000-8000-00000000000a"
+	cases := []crdt.Op{
+		{ID: "1", Type: crdt.Set, Item: valid},
+		{ID: valid, Type: crdt.Set, Item: "milk"},
+		{ID: valid, Type: crdt.Move, Item: valid, After: "start"},
+		{ID: valid, Type: crdt.Remove, Item: valid, Observed: []string{valid, "tag"}},
+	}
+	for _, op := range cases {
+		if err := normalizeSyncOp(&op, nil); !errors.Is(err, ErrInvalidSyncOp) {
+			t.Errorf("normalizeSyncOp(%+v) = %v, want ErrInvalidSyncOp", op, err)
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+// TemplateStorage persists templates and their schedules.
+type TemplateStorage interface {
+	HouseholdTemplates(ctx context.Context, householdID uuid.UUID) ([]Template, error)
+	GetTemplate(ctx context.Context, id uuid.UUID) (Template, error)
+	CreateTemplate(ctx context.Context, template Template) error
+	UpdateTemplate(ctx context.Context, template Template) error
+	DeleteTemplate(ctx context.Context, id uuid.UUID) error
+	// NextDueTemplate locks and returns a recurring template whose next run is
+	// at or before now, skipping templates locked by other transactions. It
+	// returns ErrTemplateNotFound when nothing is due.
+	NextDueTemplate(ctx context.Context, now time.Time) (Template, error)
+	SetNextRun(ctx context.Context, id uuid.UUID, next time.Time) error
+}
+
+// TemplateService manages a household's templates and applies them to lists.
+// Members can view and apply templates to lists they can edit, editors can
+// create and change templates and owners can delete them.
+type TemplateService struct {
+	storage    TemplateStorage
+	households HouseholdStorage
+	lists      *ListService
+}
+
+func NewTemplateService(storage TemplateStorage, households HouseholdStorage, lists *ListService) *TemplateService {
+	return &TemplateService{
+		storage:    storage,
+		households: households,
+		lists:      lists,
+	}
+}
+
+func (s *TemplateService) authorize(ctx context.Context, actor, templateID uuid.UUID, required Role) (Template, error) {
+	template, err := s.storage.GetTemplate(ctx, templateID)
+	if err != nil {
+		return Template{}, err
+	}
+	if err := requireRole(ctx, s.households, template.HouseholdID, actor, required); err != nil {
+		return Template{}, err
+	}
+	return template, nil
+}
+
+func (s *TemplateService) Templates(ctx context.Context, actor, householdID uuid.UUID) ([]Template, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.HouseholdTemplates(ctx, householdID)
+}
+
+func (s *TemplateService) Template(ctx context.Context, actor, id uuid.UUID) (Template, error) {
+	return s.authorize(ctx, actor, id, RoleViewer)
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, actor, householdID uuid.UUID, in TemplateInput) (Template, error) {
+	if err := requireRole(ctx, s.households, householdID, actor, RoleEditor); err != nil {
+		return Template{}, err
+	}
+
+	now := time.Now()
+	template := Template{
+		ID:          uuid.New(),
+		HouseholdID: householdID,
+		CreatedAt:   now,
+	}
+	if err := s.fill(ctx, &template, in, now); err != nil {
+		return Template{}, err
+	}
+	if err := s.storage.CreateTemplate(ctx, template); err != nil {
+		return Template{}, err
+	}
+
+	return template, nil
+}
+
+// UpdateTemplate replaces the template's name, items and schedule. The next
+// run is recalculated from now.
+func (s *TemplateService) UpdateTemplate(ctx context.Context, actor, id uuid.UUID, in TemplateInput) (Template, error) {
+	template, err := s.authorize(ctx, actor, id, RoleEditor)
+	if err != nil {
+		return Template{}, err
+	}
+
+	if err := s.fill(ctx, &template, in, time.Now()); err != nil {
+		return Template{}, err
+	}
+	if err := s.storage.UpdateTemplate(ctx, template); err != nil {
+		return Template{}, err
+	}
+
+	return template, nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, actor, id uuid.UUID) error {
+	if _, err := s.authorize(ctx, actor, id, RoleOwner); err != nil {
+		return err
+	}
+	return s.storage.DeleteTemplate(ctx, id)
+}
+
+// Apply puts the template's items on a list of the same household. Items the
+// list already has unchecked are topped up rather than duplicated.
+func (s *TemplateService) Apply(ctx context.Context, actor, templateID, listID uuid.UUID) (ApplyResult, error) {
+	template, err := s.authorize(ctx, actor, templateID, RoleViewer)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	list, err := s.lists.authorize(ctx, actor, listID, RoleEditor)
+	if err != nil {
+		return ApplyResult{}, err
+	}
+	if list.HouseholdID != template.HouseholdID {
+		return ApplyResult{}, ErrListNotFound
+	}
+
+	return s.apply(ctx, actor, template, list)
+}
+
+// ApplyNextDue applies one recurring template that is due and schedules its
+// next run. It returns the template's ID, also when applying it fails, or
+// uuid.Nil when no template is due. A template whose household has no list to
+// fill is skipped until its next run. Its changes are recorded in the list
+// history without an actor.
+func (s *TemplateService) ApplyNextDue(ctx context.Context, now time.Time) (uuid.UUID, error) {
+	template, err := s.storage.NextDueTemplate(ctx, now)
+	if errors.Is(err, ErrTemplateNotFound) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	list, err := s.targetList(ctx, template)
+	switch {
+	case errors.Is(err, ErrListNotFound):
+	case err != nil:
+		return template.ID, err
+	default:
+		if _, err := s.apply(ctx, uuid.Nil, template, list); err != nil {
+			return template.ID, err
+		}
+	}
+
+	return template.ID, s.storage.SetNextRun(ctx, template.ID, template.Cadence.Next(now.UTC()))
+}
+
+// SkipRun schedules the next run of a recurring template that failed to apply
+// at now, so that it does not hold back the templates due after it.
+func (s *TemplateService) SkipRun(ctx context.Context, id uuid.UUID, now time.Time) error {
+	template, err := s.storage.GetTemplate(ctx, id)
+	if err != nil {
+		return err
+	}
+	if template.Cadence == nil {
+		return nil
+	}
+	return s.storage.SetNextRun(ctx, template.ID, template.Cadence.Next(now.UTC()))
+}
+
+func (s *TemplateService) apply(ctx context.Context, actor uuid.UUID, template Template, list List) (ApplyResult, error) {
+	result := newApplyResult(list.ID)
+	for _, item := range template.Items {
+		if err := s.lists.stockItem(ctx, actor, list, item.entry(), &result); err != nil {
+			return ApplyResult{}, err
+		}
+	}
+	return result, nil
+}
+
+// targetList is the list a recurring template fills: its own list, or the
+// household's newest one.
+func (s *TemplateService) targetList(ctx context.Context, template Template) (List, error) {
+	if template.ListID != nil {
+		list, err := s.lists.storage.GetList(ctx, *template.ListID)
+		if err == nil && list.HouseholdID == template.HouseholdID {
+			return list, nil
+		}
+		if err != nil && !errors.Is(err, ErrListNotFound) {
+			return List{}, err
+		}
+	}
+	return s.lists.storage.LatestHouseholdList(ctx, template.HouseholdID)
+}
+
+// fill validates the input and copies it onto the template.
+func (s *TemplateService) fill(ctx context.Context, template *Template, in TemplateInput, now time.Time) error {
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return ErrEmptyName
+	}
+
+	items := make([]TemplateItem, 0, len(in.Items))
+	for _, newItem := range in.Items {
+		entry, err := resolveItem(newItem)
+		if err != nil {
+			return err
+		}
+		if entry.category != "" && !s.lists.categorizer.Known(entry.category) {
+			return fmt.Errorf("%w %q", ErrUnknownCategory, entry.category)
+		}
+		items = append(items, TemplateItem{
+			Name:     entry.name,
+			Quantity: entry.quantity,
+			Note:     entry.note,
+			Category: entry.category,
+		})
+	}
+
+	var nextRun *time.Time
+	if in.Cadence != nil {
+		if err := in.Cadence.Validate(); err != nil {
+			return ErrInvalidCadence
+		}
+		next := in.Cadence.Next(now.UTC())
+		nextRun = &next
+	}
+
+	if in.ListID != nil {
+		list, err := s.lists.storage.GetList(ctx, *in.ListID)
+		if err != nil {
+			return err
+		}
+		if list.HouseholdID != template.HouseholdID {
+			return ErrListNotFound
+		}
+	}
+
+	template.Name = name
+	template.Items = items
+	template.Cadence = in.Cadence
+	template.ListID = in.ListID
+	template.NextRunAt = nextRun
+	template.UpdatedAt = now
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package shopping_list
+
+import (
+	"github.com/PocketPalCo/shopping-service/pkg/cadence"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Template is a reusable set of items such as "weekly staples". A template
+// with a cadence is recurring: the scheduler applies it to ListID, or to the
+// household's newest list when ListID is not set, each time NextRunAt passes.
+// Monthly cadences run at midnight UTC.
+// A recurring item is a recurring template with a single item.
+type Template struct {
+	ID          uuid.UUID        `json:"id"`
+	HouseholdID uuid.UUID        `json:"household_id"`
+	Name        string           `json:"name"`
+	Items       []TemplateItem   `json:"items"`
+	Cadence     *cadence.Cadence `json:"cadence,omitempty"`
+	ListID      *uuid.UUID       `json:"list_id,omitempty"`
+	NextRunAt   *time.Time       `json:"next_run_at,omitempty"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type TemplateItem struct {
+	Name     string            `json:"name"`
+	Quantity quantity.Quantity `json:"quantity"`
+	Note     string            `json:"note,omitempty"`
+	Category string            `json:"category,omitempty"`
+}
+
+// TemplateInput is the input for creating or replacing a template. Items
+// accept the same fields as adding an item to a list, including free text.
+type TemplateInput struct {
+	Name    string           `json:"name"`
+	Items   []NewItem        `json:"items"`
+	Cadence *cadence.Cadence `json:"cadence"`
+	ListID  *uuid.UUID       `json:"list_id"`
+}
+
+func (t TemplateItem) entry() itemEntry {
+	return itemEntry{name: t.Name, quantity: t.Quantity, note: t.Note, category: t.Category}
+}
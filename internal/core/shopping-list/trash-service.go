@@ -0,0 +1,112 @@
+package shopping_list
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+)
+
+// TrashStorage reads and empties the households' trash. Lists and items are
+// moved to it by deleting them and out of it by restoring them, both through
+// ListStorage.
+type TrashStorage interface {
+	// TrashedLists returns the household's deleted lists, most recently
+	// deleted first.
+	TrashedLists(ctx context.Context, householdID uuid.UUID) ([]TrashedList, error)
+	// TrashedItems returns the deleted items of the household's lists that
+	// are not in the trash themselves, most recently deleted first.
+	TrashedItems(ctx context.Context, householdID uuid.UUID) ([]TrashedItem, error)
+	GetTrashedList(ctx context.Context, householdID, id uuid.UUID) (TrashedList, error)
+	GetTrashedItem(ctx context.Context, householdID, id uuid.UUID) (TrashedItem, error)
+	// EmptyTrash deletes everything in the household's trash for good.
+	EmptyTrash(ctx context.Context, householdID uuid.UUID) (EmptiedTrash, error)
+}
+
+// TrashService lets members see what their household deleted and bring it
+// back. Viewers can look, editors can restore items, and owners, who are the
+// ones deleting lists, can restore lists and empty the trash.
+type TrashService struct {
+	storage TrashStorage
+	lists   *ListService
+}
+
+func NewTrashService(storage TrashStorage, lists *ListService) *TrashService {
+	return &TrashService{
+		storage: storage,
+		lists:   lists,
+	}
+}
+
+func (s *TrashService) Trash(ctx context.Context, actor, householdID uuid.UUID) (Trash, error) {
+	if err := requireRole(ctx, s.lists.households, householdID, actor, RoleViewer); err != nil {
+		return Trash{}, err
+	}
+
+	lists, err := s.storage.TrashedLists(ctx, householdID)
+	if err != nil {
+		return Trash{}, err
+	}
+	items, err := s.storage.TrashedItems(ctx, householdID)
+	if err != nil {
+		return Trash{}, err
+	}
+	return Trash{Lists: lists, Items: items}, nil
+}
+
+// RestoreList takes the list out of the trash with the items it had.
+func (s *TrashService) RestoreList(ctx context.Context, actor, householdID, listID uuid.UUID) (List, error) {
+	if err := requireRole(ctx, s.lists.households, householdID, actor, RoleOwner); err != nil {
+		return List{}, err
+	}
+	trashed, err := s.storage.GetTrashedList(ctx, householdID, listID)
+	if err != nil {
+		return List{}, err
+	}
+
+	list := trashed.List
+	list.UpdatedAt = time.Now()
+	if err := s.lists.storage.RestoreList(ctx, list.ID); err != nil {
+		return List{}, err
+	}
+	if err := s.lists.storage.UpdateList(ctx, list); err != nil {
+		return List{}, err
+	}
+	return list, nil
+}
+
+// RestoreItem puts the item back at the end of its list and records that in
+// the list's history, so it can be undone like adding the item.
+func (s *TrashService) RestoreItem(ctx context.Context, actor, householdID, itemID uuid.UUID) (Item, error) {
+	if err := requireRole(ctx, s.lists.households, householdID, actor, RoleEditor); err != nil {
+		return Item{}, err
+	}
+	trashed, err := s.storage.GetTrashedItem(ctx, householdID, itemID)
+	if err != nil {
+		return Item{}, err
+	}
+	list, err := s.lists.storage.GetList(ctx, trashed.ListID)
+	if err != nil {
+		return Item{}, err
+	}
+
+	item := trashed.Item
+	if item.Position, err = s.lists.storage.NextItemPosition(ctx, list.ID); err != nil {
+		return Item{}, err
+	}
+	item.UpdatedAt = time.Now()
+	if err := s.lists.putBack(ctx, item); err != nil {
+		return Item{}, err
+	}
+	if _, err := s.lists.record(ctx, actor, list, itemEvent(ItemAdded, nil, &item)); err != nil {
+		return Item{}, err
+	}
+	return item, nil
+}
+
+// EmptyTrash deletes everything in the household's trash for good.
+func (s *TrashService) EmptyTrash(ctx context.Context, actor, householdID uuid.UUID) (EmptiedTrash, error) {
+	if err := requireRole(ctx, s.lists.households, householdID, actor, RoleOwner); err != nil {
+		return EmptiedTrash{}, err
+	}
+	return s.storage.EmptyTrash(ctx, householdID)
+}
@@ -0,0 +1,217 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+)
+
+// memoryTrash reads the households' trash out of the lists' storage.
+type memoryTrash struct {
+	lists *memoryLists
+}
+
+func (m *memoryTrash) TrashedLists(_ context.Context, householdID uuid.UUID) ([]TrashedList, error) {
+	var lists []TrashedList
+	for _, list := range m.lists.deleted {
+		if list.HouseholdID == householdID {
+			lists = append(lists, TrashedList{List: list})
+		}
+	}
+	return lists, nil
+}
+
+func (m *memoryTrash) TrashedItems(_ context.Context, householdID uuid.UUID) ([]TrashedItem, error) {
+	var items []TrashedItem
+	for _, item := range m.lists.trash {
+		if list, ok := m.lists.lists[item.ListID]; ok && list.HouseholdID == householdID {
+			items = append(items, TrashedItem{Item: item})
+		}
+	}
+	return items, nil
+}
+
+func (m *memoryTrash) GetTrashedList(_ context.Context, householdID, id uuid.UUID) (TrashedList, error) {
+	list, ok := m.lists.deleted[id]
+	if !ok || list.HouseholdID != householdID {
+		return TrashedList{}, ErrListNotFound
+	}
+	return TrashedList{List: list}, nil
+}
+
+func (m *memoryTrash) GetTrashedItem(ctx context.Context, householdID, id uuid.UUID) (TrashedItem, error) {
+	items, _ := m.TrashedItems(ctx, householdID)
+	for _, item := range items {
+		if item.ID == id {
+			return item, nil
+		}
+	}
+	return TrashedItem{}, ErrItemNotFound
+}
+
+func (m *memoryTrash) EmptyTrash(_ context.Context, householdID uuid.UUID) (EmptiedTrash, error) {
+	var emptied EmptiedTrash
+	for id, item := range m.lists.trash {
+		list, ok := m.lists.lists[item.ListID]
+		if !ok {
+			list = m.lists.deleted[item.ListID]
+		}
+		if list.HouseholdID == householdID {
+			delete(m.lists.trash, id)
+			emptied.Items++
+		}
+	}
+	for id, list := range m.lists.deleted {
+		if list.HouseholdID == householdID {
+			delete(m.lists.deleted, id)
+			emptied.Lists++
+		}
+	}
+	return emptied, nil
+}
+
+func newTrashService(h *household) *TrashService {
+	return NewTrashService(&memoryTrash{lists: h.lists}, h.service)
+}
+
+func TestTrashRestoreItem(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	h.add(t, "Bread")
+	s := newTrashService(h)
+	if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	trash, err := s.Trash(ctx, h.viewer, h.id)
+	if err != nil {
+		t.Fatalf("Trash() = %v", err)
+	}
+	if len(trash.Items) != 1 || trash.Items[0].ID != milk.ID {
+		t.Errorf("Trash() has items %+v, want just milk", trash.Items)
+	}
+
+	if _, err := s.RestoreItem(ctx, h.viewer, h.id, milk.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("RestoreItem() by a viewer = %v, want ErrForbidden", err)
+	}
+	restored, err := s.RestoreItem(ctx, h.editor, h.id, milk.ID)
+	if err != nil {
+		t.Fatalf("RestoreItem() = %v", err)
+	}
+
+	if restored.Position != 2 || h.lists.items[milk.ID].Position != 2 {
+		t.Errorf("RestoreItem() = %+v, want it back at the end of the list", restored)
+	}
+	if _, ok := h.lists.trash[milk.ID]; ok {
+		t.Error("the item is still in the trash")
+	}
+	if last := h.history.events[len(h.history.events)-1]; last.Type != ItemAdded || *last.ItemID != milk.ID {
+		t.Errorf("last event = %+v, want milk added", last)
+	}
+	if _, err := s.RestoreItem(ctx, h.editor, h.id, milk.ID); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("RestoreItem() twice = %v, want ErrItemNotFound", err)
+	}
+
+	// Restoring is undone like adding the item.
+	if _, err := h.service.Undo(ctx, h.editor, h.list.ID); err != nil {
+		t.Fatalf("Undo() = %v", err)
+	}
+	if _, ok := h.lists.trash[milk.ID]; !ok {
+		t.Error("undoing the restore did not put the item back in the trash")
+	}
+}
+
+func TestTrashRestoreList(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	s := newTrashService(h)
+	if err := h.service.DeleteList(ctx, h.owner, h.list.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	trash, err := s.Trash(ctx, h.viewer, h.id)
+	if err != nil {
+		t.Fatalf("Trash() = %v", err)
+	}
+	if len(trash.Lists) != 1 || trash.Lists[0].ID != h.list.ID || len(trash.Items) != 0 {
+		t.Errorf("Trash() = %+v, want just the list", trash)
+	}
+
+	if _, err := s.RestoreList(ctx, h.editor, h.id, h.list.ID); !errors.Is(err, ErrForbidden) {
+		t.Errorf("RestoreList() by an editor = %v, want ErrForbidden", err)
+	}
+	restored, err := s.RestoreList(ctx, h.owner, h.id, h.list.ID)
+	if err != nil {
+		t.Fatalf("RestoreList() = %v", err)
+	}
+	if restored.ID != h.list.ID || !restored.UpdatedAt.After(h.list.UpdatedAt) {
+		t.Errorf("RestoreList() = %+v, want the list touched", restored)
+	}
+
+	view, err := h.service.List(ctx, h.viewer, h.list.ID, nil)
+	if err != nil {
+		t.Fatalf("List() after RestoreList() = %v", err)
+	}
+	if len(view.Groups) != 1 || len(view.Groups[0].Items) != 1 || view.Groups[0].Items[0].ID != milk.ID {
+		t.Errorf("List() = %+v, want the list back with milk", view.Groups)
+	}
+}
+
+func TestEmptyTrash(t *testing.T) {
+	ctx := context.Background()
+	h, other := newHousehold(t), newHousehold(t)
+	milk := h.add(t, "Milk")
+	kept := other.add(t, "Milk")
+	s := NewTrashService(&memoryTrash{lists: h.lists}, h.service)
+	if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+	h.lists.trash[kept.ID] = kept
+	h.lists.lists[other.list.ID] = other.list
+	list, err := h.service.CreateList(ctx, h.owner, h.id, "Hardware")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.service.DeleteList(ctx, h.owner, list.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.EmptyTrash(ctx, h.editor, h.id); !errors.Is(err, ErrForbidden) {
+		t.Errorf("EmptyTrash() by an editor = %v, want ErrForbidden", err)
+	}
+	emptied, err := s.EmptyTrash(ctx, h.owner, h.id)
+	if err != nil {
+		t.Fatalf("EmptyTrash() = %v", err)
+	}
+
+	if emptied != (EmptiedTrash{Lists: 1, Items: 1}) {
+		t.Errorf("EmptyTrash() = %+v, want one list and one item", emptied)
+	}
+	if _, ok := h.lists.trash[kept.ID]; !ok {
+		t.Error("EmptyTrash() deleted another household's item")
+	}
+	if _, err := s.RestoreItem(ctx, h.editor, h.id, milk.ID); !errors.Is(err, ErrItemNotFound) {
+		t.Errorf("RestoreItem() after EmptyTrash() = %v, want ErrItemNotFound", err)
+	}
+}
+
+// Members restore from their own household's trash only.
+func TestTrashOtherHousehold(t *testing.T) {
+	ctx := context.Background()
+	h, other := newHousehold(t), newHousehold(t)
+	milk := h.add(t, "Milk")
+	if err := h.service.RemoveItem(ctx, h.editor, h.list.ID, milk.ID); err != nil {
+		t.Fatal(err)
+	}
+	s := newTrashService(h)
+
+	if _, err := s.Trash(ctx, other.owner, h.id); !errors.Is(err, ErrNotMember) {
+		t.Errorf("Trash() by another household's owner = %v, want ErrNotMember", err)
+	}
+	if _, err := s.RestoreItem(ctx, other.owner, h.id, milk.ID); !errors.Is(err, ErrNotMember) {
+		t.Errorf("RestoreItem() by another household's owner = %v, want ErrNotMember", err)
+	}
+}
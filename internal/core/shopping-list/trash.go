@@ -0,0 +1,29 @@
+package shopping_list
+
+import "time"
+
+// TrashedList is a deleted list. Its items come back with it when it is
+// restored.
+type TrashedList struct {
+	List
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// TrashedItem is an item deleted from a list that is not itself in the trash.
+type TrashedItem struct {
+	Item
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// Trash is what a household deleted and can still restore, most recently
+// deleted first.
+type Trash struct {
+	Lists []TrashedList `json:"lists"`
+	Items []TrashedItem `json:"items"`
+}
+
+// EmptiedTrash counts what emptying the trash or purging it deleted for good.
+type EmptiedTrash struct {
+	Lists int64 `json:"lists"`
+	Items int64 `json:"items"`
+}
@@ -0,0 +1,353 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+// TripStorage persists shopping trips and their timelines.
+type TripStorage interface {
+	// GetTrip returns the trip with its timeline, oldest entry first.
+	GetTrip(ctx context.Context, id uuid.UUID) (Trip, error)
+	// ActiveTrips returns the household's trips in progress, without their
+	// timelines, newest first.
+	ActiveTrips(ctx context.Context, householdID uuid.UUID) ([]Trip, error)
+	// UserActiveTrip returns ErrTripNotFound when the user is not on a trip.
+	UserActiveTrip(ctx context.Context, userID uuid.UUID) (Trip, error)
+	CreateTrip(ctx context.Context, trip Trip) error
+	FinishTrip(ctx context.Context, trip Trip) error
+	AddTripEntry(ctx context.Context, entry TripEntry) error
+}
+
+// TripService runs shopping trips: a member starts one at a store for some
+// of the household's lists, checks items off or marks them as not found as
+// they go, and finishes it. Members can view trips, editors can shop. Every
+// step is pushed to the household as it happens.
+type TripService struct {
+	storage   TripStorage
+	lists     *ListService
+	prices    *PriceService
+	publisher EventPublisher
+}
+
+func NewTripService(storage TripStorage, lists *ListService, prices *PriceService, publisher EventPublisher) *TripService {
+	return &TripService{
+		storage:   storage,
+		lists:     lists,
+		prices:    prices,
+		publisher: publisher,
+	}
+}
+
+func (s *TripService) authorize(ctx context.Context, actor, tripID uuid.UUID, required Role) (Trip, error) {
+	trip, err := s.storage.GetTrip(ctx, tripID)
+	if err != nil {
+		return Trip{}, err
+	}
+	if err := requireRole(ctx, s.lists.households, trip.HouseholdID, actor, required); err != nil {
+		return Trip{}, err
+	}
+	return trip, nil
+}
+
+// activeTrip is authorize for changes, which only active trips take.
+func (s *TripService) activeTrip(ctx context.Context, actor, tripID uuid.UUID) (Trip, error) {
+	trip, err := s.authorize(ctx, actor, tripID, RoleEditor)
+	if err != nil {
+		return Trip{}, err
+	}
+	if trip.Status != TripActive {
+		return Trip{}, ErrTripFinished
+	}
+	return trip, nil
+}
+
+// StartTrip starts a trip at the store for lists of its household. A member
+// is on one trip at a time.
+func (s *TripService) StartTrip(ctx context.Context, actor uuid.UUID, in NewTrip) (Trip, error) {
+	currency, err := parseCurrency(in.Currency)
+	if err != nil {
+		return Trip{}, err
+	}
+	if len(in.ListIDs) == 0 {
+		return Trip{}, ErrInvalidTripLists
+	}
+
+	store, err := s.prices.stores.GetStore(ctx, in.StoreID)
+	if err != nil {
+		return Trip{}, err
+	}
+	if err := requireRole(ctx, s.lists.households, store.HouseholdID, actor, RoleEditor); err != nil {
+		return Trip{}, err
+	}
+	listIDs := make([]uuid.UUID, 0, len(in.ListIDs))
+	seen := make(map[uuid.UUID]bool, len(in.ListIDs))
+	for _, id := range in.ListIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		list, err := s.lists.storage.GetList(ctx, id)
+		if err != nil {
+			return Trip{}, err
+		}
+		if list.HouseholdID != store.HouseholdID {
+			return Trip{}, ErrInvalidTripLists
+		}
+		listIDs = append(listIDs, id)
+	}
+
+	if _, err := s.storage.UserActiveTrip(ctx, actor); err == nil {
+		return Trip{}, ErrTripInProgress
+	} else if !errors.Is(err, ErrTripNotFound) {
+		return Trip{}, err
+	}
+
+	trip := Trip{
+		ID:          uuid.New(),
+		HouseholdID: store.HouseholdID,
+		StoreID:     store.ID,
+		ListIDs:     listIDs,
+		StartedBy:   actor,
+		Status:      TripActive,
+		Currency:    currency,
+		StartedAt:   time.Now(),
+		Timeline:    []TripEntry{},
+	}
+	if err := s.storage.CreateTrip(ctx, trip); err != nil {
+		return Trip{}, err
+	}
+
+	return trip, s.notify(ctx, actor, trip.HouseholdID, EventTripStarted, trip, trip.StartedAt)
+}
+
+func (s *TripService) Trip(ctx context.Context, actor, tripID uuid.UUID) (Trip, error) {
+	return s.authorize(ctx, actor, tripID, RoleViewer)
+}
+
+// ActiveTrips returns the household's trips in progress.
+func (s *TripService) ActiveTrips(ctx context.Context, actor, householdID uuid.UUID) ([]Trip, error) {
+	if err := requireRole(ctx, s.lists.households, householdID, actor, RoleViewer); err != nil {
+		return nil, err
+	}
+	return s.storage.ActiveTrips(ctx, householdID)
+}
+
+// CheckItem checks an item of one of the trip's lists off the way checking
+// it off the list does, and adds it to the trip's timeline.
+func (s *TripService) CheckItem(ctx context.Context, actor, tripID, itemID uuid.UUID, in TripCheckInput) (TripEntry, error) {
+	if in.Amount != nil && *in.Amount < 0 {
+		return TripEntry{}, ErrInvalidAmount
+	}
+	trip, err := s.activeTrip(ctx, actor, tripID)
+	if err != nil {
+		return TripEntry{}, err
+	}
+	item, err := s.tripItem(ctx, trip, itemID)
+	if err != nil {
+		return TripEntry{}, err
+	}
+	if item.Checked {
+		return TripEntry{}, ErrItemChecked
+	}
+
+	if item, err = s.lists.setChecked(ctx, actor, item.ListID, item.ID, true); err != nil {
+		return TripEntry{}, err
+	}
+	entry := tripEntry(trip, TripItemChecked, item, actor)
+	entry.Amount = in.Amount
+
+	return entry, s.addEntry(ctx, actor, trip, entry)
+}
+
+// MarkNotFound records that an item of one of the trip's lists was not found
+// at the store. The item stays on its list.
+func (s *TripService) MarkNotFound(ctx context.Context, actor, tripID, itemID uuid.UUID) (TripEntry, error) {
+	trip, err := s.activeTrip(ctx, actor, tripID)
+	if err != nil {
+		return TripEntry{}, err
+	}
+	item, err := s.tripItem(ctx, trip, itemID)
+	if err != nil {
+		return TripEntry{}, err
+	}
+	if item.Checked {
+		return TripEntry{}, ErrItemChecked
+	}
+
+	entry := tripEntry(trip, TripItemNotFound, item, actor)
+	return entry, s.addEntry(ctx, actor, trip, entry)
+}
+
+// FinishTrip ends the trip, records what was paid for the items checked off
+// on it as prices at the store and returns the trip's summary. A total counts
+// towards the household's budgets as well: the part of it the items' prices
+// do not make up is kept on the trip as unitemized spending.
+func (s *TripService) FinishTrip(ctx context.Context, actor, tripID uuid.UUID, in FinishTripInput) (TripSummary, error) {
+	if in.Total != nil && *in.Total < 0 {
+		return TripSummary{}, ErrInvalidAmount
+	}
+	trip, err := s.activeTrip(ctx, actor, tripID)
+	if err != nil {
+		return TripSummary{}, err
+	}
+	store, err := s.prices.stores.GetStore(ctx, trip.StoreID)
+	if err != nil {
+		return TripSummary{}, err
+	}
+
+	var listItems []Item
+	items := make(map[uuid.UUID]Item)
+	for _, listID := range trip.ListIDs {
+		onList, err := s.lists.storage.ListItems(ctx, listID)
+		if err != nil {
+			return TripSummary{}, err
+		}
+		for _, item := range onList {
+			items[item.ID] = item
+		}
+		listItems = append(listItems, onList...)
+	}
+
+	now := time.Now()
+	summary := TripSummary{NotFound: []string{}, Remaining: []string{}}
+	var spent int64
+	checked := make(map[uuid.UUID]bool)
+	for _, entry := range boughtEntries(trip.Timeline, items) {
+		checked[entry.ItemID] = true
+		summary.Checked++
+		if entry.Amount == nil {
+			continue
+		}
+		spent += *entry.Amount
+
+		price := Price{
+			ID:        uuid.New(),
+			Name:      entry.Name,
+			Quantity:  entry.Quantity,
+			Category:  entry.Category,
+			Amount:    *entry.Amount,
+			Currency:  trip.Currency,
+			PaidAt:    entry.OccurredAt,
+			CreatedAt: now,
+		}
+		if _, err := s.prices.record(ctx, actor, store, price); err != nil {
+			return TripSummary{}, err
+		}
+		summary.PricesRecorded++
+	}
+	var unitemized *int64
+	if in.Total != nil {
+		rest := *in.Total - spent
+		spent, unitemized = *in.Total, &rest
+	}
+
+	looked := make(map[uuid.UUID]bool)
+	for _, entry := range trip.Timeline {
+		if entry.Kind == TripItemNotFound && !checked[entry.ItemID] && !looked[entry.ItemID] {
+			summary.NotFound = append(summary.NotFound, entry.Name)
+		}
+		looked[entry.ItemID] = true
+	}
+	for _, item := range listItems {
+		if !item.Checked && !looked[item.ID] {
+			summary.Remaining = append(summary.Remaining, item.Name)
+		}
+	}
+
+	trip.Status = TripFinished
+	trip.Spent = &spent
+	trip.Unitemized = unitemized
+	trip.FinishedAt = &now
+	if err := s.storage.FinishTrip(ctx, trip); err != nil {
+		return TripSummary{}, err
+	}
+	if unitemized != nil && *unitemized != 0 {
+		// Spending without a category only counts towards budgets of all
+		// spending.
+		rest := Price{HouseholdID: trip.HouseholdID, StoreID: store.ID, Amount: *unitemized, Currency: trip.Currency, PaidAt: now}
+		if err := s.prices.budgets.recordSpend(ctx, actor, rest); err != nil {
+			return TripSummary{}, err
+		}
+	}
+	summary.Trip = trip
+	summary.Duration = int64(now.Sub(trip.StartedAt).Seconds())
+
+	return summary, s.notify(ctx, actor, trip.HouseholdID, EventTripFinished, summary, now)
+}
+
+// boughtEntries returns the latest check-off of each item in the timeline, in
+// the order the items were first checked off. An item that was put back on
+// its list and checked off again during the trip was bought once; one that is
+// no longer checked off was not bought at all. Items since deleted from their
+// list are taken as bought.
+func boughtEntries(timeline []TripEntry, items map[uuid.UUID]Item) []TripEntry {
+	var bought []TripEntry
+	index := make(map[uuid.UUID]int)
+	for _, entry := range timeline {
+		if entry.Kind != TripItemChecked {
+			continue
+		}
+		if item, ok := items[entry.ItemID]; ok && !item.Checked {
+			continue
+		}
+		if i, ok := index[entry.ItemID]; ok {
+			bought[i] = entry
+			continue
+		}
+		index[entry.ItemID] = len(bought)
+		bought = append(bought, entry)
+	}
+	return bought
+}
+
+// tripItem finds the item on one of the trip's lists.
+func (s *TripService) tripItem(ctx context.Context, trip Trip, itemID uuid.UUID) (Item, error) {
+	for _, listID := range trip.ListIDs {
+		item, err := s.lists.storage.GetItem(ctx, listID, itemID)
+		if errors.Is(err, ErrItemNotFound) {
+			continue
+		}
+		return item, err
+	}
+	return Item{}, ErrItemNotFound
+}
+
+func (s *TripService) addEntry(ctx context.Context, actor uuid.UUID, trip Trip, entry TripEntry) error {
+	if err := s.storage.AddTripEntry(ctx, entry); err != nil {
+		return err
+	}
+
+	eventType := EventTripItemChecked
+	if entry.Kind == TripItemNotFound {
+		eventType = EventTripItemNotFound
+	}
+	return s.notify(ctx, actor, trip.HouseholdID, eventType, entry, entry.OccurredAt)
+}
+
+func (s *TripService) notify(ctx context.Context, actor, householdID uuid.UUID, eventType EventType, data any, now time.Time) error {
+	return notifyHousehold(ctx, s.lists.households, s.publisher, Event{
+		Type:        eventType,
+		HouseholdID: householdID,
+		ActorID:     actor,
+		Data:        data,
+		OccurredAt:  now,
+	})
+}
+
+func tripEntry(trip Trip, kind TripEntryKind, item Item, actor uuid.UUID) TripEntry {
+	return TripEntry{
+		ID:         uuid.New(),
+		TripID:     trip.ID,
+		Kind:       kind,
+		ItemID:     item.ID,
+		ListID:     item.ListID,
+		Name:       item.Name,
+		Quantity:   item.Quantity,
+		Category:   item.Category,
+		ActorID:    actor,
+		OccurredAt: time.Now(),
+	}
+}
@@ -0,0 +1,245 @@
+package shopping_list
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"slices"
+	"testing"
+	"time"
+)
+
+type memoryTrips struct {
+	TripStorage
+	trips map[uuid.UUID]Trip
+}
+
+func (m *memoryTrips) GetTrip(_ context.Context, id uuid.UUID) (Trip, error) {
+	trip, ok := m.trips[id]
+	if !ok {
+		return Trip{}, ErrTripNotFound
+	}
+	return trip, nil
+}
+
+func (m *memoryTrips) UserActiveTrip(_ context.Context, userID uuid.UUID) (Trip, error) {
+	for _, trip := range m.trips {
+		if trip.StartedBy == userID && trip.Status == TripActive {
+			return trip, nil
+		}
+	}
+	return Trip{}, ErrTripNotFound
+}
+
+func (m *memoryTrips) CreateTrip(_ context.Context, trip Trip) error {
+	m.trips[trip.ID] = trip
+	return nil
+}
+
+func (m *memoryTrips) FinishTrip(_ context.Context, trip Trip) error {
+	m.trips[trip.ID] = trip
+	return nil
+}
+
+func (m *memoryTrips) AddTripEntry(_ context.Context, entry TripEntry) error {
+	trip := m.trips[entry.TripID]
+	trip.Timeline = append(trip.Timeline, entry)
+	m.trips[trip.ID] = trip
+	return nil
+}
+
+type memoryStores struct {
+	StoreStorage
+	stores map[uuid.UUID]Store
+}
+
+func (m *memoryStores) GetStore(_ context.Context, id uuid.UUID) (Store, error) {
+	store, ok := m.stores[id]
+	if !ok {
+		return Store{}, ErrStoreNotFound
+	}
+	return store, nil
+}
+
+// memoryPrices adds up spending from the recorded prices and the unitemized
+// part of finished trips, like the database does.
+type memoryPrices struct {
+	PriceStorage
+	prices []Price
+	trips  *memoryTrips
+}
+
+func (m *memoryPrices) RecordPrice(_ context.Context, price Price) error {
+	m.prices = append(m.prices, price)
+	return nil
+}
+
+func (m *memoryPrices) Spend(_ context.Context, householdID uuid.UUID, category, currency string, from, to time.Time) (int64, error) {
+	in := func(t time.Time) bool { return !t.Before(from) && t.Before(to) }
+	var spent int64
+	for _, price := range m.prices {
+		if price.HouseholdID == householdID && price.Currency == currency && (category == "" || price.Category == category) && in(price.PaidAt) {
+			spent += price.Amount
+		}
+	}
+	for _, trip := range m.trips.trips {
+		if category == "" && trip.HouseholdID == householdID && trip.Currency == currency && trip.Unitemized != nil && in(*trip.FinishedAt) {
+			spent += *trip.Unitemized
+		}
+	}
+	return spent, nil
+}
+
+type memoryBudgets struct {
+	BudgetStorage
+	budgets []Budget
+}
+
+func (m *memoryBudgets) HouseholdBudgets(_ context.Context, householdID uuid.UUID) ([]Budget, error) {
+	var budgets []Budget
+	for _, budget := range m.budgets {
+		if budget.HouseholdID == householdID {
+			budgets = append(budgets, budget)
+		}
+	}
+	return budgets, nil
+}
+
+// recordingPublisher keeps the events it is given.
+type recordingPublisher struct {
+	events []Event
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, _ []uuid.UUID, event Event) {
+	p.events = append(p.events, event)
+}
+
+type shoppingTrip struct {
+	*household
+	trips     *memoryTrips
+	prices    *memoryPrices
+	budgets   *memoryBudgets
+	published *recordingPublisher
+	service   *TripService
+	trip      Trip
+}
+
+// startTrip starts a trip in euros for the household's list, as the editor.
+func startTrip(t *testing.T, h *household) *shoppingTrip {
+	t.Helper()
+	trips := &memoryTrips{trips: make(map[uuid.UUID]Trip)}
+	s := &shoppingTrip{
+		household: h,
+		trips:     trips,
+		prices:    &memoryPrices{trips: trips},
+		budgets:   &memoryBudgets{},
+		published: &recordingPublisher{},
+	}
+	store := Store{ID: uuid.New(), HouseholdID: h.id, Name: "Corner shop"}
+	stores := &memoryStores{stores: map[uuid.UUID]Store{store.ID: store}}
+	budgets := NewBudgetService(s.budgets, s.prices, h.members, nil, s.published)
+	prices := NewPriceService(s.prices, h.members, stores, h.service, budgets)
+	s.service = NewTripService(trips, h.service, prices, s.published)
+
+	trip, err := s.service.StartTrip(context.Background(), h.editor, NewTrip{StoreID: store.ID, ListIDs: []uuid.UUID{h.list.ID}, Currency: "eur"})
+	if err != nil {
+		t.Fatalf("StartTrip() = %v", err)
+	}
+	s.trip = trip
+	return s
+}
+
+func (s *shoppingTrip) check(t *testing.T, item Item, amount *int64) {
+	t.Helper()
+	if _, err := s.service.CheckItem(context.Background(), s.editor, s.trip.ID, item.ID, TripCheckInput{Amount: amount}); err != nil {
+		t.Fatalf("CheckItem() = %v", err)
+	}
+}
+
+func TestFinishTrip(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk, bread, eggs := h.add(t, "Milk"), h.add(t, "Bread"), h.add(t, "Eggs")
+	h.add(t, "Butter")
+	s := startTrip(t, h)
+	paid := int64(120)
+	s.check(t, milk, &paid)
+	if _, err := s.service.MarkNotFound(ctx, h.editor, s.trip.ID, bread.ID); err != nil {
+		t.Fatal(err)
+	}
+	s.check(t, eggs, nil)
+
+	if _, err := s.service.FinishTrip(ctx, h.viewer, s.trip.ID, FinishTripInput{}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("FinishTrip() by a viewer = %v, want ErrForbidden", err)
+	}
+	summary, err := s.service.FinishTrip(ctx, h.editor, s.trip.ID, FinishTripInput{})
+	if err != nil {
+		t.Fatalf("FinishTrip() = %v", err)
+	}
+
+	if summary.Checked != 2 || summary.PricesRecorded != 1 {
+		t.Errorf("FinishTrip() checked %d items and recorded %d prices, want 2 and 1", summary.Checked, summary.PricesRecorded)
+	}
+	if !slices.Equal(summary.NotFound, []string{"Bread"}) || !slices.Equal(summary.Remaining, []string{"Butter"}) {
+		t.Errorf("FinishTrip() has %q not found and %q remaining, want Bread and Butter", summary.NotFound, summary.Remaining)
+	}
+	if summary.Spent == nil || *summary.Spent != 120 || summary.Unitemized != nil {
+		t.Errorf("FinishTrip() spent %v with %v unitemized, want 120 and none", summary.Spent, summary.Unitemized)
+	}
+	if stored := s.trips.trips[s.trip.ID]; stored.Status != TripFinished || stored.FinishedAt == nil {
+		t.Errorf("stored trip = %+v, want it finished", stored)
+	}
+	if len(s.prices.prices) != 1 {
+		t.Fatalf("FinishTrip() recorded %d prices, want 1", len(s.prices.prices))
+	}
+	if price := s.prices.prices[0]; price.Name != "Milk" || price.Amount != 120 || price.Currency != "EUR" || price.Category != "dairy" {
+		t.Errorf("recorded %+v, want 120 EUR for milk in dairy", price)
+	}
+	if last := s.published.events[len(s.published.events)-1]; last.Type != EventTripFinished {
+		t.Errorf("last event = %s, want %s", last.Type, EventTripFinished)
+	}
+
+	if _, err := s.service.FinishTrip(ctx, h.editor, s.trip.ID, FinishTripInput{}); !errors.Is(err, ErrTripFinished) {
+		t.Errorf("FinishTrip() twice = %v, want ErrTripFinished", err)
+	}
+}
+
+// What a total has beyond the items' prices counts towards the budgets of
+// all spending, and only those.
+func TestFinishTripUnitemized(t *testing.T) {
+	ctx := context.Background()
+	h := newHousehold(t)
+	milk := h.add(t, "Milk")
+	s := startTrip(t, h)
+	everything := Budget{ID: uuid.New(), HouseholdID: h.id, Period: PeriodMonthly, Amount: 1000, Currency: "EUR", Thresholds: DefaultThresholds}
+	dairy := Budget{ID: uuid.New(), HouseholdID: h.id, Category: "dairy", Period: PeriodMonthly, Amount: 500, Currency: "EUR", Thresholds: DefaultThresholds}
+	s.budgets.budgets = []Budget{everything, dairy}
+	paid := int64(300)
+	s.check(t, milk, &paid)
+
+	negative := int64(-1)
+	if _, err := s.service.FinishTrip(ctx, h.editor, s.trip.ID, FinishTripInput{Total: &negative}); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("FinishTrip() with a negative total = %v, want ErrInvalidAmount", err)
+	}
+	total := int64(900)
+	summary, err := s.service.FinishTrip(ctx, h.editor, s.trip.ID, FinishTripInput{Total: &total})
+	if err != nil {
+		t.Fatalf("FinishTrip() = %v", err)
+	}
+
+	if *summary.Spent != 900 || summary.Unitemized == nil || *summary.Unitemized != 600 {
+		t.Errorf("FinishTrip() spent %d with %v unitemized, want 900 with 600", *summary.Spent, summary.Unitemized)
+	}
+	if stored := s.trips.trips[s.trip.ID]; stored.Unitemized == nil || *stored.Unitemized != 600 {
+		t.Errorf("stored trip has %v unitemized, want 600", stored.Unitemized)
+	}
+	var alerts []BudgetAlert
+	for _, event := range s.published.events {
+		if event.Type == EventBudgetThreshold {
+			alerts = append(alerts, event.Data.(BudgetAlert))
+		}
+	}
+	if len(alerts) != 1 || alerts[0].Budget.ID != everything.ID || alerts[0].Threshold != 80 || alerts[0].Spent != 900 {
+		t.Errorf("budget alerts = %+v, want 80%% of all spending reached at 900", alerts)
+	}
+}
@@ -0,0 +1,88 @@
+package shopping_list
+
+import (
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"time"
+)
+
+type TripStatus string
+
+const (
+	TripActive   TripStatus = "active"
+	TripFinished TripStatus = "finished"
+)
+
+type TripEntryKind string
+
+const (
+	TripItemChecked  TripEntryKind = "checked"
+	TripItemNotFound TripEntryKind = "not_found"
+)
+
+// Trip is a visit to a store to buy the items of one or more lists. Spent is
+// set when the trip is finished, in the trip's currency's minor unit, and so
+// is Unitemized when a total was given: the part of it not paid for any of the
+// items, which can be negative after discounts.
+type Trip struct {
+	ID          uuid.UUID   `json:"id"`
+	HouseholdID uuid.UUID   `json:"household_id"`
+	StoreID     uuid.UUID   `json:"store_id"`
+	ListIDs     []uuid.UUID `json:"list_ids"`
+	StartedBy   uuid.UUID   `json:"started_by"`
+	Status      TripStatus  `json:"status"`
+	Currency    string      `json:"currency"`
+	Spent       *int64      `json:"spent,omitempty"`
+	Unitemized  *int64      `json:"unitemized,omitempty"`
+	StartedAt   time.Time   `json:"started_at"`
+	FinishedAt  *time.Time  `json:"finished_at,omitempty"`
+	Timeline    []TripEntry `json:"timeline" db:"-"`
+}
+
+// TripEntry is an item checked off or not found during a trip, as the item
+// was then. Amount is what was paid for it, when known.
+type TripEntry struct {
+	ID         uuid.UUID         `json:"id"`
+	TripID     uuid.UUID         `json:"trip_id"`
+	Kind       TripEntryKind     `json:"kind"`
+	ItemID     uuid.UUID         `json:"item_id"`
+	ListID     uuid.UUID         `json:"list_id"`
+	Name       string            `json:"name"`
+	Quantity   quantity.Quantity `json:"quantity"`
+	Category   string            `json:"category"`
+	Amount     *int64            `json:"amount,omitempty"`
+	ActorID    uuid.UUID         `json:"actor_id"`
+	OccurredAt time.Time         `json:"occurred_at"`
+}
+
+// NewTrip starts a trip for lists of the store's household. Currency is the
+// ISO 4217 code the amounts paid on the trip are in.
+type NewTrip struct {
+	StoreID  uuid.UUID   `json:"store_id"`
+	ListIDs  []uuid.UUID `json:"list_ids"`
+	Currency string      `json:"currency"`
+}
+
+// TripCheckInput checks an item off during a trip, optionally with what was
+// paid for it.
+type TripCheckInput struct {
+	Amount *int64 `json:"amount"`
+}
+
+// FinishTripInput finishes a trip. Total is what the trip cost, such as the
+// receipt total; without it the amounts paid for the items are added up.
+type FinishTripInput struct {
+	Total *int64 `json:"total"`
+}
+
+// TripSummary is a finished trip with what came of it. NotFound names the
+// items that were not found and not checked off later in the trip, Remaining
+// those still unchecked on the trip's lists that nobody looked for.
+type TripSummary struct {
+	Trip
+	Duration       int64    `json:"duration"` // seconds
+	Checked        int      `json:"checked"`
+	NotFound       []string `json:"not_found"`
+	Remaining      []string `json:"remaining"`
+	PricesRecorded int      `json:"prices_recorded"`
+}
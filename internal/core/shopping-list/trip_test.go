@@ -0,0 +1,47 @@
+package shopping_list
+
+import (
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestBoughtEntries(t *testing.T) {
+	milk, bread, eggs, gone := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+	amount := func(v int64) *int64 { return &v }
+	timeline := []TripEntry{
+		{Kind: TripItemChecked, ItemID: milk, Amount: amount(120)},
+		{Kind: TripItemNotFound, ItemID: bread},
+		{Kind: TripItemChecked, ItemID: eggs, Amount: amount(300)},
+		{Kind: TripItemChecked, ItemID: gone},
+		// milk was put back on the list and checked off again
+		{Kind: TripItemChecked, ItemID: milk, Amount: amount(99)},
+		{Kind: TripItemChecked, ItemID: bread},
+	}
+	items := map[uuid.UUID]Item{
+		milk:  {ID: milk, Checked: true},
+		bread: {ID: bread, Checked: true},
+		// eggs were put back on the list after the trip checked them off
+		eggs: {ID: eggs},
+	}
+
+	bought := boughtEntries(timeline, items)
+	want := []struct {
+		item   uuid.UUID
+		amount int64
+	}{{milk, 99}, {gone, 0}, {bread, 0}}
+	if len(bought) != len(want) {
+		t.Fatalf("boughtEntries() = %d entries, want %d", len(bought), len(want))
+	}
+	for i, w := range want {
+		if bought[i].ItemID != w.item {
+			t.Errorf("entry %d is item %v, want %v", i, bought[i].ItemID, w.item)
+		}
+		var got int64
+		if bought[i].Amount != nil {
+			got = *bought[i].Amount
+		}
+		if got != w.amount {
+			t.Errorf("entry %d amount = %d, want %d", i, got, w.amount)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	userIDHeader = "X-User-ID"
+	userIDKey    = "userID"
+)
+
+// requireUser reads the caller's identity from the X-User-ID header. Requests
+// are expected to arrive through a gateway that has already authenticated the user.
+func requireUser(c *fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Get(userIDHeader))
+	if err != nil {
+		return fiber.NewError(fiber.StatusUnauthorized, "missing or invalid "+userIDHeader+" header")
+	}
+
+	c.Locals(userIDKey, userID)
+	return c.Next()
+}
+
+// currentUser returns the identity stored by requireUser.
+func currentUser(c *fiber.Ctx) uuid.UUID {
+	userID, _ := c.Locals(userIDKey).(uuid.UUID)
+	return userID
+}
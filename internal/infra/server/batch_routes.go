@@ -0,0 +1,29 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func registerBatchRoutes(lists fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	lists.Post("/:id/items\\:batch", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.BatchInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		service := shopping_list.NewBatchService(newListService(tx, dictionary))
+		result, err := service.Apply(c.UserContext(), currentUser(c), listID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+}
@@ -0,0 +1,94 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newBudgetService(c *fiber.Ctx, tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.BudgetService {
+	return shopping_list.NewBudgetService(
+		repository.NewBudgetStorageRepo(tx),
+		repository.NewPriceStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		dictionary,
+		newEventPublisher(c),
+	)
+}
+
+func registerBudgetRoutes(households, budgets fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/budgets", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newBudgetService(c, tx, dictionary).Budgets(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	households.Post("/:id/budgets", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.BudgetInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		budget, err := newBudgetService(c, tx, dictionary).SetBudget(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(budget)
+	}))
+
+	households.Get("/:id/budgets/summary", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newBudgetService(c, tx, dictionary).Summary(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	budgets.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		budgetID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.BudgetInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		budget, err := newBudgetService(c, tx, dictionary).UpdateBudget(c.UserContext(), currentUser(c), budgetID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(budget)
+	}))
+
+	budgets.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		budgetID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newBudgetService(c, tx, dictionary).DeleteBudget(c.UserContext(), currentUser(c), budgetID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+}
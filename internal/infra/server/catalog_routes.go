@@ -0,0 +1,47 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newCatalogService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.CatalogService {
+	return shopping_list.NewCatalogService(
+		repository.NewProductStorageRepo(tx),
+		newListService(tx, dictionary),
+	)
+}
+
+func registerCatalogRoutes(lists, products fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	lists.Post("/:id/items\\:scan", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.ScanInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		added, err := newCatalogService(tx, dictionary).Scan(c.UserContext(), currentUser(c), listID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		if added.Merged {
+			return c.JSON(added)
+		}
+		return c.Status(fiber.StatusCreated).JSON(added)
+	}))
+
+	products.Get("/:gtin", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		product, err := newCatalogService(tx, dictionary).Product(c.UserContext(), c.Params("gtin"))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(product)
+	}))
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"github.com/PocketPalCo/shopping-service/config"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+func tombstoneRetention(cfg *config.Config) time.Duration {
+	return time.Duration(cfg.TombstoneRetention) * 24 * time.Hour
+}
+
+func registerChangeRoutes(changes fiber.Router, db postgres.DB, retention time.Duration) {
+	changes.Get("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		limit := c.QueryInt("limit", shopping_list.DefaultChangeLimit)
+		service := shopping_list.NewChangeService(repository.NewChangeStorageRepo(tx), retention)
+		set, err := service.Changes(c.UserContext(), currentUser(c), c.Query("cursor"), limit)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(set)
+	}))
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+func newClaimService(c *fiber.Ctx, tx pgx.Tx, dictionary *categories.Dictionary, ttl time.Duration) *shopping_list.ClaimService {
+	return shopping_list.NewClaimService(
+		repository.NewClaimStorageRepo(tx),
+		newListService(tx, dictionary),
+		newEventPublisher(c),
+		ttl,
+	)
+}
+
+func registerClaimRoutes(lists, me fiber.Router, db postgres.DB, dictionary *categories.Dictionary, ttl time.Duration) {
+	lists.Post("/:id/items/:itemId/claim", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		itemID, err := uuidParam(c, "itemId")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.ClaimInput
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+		}
+
+		item, err := newClaimService(c, tx, dictionary, ttl).ClaimItem(c.UserContext(), currentUser(c), listID, itemID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	lists.Delete("/:id/items/:itemId/claim", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		itemID, err := uuidParam(c, "itemId")
+		if err != nil {
+			return err
+		}
+
+		item, err := newClaimService(c, tx, dictionary, ttl).ReleaseClaim(c.UserContext(), currentUser(c), listID, itemID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	me.Get("/items", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		lists, err := newClaimService(c, tx, dictionary, ttl).MyItems(c.UserContext(), currentUser(c))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(lists)
+	}))
+}
@@ -0,0 +1,75 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func registerHistoryRoutes(lists fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	lists.Get("/:id/history", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		limit := c.QueryInt("limit", shopping_list.DefaultHistoryLimit)
+		events, err := newListService(tx, dictionary).History(c.UserContext(), currentUser(c), listID, limit)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(events)
+	}))
+
+	lists.Post("/:id/revert", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		to, err := uuidQuery(c, "to")
+		if err != nil {
+			return err
+		}
+		if to == nil {
+			return fiber.NewError(fiber.StatusBadRequest, "missing to")
+		}
+
+		events, err := newListService(tx, dictionary).Revert(c.UserContext(), currentUser(c), listID, *to)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(events)
+	}))
+
+	lists.Post("/:id/undo", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		event, err := newListService(tx, dictionary).Undo(c.UserContext(), currentUser(c), listID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(event)
+	}))
+
+	lists.Post("/:id/items\\:reorder", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.ReorderInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		items, err := newListService(tx, dictionary).ReorderItems(c.UserContext(), currentUser(c), listID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(items)
+	}))
+}
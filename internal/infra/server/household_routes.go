@@ -0,0 +1,88 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type addMemberRequest struct {
+	UserID uuid.UUID          `json:"user_id"`
+	Role   shopping_list.Role `json:"role"`
+}
+
+func newHouseholdService(c *fiber.Ctx, tx pgx.Tx) *shopping_list.HouseholdService {
+	return shopping_list.NewHouseholdService(repository.NewHouseholdStorageRepo(tx), newEventPublisher(c))
+}
+
+func registerHouseholdRoutes(households fiber.Router, db postgres.DB) {
+	households.Get("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		result, err := newHouseholdService(c, tx).Households(c.UserContext(), currentUser(c))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	households.Post("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		var req listNameRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		household, err := newHouseholdService(c, tx).CreateHousehold(c.UserContext(), currentUser(c), req.Name)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(household)
+	}))
+
+	households.Get("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		household, err := newHouseholdService(c, tx).Household(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(household)
+	}))
+
+	households.Post("/:id/members", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req addMemberRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		member, err := newHouseholdService(c, tx).AddMember(c.UserContext(), currentUser(c), householdID, req.UserID, req.Role)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(member)
+	}))
+
+	households.Delete("/:id/members/:userId", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		userID, err := uuidParam(c, "userId")
+		if err != nil {
+			return err
+		}
+
+		if err := newHouseholdService(c, tx).RemoveMember(c.UserContext(), currentUser(c), householdID, userID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+}
@@ -6,6 +6,8 @@ import (
 	"github.com/PocketPalCo/shopping-service/config"
 	"github.com/PocketPalCo/shopping-service/docs"
 	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/signedtoken"
 	"github.com/gofiber/contrib/otelfiber/v2"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/adaptor"
@@ -32,7 +34,7 @@ func initGlobalMiddlewares(app *fiber.App, cfg *config.Config) {
 
 		cors.New(cors.Config{
 			AllowOrigins: "*", // TODO - add allowed origins
-			AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Request-ID",
+			AllowHeaders: "Origin, Content-Type, Accept, Authorization, X-Request-ID, X-User-ID",
 			AllowMethods: "GET, POST, PUT, DELETE, OPTIONS",
 		}),
 
@@ -53,7 +55,7 @@ func initGlobalMiddlewares(app *fiber.App, cfg *config.Config) {
 
 }
 
-func registerHttpRoutes(app *fiber.App, cfg *config.Config, db postgres.DB) {
+func registerHttpRoutes(app *fiber.App, cfg *config.Config, db postgres.DB, dictionary *categories.Dictionary) {
 	// swagger
 	docs.SwaggerInfo.Version = "1.0.0"
 	app.Get("/swagger/*", swagger.HandlerDefault)
@@ -99,6 +101,66 @@ func registerHttpRoutes(app *fiber.App, cfg *config.Config, db postgres.DB) {
 		return c.JSON(rows)
 	}))
 
+	signer := signedtoken.New([]byte(cfg.InviteSecret))
+
+	households := apiRoutes.Group("/households", requireUser)
+	lists := apiRoutes.Group("/lists", requireUser)
+	invites := apiRoutes.Group("/invites", requireUser)
+	stores := apiRoutes.Group("/stores", requireUser)
+	templates := apiRoutes.Group("/templates", requireUser)
+	pantry := apiRoutes.Group("/pantry", requireUser)
+	recipes := apiRoutes.Group("/recipes", requireUser)
+	mealPlan := apiRoutes.Group("/meal-plan", requireUser)
+	budgets := apiRoutes.Group("/budgets", requireUser)
+	receipts := apiRoutes.Group("/receipts", requireUser)
+	products := apiRoutes.Group("/products", requireUser)
+	changes := apiRoutes.Group("/sync", requireUser)
+	me := apiRoutes.Group("/me", requireUser)
+	trips := apiRoutes.Group("/trips", requireUser)
+	search := apiRoutes.Group("/search", requireUser)
+
+	registerHouseholdRoutes(households, db)
+	registerInviteRoutes(households, invites, db, signer)
+	registerListRoutes(lists, db, dictionary, cfg.DuplicateThreshold)
+	registerStoreRoutes(households, stores, db, dictionary)
+	registerTemplateRoutes(households, templates, db, dictionary)
+	registerPantryRoutes(households, pantry, db, dictionary)
+	registerRecipeRoutes(households, recipes, db, dictionary)
+	registerMealPlanRoutes(households, mealPlan, db, dictionary)
+	registerPriceRoutes(households, stores, lists, db, dictionary)
+	registerBudgetRoutes(households, budgets, db, dictionary)
+	registerReceiptRoutes(receipts, db, dictionary)
+	registerCatalogRoutes(lists, products, db, dictionary)
+	registerHistoryRoutes(lists, db, dictionary)
+	registerSyncRoutes(lists, db, dictionary)
+	registerChangeRoutes(changes, db, tombstoneRetention(cfg))
+	registerClaimRoutes(lists, me, db, dictionary, time.Duration(cfg.ClaimExpiry)*time.Second)
+	registerTripRoutes(households, trips, db, dictionary)
+	registerTrashRoutes(households, db, dictionary)
+	registerSuggestionRoutes(households, db)
+	registerSearchRoutes(search, db)
+	registerBatchRoutes(lists, db, dictionary)
+
+	apiRoutes.Get("/categories", func(c *fiber.Ctx) error {
+		return c.JSON(dictionary.Categories())
+	})
+}
+
+// loadCategoryDictionary reads the configured keyword dictionary, falling back
+// to the built-in one when none is configured or the file cannot be read.
+func loadCategoryDictionary(cfg *config.Config) *categories.Dictionary {
+	if cfg.CategoryDictionary == "" {
+		return categories.Default()
+	}
+
+	dictionary, err := categories.Load(cfg.CategoryDictionary)
+	if err != nil {
+		slog.Error("failed to load category dictionary, using the built-in one",
+			slog.String("path", cfg.CategoryDictionary), slog.String("error", err.Error()))
+		return categories.Default()
+	}
+
+	return dictionary
 }
 
 type Resp struct {
@@ -132,9 +194,27 @@ func withTransaction(db postgres.DB, handler withTransactionHandler) fiber.Handl
 		} else {
 			if commitErr := tx.Commit(ctx); commitErr != nil {
 				slog.Error("failed to commit transaction", slog.String("error", commitErr.Error()))
+			} else {
+				runAfterCommit(c)
 			}
 		}
 
 		return err
 	}
 }
+
+const afterCommitKey = "afterCommit"
+
+// afterCommit schedules fn to run once the request transaction has been
+// committed. It is dropped if the transaction rolls back.
+func afterCommit(c *fiber.Ctx, fn func()) {
+	hooks, _ := c.Locals(afterCommitKey).([]func())
+	c.Locals(afterCommitKey, append(hooks, fn))
+}
+
+func runAfterCommit(c *fiber.Ctx) {
+	hooks, _ := c.Locals(afterCommitKey).([]func())
+	for _, fn := range hooks {
+		fn()
+	}
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/signedtoken"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newInviteService(c *fiber.Ctx, tx pgx.Tx, signer *signedtoken.Signer) *shopping_list.InviteService {
+	return shopping_list.NewInviteService(
+		repository.NewInviteStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		repository.NewListStorageRepo(tx),
+		newEventPublisher(c),
+		signer,
+	)
+}
+
+func registerInviteRoutes(households, invites fiber.Router, db postgres.DB, signer *signedtoken.Signer) {
+	households.Get("/:id/invites", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		invites, err := newInviteService(c, tx, signer).Invites(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(invites)
+	}))
+
+	households.Post("/:id/invites", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.NewInvite
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		invite, err := newInviteService(c, tx, signer).CreateInvite(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(invite)
+	}))
+
+	households.Delete("/:id/invites/:inviteId", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		inviteID, err := uuidParam(c, "inviteId")
+		if err != nil {
+			return err
+		}
+
+		if err := newInviteService(c, tx, signer).RevokeInvite(c.UserContext(), currentUser(c), householdID, inviteID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+
+	invites.Post("/:token/accept", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		accepted, err := newInviteService(c, tx, signer).Accept(c.UserContext(), currentUser(c), c.Params("token"))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(accepted)
+	}))
+}
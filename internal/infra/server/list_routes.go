@@ -0,0 +1,247 @@
+package server
+
+import (
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"log/slog"
+)
+
+type listNameRequest struct {
+	Name string `json:"name"`
+}
+
+type createListRequest struct {
+	HouseholdID uuid.UUID `json:"household_id"`
+	Name        string    `json:"name"`
+}
+
+func newListService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.ListService {
+	return shopping_list.NewListService(
+		repository.NewListStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		repository.NewStoreStorageRepo(tx),
+		repository.NewPantryStorageRepo(tx),
+		repository.NewPriceStorageRepo(tx),
+		repository.NewHistoryStorageRepo(tx),
+		shopping_list.NewCategorizer(dictionary, repository.NewCategoryStorageRepo(tx)),
+	)
+}
+
+func registerListRoutes(lists fiber.Router, db postgres.DB, dictionary *categories.Dictionary, duplicateThreshold float64) {
+	lists.Get("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		result, err := newListService(tx, dictionary).Lists(c.UserContext(), currentUser(c))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	lists.Post("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		var req createListRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		list, err := newListService(tx, dictionary).CreateList(c.UserContext(), currentUser(c), req.HouseholdID, req.Name)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(list)
+	}))
+
+	lists.Get("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		storeID, err := uuidQuery(c, "store")
+		if err != nil {
+			return err
+		}
+
+		list, err := newListService(tx, dictionary).List(c.UserContext(), currentUser(c), listID, storeID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(list)
+	}))
+
+	lists.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req listNameRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		list, err := newListService(tx, dictionary).RenameList(c.UserContext(), currentUser(c), listID, req.Name)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(list)
+	}))
+
+	lists.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newListService(tx, dictionary).DeleteList(c.UserContext(), currentUser(c), listID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+
+	lists.Post("/:id/items", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.NewItem
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		added, err := newListService(tx, dictionary).AddItem(c.UserContext(), currentUser(c), listID, req, duplicateThreshold)
+		if err != nil {
+			return serviceError(err)
+		}
+		if added.Merged {
+			return c.JSON(added)
+		}
+		return c.Status(fiber.StatusCreated).JSON(added)
+	}))
+
+	lists.Put("/:id/items/:itemId", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, itemID, err := itemParams(c)
+		if err != nil {
+			return err
+		}
+		var req shopping_list.ItemUpdate
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		item, err := newListService(tx, dictionary).EditItem(c.UserContext(), currentUser(c), listID, itemID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	lists.Post("/:id/items/:itemId/check", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, itemID, err := itemParams(c)
+		if err != nil {
+			return err
+		}
+
+		item, err := newListService(tx, dictionary).CheckItem(c.UserContext(), currentUser(c), listID, itemID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	lists.Post("/:id/items/:itemId/uncheck", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, itemID, err := itemParams(c)
+		if err != nil {
+			return err
+		}
+
+		item, err := newListService(tx, dictionary).UncheckItem(c.UserContext(), currentUser(c), listID, itemID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	lists.Post("/:id/items/:itemId/merge", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, itemID, err := itemParams(c)
+		if err != nil {
+			return err
+		}
+		var req shopping_list.MergeInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		item, err := newListService(tx, dictionary).MergeItems(c.UserContext(), currentUser(c), listID, itemID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	lists.Delete("/:id/items/:itemId", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, itemID, err := itemParams(c)
+		if err != nil {
+			return err
+		}
+
+		if err := newListService(tx, dictionary).RemoveItem(c.UserContext(), currentUser(c), listID, itemID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+}
+
+func uuidParam(c *fiber.Ctx, name string) (uuid.UUID, error) {
+	id, err := uuid.Parse(c.Params(name))
+	if err != nil {
+		return uuid.Nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+name)
+	}
+	return id, nil
+}
+
+// uuidQuery reads an optional UUID query parameter; it is nil when absent.
+func uuidQuery(c *fiber.Ctx, name string) (*uuid.UUID, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "invalid "+name)
+	}
+	return &id, nil
+}
+
+func itemParams(c *fiber.Ctx) (uuid.UUID, uuid.UUID, error) {
+	listID, err := uuidParam(c, "id")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	itemID, err := uuidParam(c, "itemId")
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return listID, itemID, nil
+}
+
+// serviceError maps core errors to HTTP errors.
+func serviceError(err error) error {
+	switch {
+	case errors.Is(err, shopping_list.ErrNotFound):
+		return fiber.NewError(fiber.StatusNotFound, err.Error())
+	case errors.Is(err, shopping_list.ErrInvalid):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, shopping_list.ErrForbidden):
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case errors.Is(err, shopping_list.ErrConflict):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, shopping_list.ErrGone):
+		return fiber.NewError(fiber.StatusGone, err.Error())
+	}
+
+	slog.Error("request failed", slog.String("error", err.Error()))
+	return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type mealPlanShoppingListRequest struct {
+	From   string     `json:"from"`
+	To     string     `json:"to"`
+	ListID *uuid.UUID `json:"list_id"`
+}
+
+func newMealPlanService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.MealPlanService {
+	return shopping_list.NewMealPlanService(
+		repository.NewMealPlanStorageRepo(tx),
+		repository.NewRecipeStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		newListService(tx, dictionary),
+	)
+}
+
+func registerMealPlanRoutes(households, mealPlan fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/meal-plan", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		entries, err := newMealPlanService(tx, dictionary).MealPlan(
+			c.UserContext(), currentUser(c), householdID, c.Query("from"), c.Query("to"))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(entries)
+	}))
+
+	households.Post("/:id/meal-plan", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.MealPlanInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		entry, err := newMealPlanService(tx, dictionary).PlanMeal(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(entry)
+	}))
+
+	households.Post("/:id/meal-plan/shopping-list", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req mealPlanShoppingListRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		result, err := newMealPlanService(tx, dictionary).ShoppingList(
+			c.UserContext(), currentUser(c), householdID, req.From, req.To, req.ListID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	mealPlan.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		entryID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.MealPlanInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		entry, err := newMealPlanService(tx, dictionary).UpdateMealPlanEntry(c.UserContext(), currentUser(c), entryID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(entry)
+	}))
+
+	mealPlan.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		entryID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newMealPlanService(tx, dictionary).DeleteMealPlanEntry(c.UserContext(), currentUser(c), entryID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+}
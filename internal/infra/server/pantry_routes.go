@@ -0,0 +1,118 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// defaultExpiringDays is how far ahead the expiring endpoint looks without ?days=.
+const defaultExpiringDays = 3
+
+type consumeRequest struct {
+	Quantity quantity.Quantity `json:"quantity"`
+}
+
+func newPantryService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.PantryService {
+	return shopping_list.NewPantryService(
+		repository.NewPantryStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		newListService(tx, dictionary),
+	)
+}
+
+func registerPantryRoutes(households, pantry fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/pantry", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		items, err := newPantryService(tx, dictionary).Pantry(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(items)
+	}))
+
+	households.Post("/:id/pantry", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.NewPantryItem
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		item, err := newPantryService(tx, dictionary).AddStock(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	households.Get("/:id/pantry/expiring", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		days := c.QueryInt("days", defaultExpiringDays)
+		items, err := newPantryService(tx, dictionary).Expiring(c.UserContext(), currentUser(c), householdID, days)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(items)
+	}))
+
+	pantry.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		itemID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.PantryUpdate
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		result, err := newPantryService(tx, dictionary).UpdatePantryItem(c.UserContext(), currentUser(c), itemID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	pantry.Post("/:id/consume", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		itemID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req consumeRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		result, err := newPantryService(tx, dictionary).Consume(c.UserContext(), currentUser(c), itemID, req.Quantity)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	pantry.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		itemID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newPantryService(tx, dictionary).DeletePantryItem(c.UserContext(), currentUser(c), itemID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+}
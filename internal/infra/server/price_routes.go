@@ -0,0 +1,65 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newPriceService(c *fiber.Ctx, tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.PriceService {
+	return shopping_list.NewPriceService(
+		repository.NewPriceStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		repository.NewStoreStorageRepo(tx),
+		newListService(tx, dictionary),
+		newBudgetService(c, tx, dictionary),
+	)
+}
+
+func registerPriceRoutes(households, stores, lists fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/prices", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newPriceService(c, tx, dictionary).PriceHistory(c.UserContext(), currentUser(c), householdID, c.Query("name"))
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	stores.Post("/:id/prices", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		storeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.NewPrice
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		price, err := newPriceService(c, tx, dictionary).RecordPrice(c.UserContext(), currentUser(c), storeID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(price)
+	}))
+
+	lists.Get("/:id/store-estimates", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		listID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newPriceService(c, tx, dictionary).CompareStores(c.UserContext(), currentUser(c), listID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+}
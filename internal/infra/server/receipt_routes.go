@@ -0,0 +1,39 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/PocketPalCo/shopping-service/pkg/receipt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newReceiptService(c *fiber.Ctx, tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.ReceiptService {
+	return shopping_list.NewReceiptService(
+		repository.NewHouseholdStorageRepo(tx),
+		repository.NewStoreStorageRepo(tx),
+		newListService(tx, dictionary),
+		newPriceService(c, tx, dictionary),
+	)
+}
+
+func registerReceiptRoutes(receipts fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	receipts.Post("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		var req shopping_list.ReceiptInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		result, err := newReceiptService(c, tx, dictionary).Ingest(c.UserContext(), currentUser(c), req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	receipts.Get("/formats", func(c *fiber.Ctx) error {
+		return c.JSON(receipt.Formats())
+	})
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type addToListRequest struct {
+	ListID *uuid.UUID `json:"list_id"`
+}
+
+func newRecipeService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.RecipeService {
+	return shopping_list.NewRecipeService(
+		repository.NewRecipeStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		newListService(tx, dictionary),
+	)
+}
+
+func registerRecipeRoutes(households, recipes fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/recipes", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newRecipeService(tx, dictionary).Recipes(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	households.Post("/:id/recipes", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.RecipeInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		recipe, err := newRecipeService(tx, dictionary).CreateRecipe(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(recipe)
+	}))
+
+	recipes.Get("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		recipeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		recipe, err := newRecipeService(tx, dictionary).Recipe(c.UserContext(), currentUser(c), recipeID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(recipe)
+	}))
+
+	recipes.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		recipeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.RecipeInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		recipe, err := newRecipeService(tx, dictionary).UpdateRecipe(c.UserContext(), currentUser(c), recipeID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(recipe)
+	}))
+
+	recipes.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		recipeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newRecipeService(tx, dictionary).DeleteRecipe(c.UserContext(), currentUser(c), recipeID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+
+	recipes.Post("/:id/add-to-list", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		recipeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req addToListRequest
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+		}
+
+		servings := c.QueryInt("servings", 0)
+		result, err := newRecipeService(tx, dictionary).AddToList(c.UserContext(), currentUser(c), recipeID, req.ListID, servings)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+}
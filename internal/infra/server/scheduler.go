@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/google/uuid"
+	"log/slog"
+	"time"
+)
+
+// runScheduler applies due recurring templates and releases expired item
+// claims every interval until ctx is done.
+func runScheduler(ctx context.Context, db postgres.DB, dictionary *categories.Dictionary, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			applyDueTemplates(ctx, db, dictionary, now)
+			releaseExpiredClaims(ctx, db, now)
+		}
+	}
+}
+
+// applyDueTemplates applies every template due at now, each in its own
+// transaction. A template that fails to apply has its run skipped, also in a
+// transaction of its own, so that it does not hold back the others.
+func applyDueTemplates(ctx context.Context, db postgres.DB, dictionary *categories.Dictionary, now time.Time) {
+	for ctx.Err() == nil {
+		id, err := applyNextDueTemplate(ctx, db, dictionary, now)
+		if err != nil && id != uuid.Nil {
+			slog.Error("failed to apply recurring template", slog.String("template", id.String()), slog.String("error", err.Error()))
+			err = skipTemplateRun(ctx, db, dictionary, id, now)
+		}
+		if err != nil {
+			slog.Error("failed to apply recurring templates", slog.String("error", err.Error()))
+			return
+		}
+		if id == uuid.Nil {
+			return
+		}
+	}
+}
+
+func applyNextDueTemplate(ctx context.Context, db postgres.DB, dictionary *categories.Dictionary, now time.Time) (uuid.UUID, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	defer func() {
+		// Rolling back after a commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	id, err := newTemplateService(tx, dictionary).ApplyNextDue(ctx, now)
+	if err != nil || id == uuid.Nil {
+		return id, err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+func skipTemplateRun(ctx context.Context, db postgres.DB, dictionary *categories.Dictionary, id uuid.UUID, now time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Rolling back after a commit is a no-op.
+		_ = tx.Rollback(ctx)
+	}()
+
+	if err := newTemplateService(tx, dictionary).SkipRun(ctx, id, now); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// runTombstonePurge deletes the sync tombstones older than retention every
+// interval until ctx is done.
+func runTombstonePurge(ctx context.Context, db postgres.DB, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purgeTombstones(ctx, db, retention, now)
+		}
+	}
+}
+
+func purgeTombstones(ctx context.Context, db postgres.DB, retention time.Duration, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	purged, err := shopping_list.NewChangeService(repository.NewChangeStorageRepo(db), retention).PurgeTombstones(ctx, now)
+	if err != nil {
+		slog.Error("failed to purge sync tombstones", slog.String("error", err.Error()))
+		return
+	}
+	slog.Debug("purged sync tombstones", slog.Int64("count", purged))
+}
+
+// releaseExpiredClaims clears the claims that expired by now. Until then
+// clients tell an expired claim by its expires_at.
+func releaseExpiredClaims(ctx context.Context, db postgres.DB, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	if _, err := repository.NewClaimStorageRepo(db).ReleaseExpiredClaims(ctx, now); err != nil {
+		slog.Error("failed to release expired item claims", slog.String("error", err.Error()))
+	}
+}
+
+// runTrashPurge deletes for good the lists and items that have been in the
+// trash for longer than retention every interval until ctx is done.
+func runTrashPurge(ctx context.Context, db postgres.DB, retention, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			purgeTrash(ctx, db, retention, now)
+		}
+	}
+}
+
+func purgeTrash(ctx context.Context, db postgres.DB, retention time.Duration, now time.Time) {
+	ctx, cancel := context.WithTimeout(ctx, time.Minute)
+	defer cancel()
+
+	purged, err := repository.NewTrashStorageRepo(db).PurgeTrash(ctx, now.Add(-retention))
+	if err != nil {
+		slog.Error("failed to purge trash", slog.String("error", err.Error()))
+		return
+	}
+	slog.Debug("purged trash", slog.Int64("lists", purged.Lists), slog.Int64("items", purged.Items))
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func registerSearchRoutes(search fiber.Router, db postgres.DB) {
+	search.Get("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		limit := c.QueryInt("limit", shopping_list.DefaultSearchLimit)
+		service := shopping_list.NewSearchService(repository.NewSearchStorageRepo(tx))
+		results, err := service.Search(c.UserContext(), currentUser(c), c.Query("q"), c.Query("lang"), limit)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(results)
+	}))
+}
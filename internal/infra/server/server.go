@@ -26,6 +26,8 @@ type Server struct {
 	db             postgres.DB
 	traceProvider  *sdktrace.TracerProvider
 	metricProvider *metric.MeterProvider
+	jobs           context.Context
+	stopJobs       context.CancelFunc
 }
 
 func New(ctx context.Context, cfg *config.Config, dbConn *pgxpool.Pool) *Server {
@@ -79,6 +81,7 @@ func New(ctx context.Context, cfg *config.Config, dbConn *pgxpool.Pool) *Server
 	}
 
 	app := fiber.New()
+	jobs, stopJobs := context.WithCancel(context.Background())
 
 	return &Server{
 		cfg:            cfg,
@@ -86,12 +89,16 @@ func New(ctx context.Context, cfg *config.Config, dbConn *pgxpool.Pool) *Server
 		db:             instrumentedConn,
 		traceProvider:  tp,
 		metricProvider: provider,
+		jobs:           jobs,
+		stopJobs:       stopJobs,
 	}
 }
 
 func (s *Server) Shutdown() {
 	slog.Info("Shutting down server")
 
+	s.stopJobs()
+
 	if err := s.traceProvider.Shutdown(context.Background()); err != nil {
 		slog.Error("Error shutting down trace provider", slog.String("error", err.Error()))
 	}
@@ -110,8 +117,14 @@ func (s *Server) Shutdown() {
 }
 
 func (s *Server) Start() {
+	dictionary := loadCategoryDictionary(s.cfg)
+
 	initGlobalMiddlewares(s.app, s.cfg)
-	registerHttpRoutes(s.app, s.cfg, s.db)
+	registerHttpRoutes(s.app, s.cfg, s.db, dictionary)
+
+	go runScheduler(s.jobs, s.db, dictionary, time.Duration(s.cfg.SchedulerInterval)*time.Second)
+	go runTombstonePurge(s.jobs, s.db, tombstoneRetention(s.cfg), time.Hour)
+	go runTrashPurge(s.jobs, s.db, trashRetention(s.cfg), time.Hour)
 
 	setupWs(s.app, s.cfg, s.db)
 	setupWebRTC(s.app)
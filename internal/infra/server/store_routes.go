@@ -0,0 +1,88 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newStoreService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.StoreService {
+	return shopping_list.NewStoreService(repository.NewStoreStorageRepo(tx), repository.NewHouseholdStorageRepo(tx), dictionary)
+}
+
+func registerStoreRoutes(households, stores fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/stores", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newStoreService(tx, dictionary).Stores(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	households.Post("/:id/stores", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.StoreInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		store, err := newStoreService(tx, dictionary).CreateStore(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(store)
+	}))
+
+	stores.Get("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		storeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		store, err := newStoreService(tx, dictionary).Store(c.UserContext(), currentUser(c), storeID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(store)
+	}))
+
+	stores.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		storeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.StoreInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		store, err := newStoreService(tx, dictionary).UpdateStore(c.UserContext(), currentUser(c), storeID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(store)
+	}))
+
+	stores.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		storeID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newStoreService(tx, dictionary).DeleteStore(c.UserContext(), currentUser(c), storeID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func registerSuggestionRoutes(households fiber.Router, db postgres.DB) {
+	households.Get("/:id/suggestions", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		limit := c.QueryInt("limit", shopping_list.DefaultSuggestionLimit)
+		service := shopping_list.NewSuggestionService(repository.NewSuggestionStorageRepo(tx), repository.NewHouseholdStorageRepo(tx))
+		suggestions, err := service.Suggestions(c.UserContext(), currentUser(c), householdID, limit)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(suggestions)
+	}))
+}
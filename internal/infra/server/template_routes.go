@@ -0,0 +1,114 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type applyTemplateRequest struct {
+	ListID uuid.UUID `json:"list_id"`
+}
+
+func newTemplateService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.TemplateService {
+	return shopping_list.NewTemplateService(
+		repository.NewTemplateStorageRepo(tx),
+		repository.NewHouseholdStorageRepo(tx),
+		newListService(tx, dictionary),
+	)
+}
+
+func registerTemplateRoutes(households, templates fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/templates", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newTemplateService(tx, dictionary).Templates(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	households.Post("/:id/templates", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.TemplateInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		template, err := newTemplateService(tx, dictionary).CreateTemplate(c.UserContext(), currentUser(c), householdID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(template)
+	}))
+
+	templates.Get("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		templateID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		template, err := newTemplateService(tx, dictionary).Template(c.UserContext(), currentUser(c), templateID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(template)
+	}))
+
+	templates.Put("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		templateID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.TemplateInput
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		template, err := newTemplateService(tx, dictionary).UpdateTemplate(c.UserContext(), currentUser(c), templateID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(template)
+	}))
+
+	templates.Delete("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		templateID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		if err := newTemplateService(tx, dictionary).DeleteTemplate(c.UserContext(), currentUser(c), templateID); err != nil {
+			return serviceError(err)
+		}
+		return c.SendStatus(fiber.StatusNoContent)
+	}))
+
+	templates.Post("/:id/apply", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		templateID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req applyTemplateRequest
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		result, err := newTemplateService(tx, dictionary).Apply(c.UserContext(), currentUser(c), templateID, req.ListID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"github.com/PocketPalCo/shopping-service/config"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+func trashRetention(cfg *config.Config) time.Duration {
+	return time.Duration(cfg.TrashRetention) * 24 * time.Hour
+}
+
+func newTrashService(tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.TrashService {
+	return shopping_list.NewTrashService(repository.NewTrashStorageRepo(tx), newListService(tx, dictionary))
+}
+
+func registerTrashRoutes(households fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/trash", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		trash, err := newTrashService(tx, dictionary).Trash(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(trash)
+	}))
+
+	households.Post("/:id/trash/lists/:listId/restore", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		listID, err := uuidParam(c, "listId")
+		if err != nil {
+			return err
+		}
+
+		list, err := newTrashService(tx, dictionary).RestoreList(c.UserContext(), currentUser(c), householdID, listID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(list)
+	}))
+
+	households.Post("/:id/trash/items/:itemId/restore", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		itemID, err := uuidParam(c, "itemId")
+		if err != nil {
+			return err
+		}
+
+		item, err := newTrashService(tx, dictionary).RestoreItem(c.UserContext(), currentUser(c), householdID, itemID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(item)
+	}))
+
+	households.Delete("/:id/trash", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		emptied, err := newTrashService(tx, dictionary).EmptyTrash(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(emptied)
+	}))
+}
@@ -0,0 +1,119 @@
+package server
+
+import (
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/internal/repository"
+	"github.com/PocketPalCo/shopping-service/pkg/categories"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+func newTripService(c *fiber.Ctx, tx pgx.Tx, dictionary *categories.Dictionary) *shopping_list.TripService {
+	return shopping_list.NewTripService(
+		repository.NewTripStorageRepo(tx),
+		newListService(tx, dictionary),
+		newPriceService(c, tx, dictionary),
+		newEventPublisher(c),
+	)
+}
+
+func registerTripRoutes(households, trips fiber.Router, db postgres.DB, dictionary *categories.Dictionary) {
+	households.Get("/:id/trips", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		householdID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		result, err := newTripService(c, tx, dictionary).ActiveTrips(c.UserContext(), currentUser(c), householdID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(result)
+	}))
+
+	trips.Post("/", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		var req shopping_list.NewTrip
+		if err := c.BodyParser(&req); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+
+		trip, err := newTripService(c, tx, dictionary).StartTrip(c.UserContext(), currentUser(c), req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.Status(fiber.StatusCreated).JSON(trip)
+	}))
+
+	trips.Get("/:id", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		tripID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+
+		trip, err := newTripService(c, tx, dictionary).Trip(c.UserContext(), currentUser(c), tripID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(trip)
+	}))
+
+	trips.Post("/:id/items/:itemId/check", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		tripID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		itemID, err := uuidParam(c, "itemId")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.TripCheckInput
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+		}
+
+		entry, err := newTripService(c, tx, dictionary).CheckItem(c.UserContext(), currentUser(c), tripID, itemID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(entry)
+	}))
+
+	trips.Post("/:id/items/:itemId/not-found", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		tripID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		itemID, err := uuidParam(c, "itemId")
+		if err != nil {
+			return err
+		}
+
+		entry, err := newTripService(c, tx, dictionary).MarkNotFound(c.UserContext(), currentUser(c), tripID, itemID)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(entry)
+	}))
+
+	trips.Post("/:id/finish", withTransaction(db, func(c *fiber.Ctx, tx pgx.Tx) error {
+		tripID, err := uuidParam(c, "id")
+		if err != nil {
+			return err
+		}
+		var req shopping_list.FinishTripInput
+		if len(c.Body()) > 0 {
+			if err := c.BodyParser(&req); err != nil {
+				return fiber.NewError(fiber.StatusBadRequest, err.Error())
+			}
+		}
+
+		summary, err := newTripService(c, tx, dictionary).FinishTrip(c.UserContext(), currentUser(c), tripID, req)
+		if err != nil {
+			return serviceError(err)
+		}
+		return c.JSON(summary)
+	}))
+}
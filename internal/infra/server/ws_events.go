@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"log/slog"
+)
+
+// wsEventPublisher pushes core events to the recipients' WebSocket connections
+// once the request transaction has been committed.
+type wsEventPublisher struct {
+	c *fiber.Ctx
+}
+
+func newEventPublisher(c *fiber.Ctx) shopping_list.EventPublisher {
+	return wsEventPublisher{c: c}
+}
+
+func (p wsEventPublisher) Publish(_ context.Context, recipients []uuid.UUID, event shopping_list.Event) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		slog.Error("failed to marshal event", slog.String("type", string(event.Type)), slog.String("error", err.Error()))
+		return
+	}
+
+	afterCommit(p.c, func() {
+		for _, userID := range recipients {
+			if err := SendToUser(userID.String(), message); err != nil {
+				slog.Debug("event not delivered", slog.String("type", string(event.Type)), slog.String("error", err.Error()))
+			}
+		}
+	})
+}
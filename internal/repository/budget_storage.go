@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type BudgetStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewBudgetStorageRepo(conn postgres.Querier) *BudgetStorageRepo {
+	return &BudgetStorageRepo{
+		conn: conn,
+	}
+}
+
+const budgetColumns = "id, household_id, category, period, amount, currency, thresholds, created_at, updated_at"
+
+func (r *BudgetStorageRepo) HouseholdBudgets(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Budget, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT "+budgetColumns+" FROM budgets WHERE household_id = $1 ORDER BY period, category",
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Budget])
+}
+
+func (r *BudgetStorageRepo) GetBudget(ctx context.Context, id uuid.UUID) (shopping_list.Budget, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+budgetColumns+" FROM budgets WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.Budget{}, err
+	}
+	defer rows.Close()
+
+	budget, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Budget])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Budget{}, shopping_list.ErrBudgetNotFound
+	}
+
+	return budget, err
+}
+
+func (r *BudgetStorageRepo) CreateBudget(ctx context.Context, budget shopping_list.Budget) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO budgets (id, household_id, category, period, amount, currency, thresholds, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		budget.ID, budget.HouseholdID, budget.Category, string(budget.Period), budget.Amount, budget.Currency,
+		budget.Thresholds, budget.CreatedAt, budget.UpdatedAt)
+	return err
+}
+
+func (r *BudgetStorageRepo) UpdateBudget(ctx context.Context, budget shopping_list.Budget) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`UPDATE budgets SET category = $2, period = $3, amount = $4, currency = $5, thresholds = $6, updated_at = $7
+		WHERE id = $1`,
+		budget.ID, budget.Category, string(budget.Period), budget.Amount, budget.Currency, budget.Thresholds,
+		budget.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrBudgetNotFound
+	}
+
+	return nil
+}
+
+func (r *BudgetStorageRepo) DeleteBudget(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM budgets WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrBudgetNotFound
+	}
+
+	return nil
+}
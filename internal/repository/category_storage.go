@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type CategoryStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewCategoryStorageRepo(conn postgres.Querier) *CategoryStorageRepo {
+	return &CategoryStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *CategoryStorageRepo) HouseholdCategory(ctx context.Context, householdID uuid.UUID, name string) (string, error) {
+	var category string
+	// language=sql
+	err := r.conn.QueryRow(
+		ctx,
+		"SELECT category FROM household_categories WHERE household_id = $1 AND item_name = $2",
+		householdID, name).Scan(&category)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return category, err
+}
+
+func (r *CategoryStorageRepo) HouseholdCategories(ctx context.Context, householdID uuid.UUID, names []string) (map[string]string, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT item_name, category FROM household_categories WHERE household_id = $1 AND item_name = ANY($2)",
+		householdID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categoryRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[householdCategoryRow])
+	if err != nil {
+		return nil, err
+	}
+
+	remembered := make(map[string]string, len(categoryRows))
+	for _, row := range categoryRows {
+		remembered[row.ItemName] = row.Category
+	}
+	return remembered, nil
+}
+
+func (r *CategoryStorageRepo) SaveHouseholdCategory(ctx context.Context, householdID uuid.UUID, name, category string, at time.Time) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO household_categories (household_id, item_name, category, updated_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (household_id, item_name) DO UPDATE SET category = EXCLUDED.category, updated_at = EXCLUDED.updated_at`,
+		householdID, name, category, at)
+	return err
+}
+
+type householdCategoryRow struct {
+	ItemName string
+	Category string
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"time"
+)
+
+type ChangeStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewChangeStorageRepo(conn postgres.Querier) *ChangeStorageRepo {
+	return &ChangeStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *ChangeStorageRepo) ChangeHorizon(ctx context.Context) (uint64, error) {
+	var horizon string
+	// language=sql
+	err := r.conn.QueryRow(ctx, "SELECT pg_snapshot_xmin(pg_current_snapshot())::text").Scan(&horizon)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(horizon, 10, 64)
+}
+
+func (r *ChangeStorageRepo) ChangedLists(ctx context.Context, userID uuid.UUID, window shopping_list.ChangeWindow, limit int) ([]shopping_list.Change, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT l.change_seq, l.id, l.household_id, l.name, l.created_at, l.updated_at
+		FROM shopping_lists l
+		JOIN household_members m ON m.household_id = l.household_id
+		WHERE m.user_id = $1 AND l.deleted_at IS NULL AND l.change_xid >= $2::text::xid8 AND l.change_xid < $3::text::xid8 AND l.change_seq > $4
+		ORDER BY l.change_seq
+		LIMIT $5`,
+		userID, xid(window.From), xid(window.To), window.After, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	listRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[listChangeRow])
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]shopping_list.Change, 0, len(listRows))
+	for _, row := range listRows {
+		changes = append(changes, shopping_list.Change{Seq: row.ChangeSeq, List: &row.List})
+	}
+	return changes, nil
+}
+
+func (r *ChangeStorageRepo) ChangedItems(ctx context.Context, userID uuid.UUID, window shopping_list.ChangeWindow, limit int) ([]shopping_list.Change, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT i.change_seq, i.id, i.list_id, i.name, i.quantity_value, i.quantity_unit, i.note, i.category, i.checked,
+			i.checked_at, i.position, i.claimed_by, i.claim_assigned_by, i.claimed_at, i.claim_expires_at, i.created_at,
+			i.updated_at
+		FROM list_items i
+		JOIN shopping_lists l ON l.id = i.list_id
+		JOIN household_members m ON m.household_id = l.household_id
+		WHERE m.user_id = $1 AND i.deleted_at IS NULL AND l.deleted_at IS NULL
+			AND i.change_xid >= $2::text::xid8 AND i.change_xid < $3::text::xid8 AND i.change_seq > $4
+		ORDER BY i.change_seq
+		LIMIT $5`,
+		userID, xid(window.From), xid(window.To), window.After, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itemRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[itemChangeRow])
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]shopping_list.Change, 0, len(itemRows))
+	for _, row := range itemRows {
+		item := row.item()
+		changes = append(changes, shopping_list.Change{Seq: row.ChangeSeq, Item: &item})
+	}
+	return changes, nil
+}
+
+func (r *ChangeStorageRepo) Tombstones(ctx context.Context, userID uuid.UUID, window shopping_list.ChangeWindow, limit int) ([]shopping_list.Change, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT change_seq, kind, id, list_id, deleted_at FROM sync_tombstones
+		WHERE user_id = $1 AND change_xid >= $2::text::xid8 AND change_xid < $3::text::xid8 AND change_seq > $4
+		ORDER BY change_seq
+		LIMIT $5`,
+		userID, xid(window.From), xid(window.To), window.After, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tombstoneRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[tombstoneRow])
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make([]shopping_list.Change, 0, len(tombstoneRows))
+	for _, row := range tombstoneRows {
+		tombstone := row.tombstone()
+		changes = append(changes, shopping_list.Change{Seq: row.ChangeSeq, Tombstone: &tombstone})
+	}
+	return changes, nil
+}
+
+func (r *ChangeStorageRepo) PurgeTombstones(ctx context.Context, before time.Time) (int64, error) {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM sync_tombstones WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// xid formats a transaction ID for a $n::text::xid8 parameter; pgx has no
+// codec for xid8.
+func xid(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
+
+type listChangeRow struct {
+	ChangeSeq int64
+	shopping_list.List
+}
+
+type itemChangeRow struct {
+	ChangeSeq int64
+	itemRow
+}
+
+type tombstoneRow struct {
+	ChangeSeq int64
+	Kind      string
+	ID        uuid.UUID
+	ListID    *uuid.UUID
+	DeletedAt time.Time
+}
+
+func (r tombstoneRow) tombstone() shopping_list.Tombstone {
+	return shopping_list.Tombstone{
+		Kind:      shopping_list.TombstoneKind(r.Kind),
+		ID:        r.ID,
+		ListID:    r.ListID,
+		DeletedAt: r.DeletedAt,
+	}
+}
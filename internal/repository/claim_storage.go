@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"time"
+)
+
+type ClaimStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewClaimStorageRepo(conn postgres.Querier) *ClaimStorageRepo {
+	return &ClaimStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *ClaimStorageRepo) ClaimedItems(ctx context.Context, userID uuid.UUID, now time.Time) ([]shopping_list.Item, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+` FROM list_items
+		WHERE claimed_by = $1 AND claim_expires_at > $2 AND NOT checked AND deleted_at IS NULL
+			AND list_id IN (
+				SELECT l.id FROM shopping_lists l
+				JOIN household_members m ON m.household_id = l.household_id
+				WHERE m.user_id = $1 AND l.deleted_at IS NULL
+			)
+		ORDER BY list_id, position`,
+		userID, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectItems(rows)
+}
+
+func (r *ClaimStorageRepo) ReleaseExpiredClaims(ctx context.Context, now time.Time) (int64, error) {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`UPDATE list_items SET claimed_by = NULL, claim_assigned_by = NULL, claimed_at = NULL, claim_expires_at = NULL
+		WHERE claim_expires_at <= $1 AND deleted_at IS NULL`,
+		now)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
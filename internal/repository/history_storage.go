@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type HistoryStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewHistoryStorageRepo(conn postgres.Querier) *HistoryStorageRepo {
+	return &HistoryStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *HistoryStorageRepo) AppendListEvent(ctx context.Context, event shopping_list.ListEvent) (int64, error) {
+	var seq int64
+	// language=sql
+	err := r.conn.QueryRow(
+		ctx,
+		`INSERT INTO list_events (id, list_id, actor_id, type, item_id, item_before, item_after, item_order, reverts, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING seq`,
+		event.ID, event.ListID, event.ActorID, string(event.Type), event.ItemID, event.Before, event.After, event.Order,
+		event.Reverts, event.OccurredAt).Scan(&seq)
+	if err != nil {
+		return 0, err
+	}
+	return seq, nil
+}
+
+// AppendListEvents copies the events in, which numbers them in the order
+// given.
+func (r *HistoryStorageRepo) AppendListEvents(ctx context.Context, events []shopping_list.ListEvent) error {
+	_, err := r.conn.CopyFrom(
+		ctx,
+		pgx.Identifier{"list_events"},
+		[]string{"id", "list_id", "actor_id", "type", "item_id", "item_before", "item_after", "item_order", "reverts", "occurred_at"},
+		pgx.CopyFromSlice(len(events), func(i int) ([]any, error) {
+			event := events[i]
+			return []any{event.ID, event.ListID, event.ActorID, string(event.Type), event.ItemID, event.Before, event.After,
+				event.Order, event.Reverts, event.OccurredAt}, nil
+		}))
+	return err
+}
+
+func (r *HistoryStorageRepo) ListEvents(ctx context.Context, listID uuid.UUID, limit int) ([]shopping_list.ListEvent, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT "+listEventColumns+" FROM list_events WHERE list_id = $1 ORDER BY seq DESC LIMIT $2",
+		listID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectListEvents(rows)
+}
+
+func (r *HistoryStorageRepo) GetListEvent(ctx context.Context, listID, id uuid.UUID) (shopping_list.ListEvent, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+listEventColumns+" FROM list_events WHERE list_id = $1 AND id = $2", listID, id)
+	if err != nil {
+		return shopping_list.ListEvent{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[listEventRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.ListEvent{}, shopping_list.ErrListEventNotFound
+	}
+	if err != nil {
+		return shopping_list.ListEvent{}, err
+	}
+
+	return row.event(), nil
+}
+
+func (r *HistoryStorageRepo) ListEventsAfter(ctx context.Context, listID uuid.UUID, seq int64) ([]shopping_list.ListEvent, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT "+listEventColumns+" FROM list_events WHERE list_id = $1 AND seq > $2 ORDER BY seq",
+		listID, seq)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectListEvents(rows)
+}
+
+func (r *HistoryStorageRepo) LastUndoableEvent(ctx context.Context, listID, actor uuid.UUID) (shopping_list.ListEvent, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+listEventColumns+` FROM list_events e
+		WHERE list_id = $1 AND actor_id = $2 AND reverts IS NULL
+			AND NOT EXISTS (SELECT 1 FROM list_events u WHERE u.reverts = e.id)
+		ORDER BY seq DESC LIMIT 1`,
+		listID, actor)
+	if err != nil {
+		return shopping_list.ListEvent{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[listEventRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.ListEvent{}, shopping_list.ErrNothingToUndo
+	}
+	if err != nil {
+		return shopping_list.ListEvent{}, err
+	}
+
+	return row.event(), nil
+}
+
+const listEventColumns = "id, seq, list_id, actor_id, type, item_id, item_before, item_after, item_order, reverts, occurred_at"
+
+// listEventRow is a list_events row; the item snapshots and the order are JSON.
+type listEventRow struct {
+	ID         uuid.UUID
+	Seq        int64
+	ListID     uuid.UUID
+	ActorID    *uuid.UUID
+	Type       string
+	ItemID     *uuid.UUID
+	ItemBefore *shopping_list.Item
+	ItemAfter  *shopping_list.Item
+	ItemOrder  *shopping_list.ItemOrder
+	Reverts    *uuid.UUID
+	OccurredAt time.Time
+}
+
+func (r listEventRow) event() shopping_list.ListEvent {
+	return shopping_list.ListEvent{
+		ID:         r.ID,
+		Seq:        r.Seq,
+		ListID:     r.ListID,
+		ActorID:    r.ActorID,
+		Type:       shopping_list.ListEventType(r.Type),
+		ItemID:     r.ItemID,
+		Before:     r.ItemBefore,
+		After:      r.ItemAfter,
+		Order:      r.ItemOrder,
+		Reverts:    r.Reverts,
+		OccurredAt: r.OccurredAt,
+	}
+}
+
+func collectListEvents(rows pgx.Rows) ([]shopping_list.ListEvent, error) {
+	eventRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[listEventRow])
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]shopping_list.ListEvent, 0, len(eventRows))
+	for _, row := range eventRows {
+		events = append(events, row.event())
+	}
+	return events, nil
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type HouseholdStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewHouseholdStorageRepo(conn postgres.Querier) *HouseholdStorageRepo {
+	return &HouseholdStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *HouseholdStorageRepo) UserHouseholds(ctx context.Context, userID uuid.UUID) ([]shopping_list.Household, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT h.id, h.name, h.created_at, h.updated_at
+		FROM households h
+		JOIN household_members m ON m.household_id = h.id
+		WHERE m.user_id = $1
+		ORDER BY h.created_at`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Household])
+}
+
+func (r *HouseholdStorageRepo) GetHousehold(ctx context.Context, id uuid.UUID) (shopping_list.Household, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT id, name, created_at, updated_at FROM households WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.Household{}, err
+	}
+	defer rows.Close()
+
+	household, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Household])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Household{}, shopping_list.ErrHouseholdNotFound
+	}
+
+	return household, err
+}
+
+func (r *HouseholdStorageRepo) CreateHousehold(ctx context.Context, household shopping_list.Household) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		"INSERT INTO households (id, name, created_at, updated_at) VALUES ($1, $2, $3, $4)",
+		household.ID, household.Name, household.CreatedAt, household.UpdatedAt)
+	return err
+}
+
+func (r *HouseholdStorageRepo) Members(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Member, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT household_id, user_id, role, created_at, updated_at
+		FROM household_members WHERE household_id = $1 ORDER BY created_at`,
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Member])
+}
+
+func (r *HouseholdStorageRepo) MemberRole(ctx context.Context, householdID, userID uuid.UUID) (shopping_list.Role, error) {
+	var role string
+	// language=sql
+	err := r.conn.QueryRow(
+		ctx,
+		"SELECT role FROM household_members WHERE household_id = $1 AND user_id = $2",
+		householdID, userID).Scan(&role)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", shopping_list.ErrNotMember
+	}
+	if err != nil {
+		return "", err
+	}
+	return shopping_list.Role(role), nil
+}
+
+func (r *HouseholdStorageRepo) SaveMember(ctx context.Context, member shopping_list.Member) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO household_members (household_id, user_id, role, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (household_id, user_id) DO UPDATE SET role = EXCLUDED.role, updated_at = EXCLUDED.updated_at`,
+		member.HouseholdID, member.UserID, string(member.Role), member.CreatedAt, member.UpdatedAt)
+	return err
+}
+
+func (r *HouseholdStorageRepo) DeleteMember(ctx context.Context, householdID, userID uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM household_members WHERE household_id = $1 AND user_id = $2", householdID, userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrMemberNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type InviteStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewInviteStorageRepo(conn postgres.Querier) *InviteStorageRepo {
+	return &InviteStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *InviteStorageRepo) HouseholdInvites(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Invite, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT id, household_id, list_id, role, created_by, max_uses, uses, expires_at, revoked_at, created_at
+		FROM invites WHERE household_id = $1 ORDER BY created_at DESC`,
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Invite])
+}
+
+func (r *InviteStorageRepo) GetInvite(ctx context.Context, id uuid.UUID) (shopping_list.Invite, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT id, household_id, list_id, role, created_by, max_uses, uses, expires_at, revoked_at, created_at
+		FROM invites WHERE id = $1`,
+		id)
+	if err != nil {
+		return shopping_list.Invite{}, err
+	}
+	defer rows.Close()
+
+	invite, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Invite])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Invite{}, shopping_list.ErrInviteNotFound
+	}
+
+	return invite, err
+}
+
+func (r *InviteStorageRepo) CreateInvite(ctx context.Context, invite shopping_list.Invite) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO invites (id, household_id, list_id, role, created_by, max_uses, uses, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		invite.ID, invite.HouseholdID, invite.ListID, string(invite.Role), invite.CreatedBy, invite.MaxUses, invite.Uses,
+		invite.ExpiresAt, invite.CreatedAt)
+	return err
+}
+
+func (r *InviteStorageRepo) RevokeInvite(ctx context.Context, householdID, id uuid.UUID, at time.Time) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE invites SET revoked_at = COALESCE(revoked_at, $3) WHERE household_id = $1 AND id = $2",
+		householdID, id, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrInviteNotFound
+	}
+
+	return nil
+}
+
+func (r *InviteStorageRepo) UseInvite(ctx context.Context, id uuid.UUID, at time.Time) (bool, error) {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`UPDATE invites SET uses = uses + 1
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > $2 AND (max_uses = 0 OR uses < max_uses)`,
+		id, at)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *InviteStorageRepo) SaveRedemption(ctx context.Context, inviteID, userID uuid.UUID, at time.Time) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO invite_redemptions (invite_id, user_id, redeemed_at) VALUES ($1, $2, $3)
+		ON CONFLICT (invite_id, user_id) DO UPDATE SET redeemed_at = EXCLUDED.redeemed_at`,
+		inviteID, userID, at)
+	return err
+}
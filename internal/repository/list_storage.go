@@ -0,0 +1,407 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"strconv"
+	"time"
+)
+
+type ListStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewListStorageRepo(conn postgres.Querier) *ListStorageRepo {
+	return &ListStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *ListStorageRepo) UserLists(ctx context.Context, userID uuid.UUID) ([]shopping_list.List, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT l.id, l.household_id, l.name, l.created_at, l.updated_at
+		FROM shopping_lists l
+		JOIN household_members m ON m.household_id = l.household_id
+		WHERE m.user_id = $1 AND l.deleted_at IS NULL
+		ORDER BY l.created_at`,
+		userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.List])
+}
+
+func (r *ListStorageRepo) GetList(ctx context.Context, id uuid.UUID) (shopping_list.List, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT id, household_id, name, created_at, updated_at FROM shopping_lists WHERE id = $1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return shopping_list.List{}, err
+	}
+	defer rows.Close()
+
+	list, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.List])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.List{}, shopping_list.ErrListNotFound
+	}
+
+	return list, err
+}
+
+func (r *ListStorageRepo) LatestHouseholdList(ctx context.Context, householdID uuid.UUID) (shopping_list.List, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT id, household_id, name, created_at, updated_at FROM shopping_lists
+		WHERE household_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC LIMIT 1`,
+		householdID)
+	if err != nil {
+		return shopping_list.List{}, err
+	}
+	defer rows.Close()
+
+	list, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.List])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.List{}, shopping_list.ErrListNotFound
+	}
+
+	return list, err
+}
+
+func (r *ListStorageRepo) CreateList(ctx context.Context, list shopping_list.List) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		"INSERT INTO shopping_lists (id, household_id, name, created_at, updated_at) VALUES ($1, $2, $3, $4, $5)",
+		list.ID, list.HouseholdID, list.Name, list.CreatedAt, list.UpdatedAt)
+	return err
+}
+
+func (r *ListStorageRepo) UpdateList(ctx context.Context, list shopping_list.List) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE shopping_lists SET name = $2, updated_at = $3 WHERE id = $1 AND deleted_at IS NULL",
+		list.ID, list.Name, list.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrListNotFound
+	}
+
+	return nil
+}
+
+func (r *ListStorageRepo) DeleteList(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "UPDATE shopping_lists SET deleted_at = $2 WHERE id = $1 AND deleted_at IS NULL", id, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrListNotFound
+	}
+
+	return nil
+}
+
+func (r *ListStorageRepo) RestoreList(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "UPDATE shopping_lists SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrListNotFound
+	}
+
+	return nil
+}
+
+func (r *ListStorageRepo) ListItems(ctx context.Context, listID uuid.UUID) ([]shopping_list.Item, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+itemColumns+" FROM list_items WHERE list_id = $1 AND deleted_at IS NULL ORDER BY position", listID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectItems(rows)
+}
+
+func (r *ListStorageRepo) CheckedItems(ctx context.Context, householdID uuid.UUID, since time.Time) ([]shopping_list.Item, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+` FROM list_items
+		WHERE list_id IN (SELECT id FROM shopping_lists WHERE household_id = $1 AND deleted_at IS NULL)
+			AND deleted_at IS NULL AND checked AND checked_at >= $2
+		ORDER BY checked_at DESC`,
+		householdID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectItems(rows)
+}
+
+func (r *ListStorageRepo) GetItem(ctx context.Context, listID, itemID uuid.UUID) (shopping_list.Item, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+itemColumns+" FROM list_items WHERE list_id = $1 AND id = $2 AND deleted_at IS NULL", listID, itemID)
+	if err != nil {
+		return shopping_list.Item{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[itemRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Item{}, shopping_list.ErrItemNotFound
+	}
+	if err != nil {
+		return shopping_list.Item{}, err
+	}
+
+	return row.item(), nil
+}
+
+func (r *ListStorageRepo) ItemExists(ctx context.Context, itemID uuid.UUID) (bool, error) {
+	var exists bool
+	// language=sql
+	err := r.conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM list_items WHERE id = $1)", itemID).Scan(&exists)
+	return exists, err
+}
+
+func (r *ListStorageRepo) OpenItemsByName(ctx context.Context, listID uuid.UUID, name string) ([]shopping_list.Item, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+` FROM list_items
+		WHERE list_id = $1 AND deleted_at IS NULL AND NOT checked AND lower(name) = lower($2)
+		ORDER BY position`,
+		listID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectItems(rows)
+}
+
+func (r *ListStorageRepo) OpenItemsByNames(ctx context.Context, listID uuid.UUID, names []string) ([]shopping_list.Item, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+` FROM list_items
+		WHERE list_id = $1 AND deleted_at IS NULL AND NOT checked
+			AND lower(name) IN (SELECT lower(n) FROM unnest($2::TEXT[]) AS n)
+		ORDER BY position`,
+		listID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectItems(rows)
+}
+
+// SimilarOpenItems matches names with pg_trgm's % operator, which can use the
+// trigram index on list_items, at a threshold set for the transaction.
+func (r *ListStorageRepo) SimilarOpenItems(ctx context.Context, listID uuid.UUID, name string, threshold float64) ([]shopping_list.Item, error) {
+	// language=sql
+	_, err := r.conn.Exec(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)", strconv.FormatFloat(threshold, 'f', -1, 64))
+	if err != nil {
+		return nil, err
+	}
+
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+` FROM list_items
+		WHERE list_id = $1 AND deleted_at IS NULL AND NOT checked AND lower(name) % lower($2)
+		ORDER BY similarity(lower(name), lower($2)) DESC`,
+		listID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectItems(rows)
+}
+
+func (r *ListStorageRepo) NextItemPosition(ctx context.Context, listID uuid.UUID) (int, error) {
+	var position int
+	// language=sql
+	err := r.conn.QueryRow(
+		ctx,
+		"SELECT COALESCE(MAX(position), -1) + 1 FROM list_items WHERE list_id = $1 AND deleted_at IS NULL",
+		listID).Scan(&position)
+	if err != nil {
+		return 0, err
+	}
+	return position, nil
+}
+
+func (r *ListStorageRepo) CreateItem(ctx context.Context, item shopping_list.Item) error {
+	claimedBy, assignedBy, claimedAt, expiresAt := claimColumns(item.Claim)
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO list_items (id, list_id, name, quantity_value, quantity_unit, note, category, checked, checked_at, position,
+			claimed_by, claim_assigned_by, claimed_at, claim_expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+		item.ID, item.ListID, item.Name, item.Quantity.Value, string(item.Quantity.Unit), item.Note, item.Category, item.Checked,
+		item.CheckedAt, item.Position, claimedBy, assignedBy, claimedAt, expiresAt, item.CreatedAt, item.UpdatedAt)
+	return err
+}
+
+// CreateItems copies the items in rather than inserting them one at a time,
+// which keeps large imports fast. The table's insert triggers still fire.
+func (r *ListStorageRepo) CreateItems(ctx context.Context, items []shopping_list.Item) error {
+	_, err := r.conn.CopyFrom(
+		ctx,
+		pgx.Identifier{"list_items"},
+		[]string{"id", "list_id", "name", "quantity_value", "quantity_unit", "note", "category", "checked", "checked_at",
+			"position", "claimed_by", "claim_assigned_by", "claimed_at", "claim_expires_at", "created_at", "updated_at"},
+		pgx.CopyFromSlice(len(items), func(i int) ([]any, error) {
+			item := items[i]
+			claimedBy, assignedBy, claimedAt, expiresAt := claimColumns(item.Claim)
+			return []any{item.ID, item.ListID, item.Name, item.Quantity.Value, string(item.Quantity.Unit), item.Note,
+				item.Category, item.Checked, item.CheckedAt, item.Position, claimedBy, assignedBy, claimedAt, expiresAt,
+				item.CreatedAt, item.UpdatedAt}, nil
+		}))
+	return err
+}
+
+func (r *ListStorageRepo) UpdateItem(ctx context.Context, item shopping_list.Item) error {
+	claimedBy, assignedBy, claimedAt, expiresAt := claimColumns(item.Claim)
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`UPDATE list_items SET name = $3, quantity_value = $4, quantity_unit = $5, note = $6, category = $7, checked = $8,
+		checked_at = $9, position = $10, claimed_by = $11, claim_assigned_by = $12, claimed_at = $13, claim_expires_at = $14,
+		updated_at = $15
+		WHERE list_id = $1 AND id = $2 AND deleted_at IS NULL`,
+		item.ListID, item.ID, item.Name, item.Quantity.Value, string(item.Quantity.Unit), item.Note, item.Category, item.Checked,
+		item.CheckedAt, item.Position, claimedBy, assignedBy, claimedAt, expiresAt, item.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (r *ListStorageRepo) DeleteItem(ctx context.Context, listID, itemID uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE list_items SET deleted_at = $3 WHERE list_id = $1 AND id = $2 AND deleted_at IS NULL",
+		listID, itemID, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrItemNotFound
+	}
+
+	return nil
+}
+
+func (r *ListStorageRepo) RestoreItem(ctx context.Context, listID, itemID uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE list_items SET deleted_at = NULL WHERE list_id = $1 AND id = $2 AND deleted_at IS NOT NULL",
+		listID, itemID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrItemNotFound
+	}
+
+	return nil
+}
+
+const itemColumns = `id, list_id, name, quantity_value, quantity_unit, note, category, checked, checked_at, position,
+	claimed_by, claim_assigned_by, claimed_at, claim_expires_at, created_at, updated_at`
+
+// itemRow is a list_items row; the item's quantity is stored in two columns
+// and its claim in four that are all NULL without one.
+type itemRow struct {
+	ID              uuid.UUID
+	ListID          uuid.UUID
+	Name            string
+	QuantityValue   float64
+	QuantityUnit    string
+	Note            string
+	Category        string
+	Checked         bool
+	CheckedAt       *time.Time
+	Position        int
+	ClaimedBy       *uuid.UUID
+	ClaimAssignedBy *uuid.UUID
+	ClaimedAt       *time.Time
+	ClaimExpiresAt  *time.Time
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+func (r itemRow) item() shopping_list.Item {
+	var claim *shopping_list.ItemClaim
+	if r.ClaimedBy != nil {
+		claim = &shopping_list.ItemClaim{
+			UserID:     *r.ClaimedBy,
+			AssignedBy: *r.ClaimAssignedBy,
+			ClaimedAt:  *r.ClaimedAt,
+			ExpiresAt:  *r.ClaimExpiresAt,
+		}
+	}
+
+	return shopping_list.Item{
+		ID:        r.ID,
+		ListID:    r.ListID,
+		Name:      r.Name,
+		Quantity:  quantity.New(r.QuantityValue, quantity.Unit(r.QuantityUnit)),
+		Note:      r.Note,
+		Category:  r.Category,
+		Checked:   r.Checked,
+		CheckedAt: r.CheckedAt,
+		Position:  r.Position,
+		Claim:     claim,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func claimColumns(claim *shopping_list.ItemClaim) (claimedBy, assignedBy *uuid.UUID, claimedAt, expiresAt *time.Time) {
+	if claim == nil {
+		return nil, nil, nil, nil
+	}
+	return &claim.UserID, &claim.AssignedBy, &claim.ClaimedAt, &claim.ExpiresAt
+}
+
+func collectItems(rows pgx.Rows) ([]shopping_list.Item, error) {
+	itemRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[itemRow])
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]shopping_list.Item, 0, len(itemRows))
+	for _, row := range itemRows {
+		items = append(items, row.item())
+	}
+	return items, nil
+}
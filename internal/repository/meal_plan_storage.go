@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type MealPlanStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewMealPlanStorageRepo(conn postgres.Querier) *MealPlanStorageRepo {
+	return &MealPlanStorageRepo{
+		conn: conn,
+	}
+}
+
+// mealPlanSelect reads entries together with the name of their recipe.
+const mealPlanSelect = `SELECT e.id, e.household_id, e.date, e.meal, e.recipe_id, r.name AS recipe_name, e.servings,
+	e.created_at, e.updated_at
+	FROM meal_plan_entries e JOIN recipes r ON r.id = e.recipe_id`
+
+func (r *MealPlanStorageRepo) HouseholdMealPlan(ctx context.Context, householdID uuid.UUID, from, to time.Time) ([]shopping_list.MealPlanEntry, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		mealPlanSelect+`
+		WHERE e.household_id = $1 AND e.date BETWEEN $2 AND $3
+		ORDER BY e.date, array_position(ARRAY['breakfast', 'lunch', 'dinner', 'snack'], e.meal), e.created_at`,
+		householdID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.MealPlanEntry])
+}
+
+func (r *MealPlanStorageRepo) GetMealPlanEntry(ctx context.Context, id uuid.UUID) (shopping_list.MealPlanEntry, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, mealPlanSelect+" WHERE e.id = $1", id)
+	if err != nil {
+		return shopping_list.MealPlanEntry{}, err
+	}
+	defer rows.Close()
+
+	entry, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.MealPlanEntry])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.MealPlanEntry{}, shopping_list.ErrMealPlanEntryNotFound
+	}
+
+	return entry, err
+}
+
+func (r *MealPlanStorageRepo) CreateMealPlanEntry(ctx context.Context, entry shopping_list.MealPlanEntry) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO meal_plan_entries (id, household_id, date, meal, recipe_id, servings, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		entry.ID, entry.HouseholdID, entry.Date, string(entry.Meal), entry.RecipeID, entry.Servings,
+		entry.CreatedAt, entry.UpdatedAt)
+	return err
+}
+
+func (r *MealPlanStorageRepo) UpdateMealPlanEntry(ctx context.Context, entry shopping_list.MealPlanEntry) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE meal_plan_entries SET date = $2, meal = $3, recipe_id = $4, servings = $5, updated_at = $6 WHERE id = $1",
+		entry.ID, entry.Date, string(entry.Meal), entry.RecipeID, entry.Servings, entry.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrMealPlanEntryNotFound
+	}
+
+	return nil
+}
+
+func (r *MealPlanStorageRepo) DeleteMealPlanEntry(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM meal_plan_entries WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrMealPlanEntryNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type PantryStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewPantryStorageRepo(conn postgres.Querier) *PantryStorageRepo {
+	return &PantryStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *PantryStorageRepo) HouseholdPantry(ctx context.Context, householdID uuid.UUID) ([]shopping_list.PantryItem, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT "+pantryColumns+" FROM pantry_items WHERE household_id = $1 ORDER BY lower(name)",
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPantryItems(rows)
+}
+
+func (r *PantryStorageRepo) ExpiringPantryItems(ctx context.Context, householdID uuid.UUID, before time.Time) ([]shopping_list.PantryItem, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+pantryColumns+` FROM pantry_items
+		WHERE household_id = $1 AND quantity_value > 0 AND expires_at < $2
+		ORDER BY expires_at`,
+		householdID, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPantryItems(rows)
+}
+
+func (r *PantryStorageRepo) GetPantryItem(ctx context.Context, id uuid.UUID) (shopping_list.PantryItem, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+pantryColumns+" FROM pantry_items WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.PantryItem{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[pantryRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.PantryItem{}, shopping_list.ErrPantryItemNotFound
+	}
+	if err != nil {
+		return shopping_list.PantryItem{}, err
+	}
+
+	return row.item(), nil
+}
+
+func (r *PantryStorageRepo) PantryItemsByName(ctx context.Context, householdID uuid.UUID, name string) ([]shopping_list.PantryItem, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT "+pantryColumns+" FROM pantry_items WHERE household_id = $1 AND lower(name) = lower($2) ORDER BY created_at",
+		householdID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPantryItems(rows)
+}
+
+func (r *PantryStorageRepo) CreatePantryItem(ctx context.Context, item shopping_list.PantryItem) error {
+	thresholdValue, thresholdUnit := thresholdColumns(item.Threshold)
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO pantry_items (id, household_id, name, quantity_value, quantity_unit, category, threshold_value,
+			threshold_unit, expires_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		item.ID, item.HouseholdID, item.Name, item.Quantity.Value, string(item.Quantity.Unit), item.Category, thresholdValue,
+		thresholdUnit, item.ExpiresAt, item.CreatedAt, item.UpdatedAt)
+	return err
+}
+
+func (r *PantryStorageRepo) UpdatePantryItem(ctx context.Context, item shopping_list.PantryItem) error {
+	thresholdValue, thresholdUnit := thresholdColumns(item.Threshold)
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`UPDATE pantry_items SET name = $2, quantity_value = $3, quantity_unit = $4, category = $5, threshold_value = $6,
+		threshold_unit = $7, expires_at = $8, updated_at = $9
+		WHERE id = $1`,
+		item.ID, item.Name, item.Quantity.Value, string(item.Quantity.Unit), item.Category, thresholdValue, thresholdUnit,
+		item.ExpiresAt, item.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrPantryItemNotFound
+	}
+
+	return nil
+}
+
+func (r *PantryStorageRepo) DeletePantryItem(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM pantry_items WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrPantryItemNotFound
+	}
+
+	return nil
+}
+
+const pantryColumns = `id, household_id, name, quantity_value, quantity_unit, category, threshold_value, threshold_unit,
+	expires_at, created_at, updated_at`
+
+// pantryRow is a pantry_items row; quantity and threshold are stored in two columns each.
+type pantryRow struct {
+	ID             uuid.UUID
+	HouseholdID    uuid.UUID
+	Name           string
+	QuantityValue  float64
+	QuantityUnit   string
+	Category       string
+	ThresholdValue *float64
+	ThresholdUnit  *string
+	ExpiresAt      *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func (r pantryRow) item() shopping_list.PantryItem {
+	item := shopping_list.PantryItem{
+		ID:          r.ID,
+		HouseholdID: r.HouseholdID,
+		Name:        r.Name,
+		Quantity:    quantity.New(r.QuantityValue, quantity.Unit(r.QuantityUnit)),
+		Category:    r.Category,
+		ExpiresAt:   r.ExpiresAt,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+	if r.ThresholdValue != nil && r.ThresholdUnit != nil {
+		threshold := quantity.New(*r.ThresholdValue, quantity.Unit(*r.ThresholdUnit))
+		item.Threshold = &threshold
+	}
+	return item
+}
+
+func collectPantryItems(rows pgx.Rows) ([]shopping_list.PantryItem, error) {
+	pantryRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[pantryRow])
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]shopping_list.PantryItem, 0, len(pantryRows))
+	for _, row := range pantryRows {
+		items = append(items, row.item())
+	}
+	return items, nil
+}
+
+func thresholdColumns(threshold *quantity.Quantity) (*float64, *string) {
+	if threshold == nil {
+		return nil, nil
+	}
+	unit := string(threshold.Unit)
+	return &threshold.Value, &unit
+}
@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type PriceStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewPriceStorageRepo(conn postgres.Querier) *PriceStorageRepo {
+	return &PriceStorageRepo{
+		conn: conn,
+	}
+}
+
+// priceSelect reads prices together with the name of their store.
+const priceSelect = `SELECT p.id, p.household_id, p.store_id, s.name AS store_name, p.name, p.quantity_value,
+	p.quantity_unit, p.category, p.amount, p.currency, p.paid_at, p.created_at
+	FROM prices p JOIN stores s ON s.id = p.store_id`
+
+func (r *PriceStorageRepo) RecordPrice(ctx context.Context, price shopping_list.Price) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO prices (id, household_id, store_id, name, quantity_value, quantity_unit, category, amount, currency,
+			paid_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		price.ID, price.HouseholdID, price.StoreID, price.Name, price.Quantity.Value, string(price.Quantity.Unit),
+		price.Category, price.Amount, price.Currency, price.PaidAt, price.CreatedAt)
+	return err
+}
+
+func (r *PriceStorageRepo) PriceHistory(ctx context.Context, householdID uuid.UUID, name string) ([]shopping_list.Price, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		priceSelect+" WHERE p.household_id = $1 AND lower(p.name) = lower($2) ORDER BY p.paid_at DESC, p.created_at DESC",
+		householdID, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPrices(rows)
+}
+
+func (r *PriceStorageRepo) LatestPrices(ctx context.Context, storeID uuid.UUID, names []string) ([]shopping_list.Price, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT * FROM (
+			SELECT DISTINCT ON (lower(p.name), p.quantity_unit) p.id, p.household_id, p.store_id, s.name AS store_name,
+				p.name, p.quantity_value, p.quantity_unit, p.category, p.amount, p.currency, p.paid_at, p.created_at
+			FROM prices p JOIN stores s ON s.id = p.store_id
+			WHERE p.store_id = $1 AND lower(p.name) IN (SELECT lower(n) FROM unnest($2::TEXT[]) AS n)
+			ORDER BY lower(p.name), p.quantity_unit, p.paid_at DESC, p.created_at DESC
+		) latest
+		ORDER BY paid_at DESC, created_at DESC`,
+		storeID, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return collectPrices(rows)
+}
+
+func (r *PriceStorageRepo) Spend(ctx context.Context, householdID uuid.UUID, category, currency string, from, to time.Time) (int64, error) {
+	var spent int64
+	// language=sql
+	err := r.conn.QueryRow(
+		ctx,
+		`SELECT
+			(SELECT COALESCE(SUM(amount), 0) FROM prices
+			WHERE household_id = $1 AND ($2 = '' OR category = $2) AND currency = $3 AND paid_at >= $4 AND paid_at < $5) +
+			(SELECT COALESCE(SUM(unitemized), 0) FROM trips
+			WHERE household_id = $1 AND $2 = '' AND currency = $3 AND finished_at >= $4 AND finished_at < $5
+				AND unitemized IS NOT NULL)`,
+		householdID, category, currency, from, to).Scan(&spent)
+	return spent, err
+}
+
+// priceRow is a prices row; the quantity paid for is stored in two columns.
+type priceRow struct {
+	ID            uuid.UUID
+	HouseholdID   uuid.UUID
+	StoreID       uuid.UUID
+	StoreName     string
+	Name          string
+	QuantityValue float64
+	QuantityUnit  string
+	Category      string
+	Amount        int64
+	Currency      string
+	PaidAt        time.Time
+	CreatedAt     time.Time
+}
+
+func (r priceRow) price() shopping_list.Price {
+	return shopping_list.Price{
+		ID:          r.ID,
+		HouseholdID: r.HouseholdID,
+		StoreID:     r.StoreID,
+		StoreName:   r.StoreName,
+		Name:        r.Name,
+		Quantity:    quantity.New(r.QuantityValue, quantity.Unit(r.QuantityUnit)),
+		Category:    r.Category,
+		Amount:      r.Amount,
+		Currency:    r.Currency,
+		PaidAt:      r.PaidAt,
+		CreatedAt:   r.CreatedAt,
+	}
+}
+
+func collectPrices(rows pgx.Rows) ([]shopping_list.Price, error) {
+	priceRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[priceRow])
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]shopping_list.Price, 0, len(priceRows))
+	for _, row := range priceRows {
+		prices = append(prices, row.price())
+	}
+	return prices, nil
+}
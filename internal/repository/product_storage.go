@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type ProductStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewProductStorageRepo(conn postgres.Querier) *ProductStorageRepo {
+	return &ProductStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *ProductStorageRepo) GetProduct(ctx context.Context, gtin string) (shopping_list.Product, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+productColumns+" FROM products WHERE gtin = $1", gtin)
+	if err != nil {
+		return shopping_list.Product{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[productRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Product{}, shopping_list.ErrProductNotFound
+	}
+	if err != nil {
+		return shopping_list.Product{}, err
+	}
+
+	return row.product(), nil
+}
+
+// ImportProducts inserts the products in one statement, replacing catalog
+// entries with the same GTIN. The GTINs must be distinct.
+func (r *ProductStorageRepo) ImportProducts(ctx context.Context, products []shopping_list.Product, at time.Time) error {
+	gtins := make([]string, len(products))
+	names := make([]string, len(products))
+	brands := make([]string, len(products))
+	categories := make([]string, len(products))
+	values := make([]float64, len(products))
+	units := make([]string, len(products))
+	for i, product := range products {
+		gtins[i] = product.GTIN
+		names[i] = product.Name
+		brands[i] = product.Brand
+		categories[i] = product.Category
+		values[i] = product.Quantity.Value
+		units[i] = string(product.Quantity.Unit)
+	}
+
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO products (gtin, name, brand, category, quantity_value, quantity_unit, created_at, updated_at)
+		SELECT gtin, name, brand, category, quantity_value, quantity_unit, $7, $7
+		FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::numeric[], $6::text[])
+			AS p (gtin, name, brand, category, quantity_value, quantity_unit)
+		ON CONFLICT (gtin) DO UPDATE SET
+			name = excluded.name,
+			brand = excluded.brand,
+			category = excluded.category,
+			quantity_value = excluded.quantity_value,
+			quantity_unit = excluded.quantity_unit,
+			updated_at = excluded.updated_at`,
+		gtins, names, brands, categories, values, units, at)
+	return err
+}
+
+const productColumns = "gtin, name, brand, category, quantity_value, quantity_unit, created_at, updated_at"
+
+// productRow is a products row; the quantity is stored in two columns.
+type productRow struct {
+	GTIN          string
+	Name          string
+	Brand         string
+	Category      string
+	QuantityValue float64
+	QuantityUnit  string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+func (r productRow) product() shopping_list.Product {
+	return shopping_list.Product{
+		GTIN:      r.GTIN,
+		Name:      r.Name,
+		Brand:     r.Brand,
+		Category:  r.Category,
+		Quantity:  quantity.New(r.QuantityValue, quantity.Unit(r.QuantityUnit)),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type RecipeStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewRecipeStorageRepo(conn postgres.Querier) *RecipeStorageRepo {
+	return &RecipeStorageRepo{
+		conn: conn,
+	}
+}
+
+const recipeColumns = "id, household_id, name, servings, ingredients, instructions, created_at, updated_at"
+
+func (r *RecipeStorageRepo) HouseholdRecipes(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Recipe, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+recipeColumns+" FROM recipes WHERE household_id = $1 ORDER BY name", householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Recipe])
+}
+
+func (r *RecipeStorageRepo) GetRecipe(ctx context.Context, id uuid.UUID) (shopping_list.Recipe, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+recipeColumns+" FROM recipes WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.Recipe{}, err
+	}
+	defer rows.Close()
+
+	recipe, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Recipe])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Recipe{}, shopping_list.ErrRecipeNotFound
+	}
+
+	return recipe, err
+}
+
+func (r *RecipeStorageRepo) CreateRecipe(ctx context.Context, recipe shopping_list.Recipe) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO recipes (id, household_id, name, servings, ingredients, instructions, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		recipe.ID, recipe.HouseholdID, recipe.Name, recipe.Servings, recipe.Ingredients, recipe.Instructions,
+		recipe.CreatedAt, recipe.UpdatedAt)
+	return err
+}
+
+func (r *RecipeStorageRepo) UpdateRecipe(ctx context.Context, recipe shopping_list.Recipe) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE recipes SET name = $2, servings = $3, ingredients = $4, instructions = $5, updated_at = $6 WHERE id = $1",
+		recipe.ID, recipe.Name, recipe.Servings, recipe.Ingredients, recipe.Instructions, recipe.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrRecipeNotFound
+	}
+
+	return nil
+}
+
+func (r *RecipeStorageRepo) DeleteRecipe(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM recipes WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrRecipeNotFound
+	}
+
+	return nil
+}
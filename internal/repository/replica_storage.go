@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/crdt"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type ReplicaStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewReplicaStorageRepo(conn postgres.Querier) *ReplicaStorageRepo {
+	return &ReplicaStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *ReplicaStorageRepo) GetListReplica(ctx context.Context, listID uuid.UUID) (shopping_list.ListReplica, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT l.id AS list_id, r.document, r.version, r.updated_at
+		FROM shopping_lists l
+		LEFT JOIN list_replicas r ON r.list_id = l.id
+		WHERE l.id = $1 AND l.deleted_at IS NULL
+		FOR UPDATE OF l`,
+		listID)
+	if err != nil {
+		return shopping_list.ListReplica{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[replicaRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.ListReplica{}, shopping_list.ErrListNotFound
+	}
+	if err != nil {
+		return shopping_list.ListReplica{}, err
+	}
+
+	return row.replica(), nil
+}
+
+func (r *ReplicaStorageRepo) SaveListReplica(ctx context.Context, replica shopping_list.ListReplica) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO list_replicas (list_id, document, version, updated_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (list_id) DO UPDATE SET
+			document = EXCLUDED.document, version = EXCLUDED.version, updated_at = EXCLUDED.updated_at`,
+		replica.ListID, replica.Document, replica.Version, replica.UpdatedAt)
+	return err
+}
+
+// replicaRow has nullable columns for lists that were never synced.
+type replicaRow struct {
+	ListID    uuid.UUID
+	Document  *crdt.Document
+	Version   *int64
+	UpdatedAt *time.Time
+}
+
+func (row replicaRow) replica() shopping_list.ListReplica {
+	replica := shopping_list.ListReplica{ListID: row.ListID, Document: row.Document}
+	if row.Version != nil {
+		replica.Version = *row.Version
+	}
+	if row.UpdatedAt != nil {
+		replica.UpdatedAt = *row.UpdatedAt
+	}
+	return replica
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"html"
+	"strings"
+)
+
+// searchTrigramThreshold is the name similarity at which a row matches a
+// query its words do not, such as a misspelled one.
+const searchTrigramThreshold = "0.3"
+
+// Highlights come back from the database with matched words between two
+// private use characters, which are taken out of the text beforehand, so that
+// the text can be escaped before they are turned into <mark> tags.
+const (
+	headlineStart = "\ue000"
+	headlineStop  = "\ue001"
+)
+
+// headlineOptions marks matched words and keeps long texts such as recipe
+// instructions to a couple of fragments.
+const headlineOptions = "StartSel=" + headlineStart + ", StopSel=" + headlineStop + ", MaxWords=20, MinWords=5, MaxFragments=2"
+
+var headlineMarks = strings.NewReplacer(headlineStart, "<mark>", headlineStop, "</mark>")
+
+type SearchStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewSearchStorageRepo(conn postgres.Querier) *SearchStorageRepo {
+	return &SearchStorageRepo{
+		conn: conn,
+	}
+}
+
+// Search ranks rows by how well their words match, from the tsvectors the
+// triggers keep, plus how similar their names are to the query. Highlights
+// are read with the first of the query's configurations.
+func (r *SearchStorageRepo) Search(ctx context.Context, userID uuid.UUID, query shopping_list.SearchQuery) (shopping_list.SearchResults, error) {
+	// language=sql
+	_, err := r.conn.Exec(ctx, "SELECT set_config('pg_trgm.similarity_threshold', $1, true)", searchTrigramThreshold)
+	if err != nil {
+		return shopping_list.SearchResults{}, err
+	}
+
+	var results shopping_list.SearchResults
+	// language=sql
+	results.Lists, err = r.search(
+		ctx,
+		`WITH q AS (SELECT search_query($2::text[]::regconfig[], $3) AS query)
+		SELECT l.id::text AS id, l.name AS title,
+			ts_headline(($2::text[])[1]::regconfig, translate(l.name, $6, ''), q.query, $5) AS highlight,
+			ts_rank(l.search_vector, q.query) + similarity(lower(l.name), lower($3)) AS rank,
+			NULL::uuid AS list_id, l.household_id
+		FROM shopping_lists l
+		CROSS JOIN q
+		JOIN household_members m ON m.household_id = l.household_id
+		WHERE m.user_id = $1 AND l.deleted_at IS NULL AND (l.search_vector @@ q.query OR lower(l.name) % lower($3))
+		ORDER BY rank DESC, l.name
+		LIMIT $4`,
+		userID, query)
+	if err != nil {
+		return shopping_list.SearchResults{}, err
+	}
+
+	// language=sql
+	results.Items, err = r.search(
+		ctx,
+		`WITH q AS (SELECT search_query($2::text[]::regconfig[], $3) AS query)
+		SELECT i.id::text AS id, i.name AS title,
+			ts_headline(($2::text[])[1]::regconfig, translate(concat_ws(' · ', i.name, nullif(i.note, '')), $6, ''), q.query, $5) AS highlight,
+			ts_rank(i.search_vector, q.query) + similarity(lower(i.name), lower($3)) AS rank,
+			i.list_id, l.household_id
+		FROM list_items i
+		CROSS JOIN q
+		JOIN shopping_lists l ON l.id = i.list_id
+		JOIN household_members m ON m.household_id = l.household_id
+		WHERE m.user_id = $1 AND i.deleted_at IS NULL AND l.deleted_at IS NULL
+			AND (i.search_vector @@ q.query OR lower(i.name) % lower($3))
+		ORDER BY rank DESC, i.name
+		LIMIT $4`,
+		userID, query)
+	if err != nil {
+		return shopping_list.SearchResults{}, err
+	}
+
+	// language=sql
+	results.Recipes, err = r.search(
+		ctx,
+		`WITH q AS (SELECT search_query($2::text[]::regconfig[], $3) AS query)
+		SELECT r.id::text AS id, r.name AS title,
+			ts_headline(
+				($2::text[])[1]::regconfig,
+				translate(concat_ws(' · ', r.name,
+					(SELECT string_agg(i->>'name', ', ') FROM jsonb_array_elements(r.ingredients) i),
+					nullif(r.instructions, '')), $6, ''),
+				q.query, $5) AS highlight,
+			ts_rank(r.search_vector, q.query) + similarity(lower(r.name), lower($3)) AS rank,
+			NULL::uuid AS list_id, r.household_id
+		FROM recipes r
+		CROSS JOIN q
+		JOIN household_members m ON m.household_id = r.household_id
+		WHERE m.user_id = $1 AND (r.search_vector @@ q.query OR lower(r.name) % lower($3))
+		ORDER BY rank DESC, r.name
+		LIMIT $4`,
+		userID, query)
+	if err != nil {
+		return shopping_list.SearchResults{}, err
+	}
+
+	// The catalog is the same for everyone; the user is only named so that
+	// the query takes the same parameters as the others.
+	// language=sql
+	results.Products, err = r.search(
+		ctx,
+		`WITH q AS (SELECT search_query($2::text[]::regconfig[], $3) AS query)
+		SELECT p.gtin AS id, p.name AS title,
+			ts_headline(($2::text[])[1]::regconfig, translate(concat_ws(' · ', p.name, nullif(p.brand, '')), $6, ''), q.query, $5) AS highlight,
+			ts_rank(p.search_vector, q.query) + similarity(lower(p.name), lower($3)) AS rank,
+			NULL::uuid AS list_id, NULL::uuid AS household_id
+		FROM products p
+		CROSS JOIN q
+		WHERE $1::uuid IS NOT NULL AND (p.search_vector @@ q.query OR lower(p.name) % lower($3))
+		ORDER BY rank DESC, p.name
+		LIMIT $4`,
+		userID, query)
+	if err != nil {
+		return shopping_list.SearchResults{}, err
+	}
+
+	return results, nil
+}
+
+// search runs one of Search's queries, which all take the same parameters.
+func (r *SearchStorageRepo) search(ctx context.Context, sql string, userID uuid.UUID, query shopping_list.SearchQuery) ([]shopping_list.SearchHit, error) {
+	rows, err := r.conn.Query(ctx, sql, userID, query.Languages, query.Text, query.Limit, headlineOptions, headlineStart+headlineStop)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hits, err := pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.SearchHit])
+	if err != nil {
+		return nil, err
+	}
+	for i := range hits {
+		hits[i].Highlight = markHighlight(hits[i].Highlight)
+	}
+	return hits, nil
+}
+
+// markHighlight escapes a highlight read from the database, which holds the
+// text as the user wrote it, and wraps its matched words in <mark> tags.
+func markHighlight(headline string) string {
+	return headlineMarks.Replace(html.EscapeString(headline))
+}
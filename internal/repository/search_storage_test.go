@@ -0,0 +1,20 @@
+package repository
+
+import "testing"
+
+func TestMarkHighlight(t *testing.T) {
+	mark := func(word string) string { return headlineStart + word + headlineStop }
+	cases := []struct {
+		headline string
+		want     string
+	}{
+		{mark("Milk") + " · semi-skimmed", "<mark>Milk</mark> · semi-skimmed"},
+		{"<img src=x onerror=alert(1)> " + mark("milk"), "&lt;img src=x onerror=alert(1)&gt; <mark>milk</mark>"},
+		{mark("<b>Milk</b>") + ` & "bread"`, "<mark>&lt;b&gt;Milk&lt;/b&gt;</mark> &amp; &#34;bread&#34;"},
+	}
+	for _, tc := range cases {
+		if got := markHighlight(tc.headline); got != tc.want {
+			t.Errorf("markHighlight(%q) = %q, want %q", tc.headline, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+type StoreStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewStoreStorageRepo(conn postgres.Querier) *StoreStorageRepo {
+	return &StoreStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *StoreStorageRepo) HouseholdStores(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Store, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT id, household_id, name, sections, created_at, updated_at FROM stores WHERE household_id = $1 ORDER BY name",
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Store])
+}
+
+func (r *StoreStorageRepo) GetStore(ctx context.Context, id uuid.UUID) (shopping_list.Store, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT id, household_id, name, sections, created_at, updated_at FROM stores WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.Store{}, err
+	}
+	defer rows.Close()
+
+	store, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Store])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Store{}, shopping_list.ErrStoreNotFound
+	}
+
+	return store, err
+}
+
+func (r *StoreStorageRepo) CreateStore(ctx context.Context, store shopping_list.Store) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		"INSERT INTO stores (id, household_id, name, sections, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		store.ID, store.HouseholdID, store.Name, store.Sections, store.CreatedAt, store.UpdatedAt)
+	return err
+}
+
+func (r *StoreStorageRepo) UpdateStore(ctx context.Context, store shopping_list.Store) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE stores SET name = $2, sections = $3, updated_at = $4 WHERE id = $1",
+		store.ID, store.Name, store.Sections, store.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrStoreNotFound
+	}
+
+	return nil
+}
+
+func (r *StoreStorageRepo) DeleteStore(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM stores WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrStoreNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type SuggestionStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewSuggestionStorageRepo(conn postgres.Querier) *SuggestionStorageRepo {
+	return &SuggestionStorageRepo{
+		conn: conn,
+	}
+}
+
+// Purchases reads check-offs from the lists' history rather than the items,
+// which are unchecked and checked off again as they are bought. Checking off
+// an item again by undoing its unchecking is not a new purchase. Lists in the
+// trash are left out like their items are.
+func (r *SuggestionStorageRepo) Purchases(ctx context.Context, householdID uuid.UUID, since time.Time) ([]shopping_list.Purchase, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT e.item_after->>'name' AS name, e.item_after->>'category' AS category, e.occurred_at AS checked_at
+		FROM list_events e
+		JOIN shopping_lists l ON l.id = e.list_id
+		WHERE l.household_id = $1 AND l.deleted_at IS NULL AND e.type = 'item_checked' AND e.reverts IS NULL AND e.occurred_at >= $2
+			AND NOT EXISTS (SELECT 1 FROM list_events r WHERE r.reverts = e.id)
+		ORDER BY e.occurred_at`,
+		householdID, since)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Purchase])
+}
+
+func (r *SuggestionStorageRepo) OpenItemNames(ctx context.Context, householdID uuid.UUID) ([]string, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT name FROM list_items
+		WHERE deleted_at IS NULL AND NOT checked
+			AND list_id IN (SELECT id FROM shopping_lists WHERE household_id = $1 AND deleted_at IS NULL)`,
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type TemplateStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewTemplateStorageRepo(conn postgres.Querier) *TemplateStorageRepo {
+	return &TemplateStorageRepo{
+		conn: conn,
+	}
+}
+
+const templateColumns = "id, household_id, name, items, cadence, list_id, next_run_at, created_at, updated_at"
+
+func (r *TemplateStorageRepo) HouseholdTemplates(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Template, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+templateColumns+" FROM templates WHERE household_id = $1 ORDER BY name", householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Template])
+}
+
+func (r *TemplateStorageRepo) GetTemplate(ctx context.Context, id uuid.UUID) (shopping_list.Template, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+templateColumns+" FROM templates WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.Template{}, err
+	}
+	defer rows.Close()
+
+	return collectTemplate(rows)
+}
+
+func (r *TemplateStorageRepo) CreateTemplate(ctx context.Context, template shopping_list.Template) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO templates (id, household_id, name, items, cadence, list_id, next_run_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		template.ID, template.HouseholdID, template.Name, template.Items, template.Cadence, template.ListID,
+		template.NextRunAt, template.CreatedAt, template.UpdatedAt)
+	return err
+}
+
+func (r *TemplateStorageRepo) UpdateTemplate(ctx context.Context, template shopping_list.Template) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`UPDATE templates SET name = $2, items = $3, cadence = $4, list_id = $5, next_run_at = $6, updated_at = $7
+		WHERE id = $1`,
+		template.ID, template.Name, template.Items, template.Cadence, template.ListID, template.NextRunAt, template.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *TemplateStorageRepo) DeleteTemplate(ctx context.Context, id uuid.UUID) error {
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM templates WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func (r *TemplateStorageRepo) NextDueTemplate(ctx context.Context, now time.Time) (shopping_list.Template, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+templateColumns+` FROM templates
+		WHERE next_run_at <= $1
+		ORDER BY next_run_at
+		LIMIT 1
+		FOR UPDATE SKIP LOCKED`,
+		now)
+	if err != nil {
+		return shopping_list.Template{}, err
+	}
+	defer rows.Close()
+
+	return collectTemplate(rows)
+}
+
+func (r *TemplateStorageRepo) SetNextRun(ctx context.Context, id uuid.UUID, next time.Time) error {
+	// language=sql
+	_, err := r.conn.Exec(ctx, "UPDATE templates SET next_run_at = $2 WHERE id = $1", id, next)
+	return err
+}
+
+func collectTemplate(rows pgx.Rows) (shopping_list.Template, error) {
+	template, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Template])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Template{}, shopping_list.ErrTemplateNotFound
+	}
+
+	return template, err
+}
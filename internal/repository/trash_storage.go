@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type TrashStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewTrashStorageRepo(conn postgres.Querier) *TrashStorageRepo {
+	return &TrashStorageRepo{
+		conn: conn,
+	}
+}
+
+func (r *TrashStorageRepo) TrashedLists(ctx context.Context, householdID uuid.UUID) ([]shopping_list.TrashedList, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT id, household_id, name, created_at, updated_at, deleted_at FROM shopping_lists
+		WHERE household_id = $1 AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC`,
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.TrashedList])
+}
+
+func (r *TrashStorageRepo) TrashedItems(ctx context.Context, householdID uuid.UUID) ([]shopping_list.TrashedItem, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+`, deleted_at FROM list_items
+		WHERE deleted_at IS NOT NULL
+			AND list_id IN (SELECT id FROM shopping_lists WHERE household_id = $1 AND deleted_at IS NULL)
+		ORDER BY deleted_at DESC`,
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	itemRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[trashedItemRow])
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]shopping_list.TrashedItem, 0, len(itemRows))
+	for _, row := range itemRows {
+		items = append(items, row.trashedItem())
+	}
+	return items, nil
+}
+
+func (r *TrashStorageRepo) GetTrashedList(ctx context.Context, householdID, id uuid.UUID) (shopping_list.TrashedList, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT id, household_id, name, created_at, updated_at, deleted_at FROM shopping_lists
+		WHERE household_id = $1 AND id = $2 AND deleted_at IS NOT NULL`,
+		householdID, id)
+	if err != nil {
+		return shopping_list.TrashedList{}, err
+	}
+	defer rows.Close()
+
+	list, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.TrashedList])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.TrashedList{}, shopping_list.ErrListNotFound
+	}
+
+	return list, err
+}
+
+func (r *TrashStorageRepo) GetTrashedItem(ctx context.Context, householdID, id uuid.UUID) (shopping_list.TrashedItem, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT `+itemColumns+`, deleted_at FROM list_items
+		WHERE id = $2 AND deleted_at IS NOT NULL
+			AND list_id IN (SELECT id FROM shopping_lists WHERE household_id = $1 AND deleted_at IS NULL)`,
+		householdID, id)
+	if err != nil {
+		return shopping_list.TrashedItem{}, err
+	}
+	defer rows.Close()
+
+	row, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[trashedItemRow])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.TrashedItem{}, shopping_list.ErrItemNotFound
+	}
+	if err != nil {
+		return shopping_list.TrashedItem{}, err
+	}
+
+	return row.trashedItem(), nil
+}
+
+func (r *TrashStorageRepo) EmptyTrash(ctx context.Context, householdID uuid.UUID) (shopping_list.EmptiedTrash, error) {
+	var emptied shopping_list.EmptiedTrash
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		`DELETE FROM list_items
+		WHERE deleted_at IS NOT NULL AND list_id IN (SELECT id FROM shopping_lists WHERE household_id = $1)`,
+		householdID)
+	if err != nil {
+		return shopping_list.EmptiedTrash{}, err
+	}
+	emptied.Items = tag.RowsAffected()
+
+	// language=sql
+	tag, err = r.conn.Exec(ctx, "DELETE FROM shopping_lists WHERE household_id = $1 AND deleted_at IS NOT NULL", householdID)
+	if err != nil {
+		return shopping_list.EmptiedTrash{}, err
+	}
+	emptied.Lists = tag.RowsAffected()
+
+	return emptied, nil
+}
+
+// PurgeTrash deletes for good what went to the trash before the given time,
+// with the items of the lists among it.
+func (r *TrashStorageRepo) PurgeTrash(ctx context.Context, before time.Time) (shopping_list.EmptiedTrash, error) {
+	var purged shopping_list.EmptiedTrash
+	// language=sql
+	tag, err := r.conn.Exec(ctx, "DELETE FROM list_items WHERE deleted_at < $1", before)
+	if err != nil {
+		return shopping_list.EmptiedTrash{}, err
+	}
+	purged.Items = tag.RowsAffected()
+
+	// language=sql
+	tag, err = r.conn.Exec(ctx, "DELETE FROM shopping_lists WHERE deleted_at < $1", before)
+	if err != nil {
+		return shopping_list.EmptiedTrash{}, err
+	}
+	purged.Lists = tag.RowsAffected()
+
+	return purged, nil
+}
+
+type trashedItemRow struct {
+	itemRow
+	DeletedAt time.Time
+}
+
+func (r trashedItemRow) trashedItem() shopping_list.TrashedItem {
+	return shopping_list.TrashedItem{Item: r.item(), DeletedAt: r.DeletedAt}
+}
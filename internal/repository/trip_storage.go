@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	shopping_list "github.com/PocketPalCo/shopping-service/internal/core/shopping-list"
+	"github.com/PocketPalCo/shopping-service/internal/infra/postgres"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"time"
+)
+
+type TripStorageRepo struct {
+	conn postgres.Querier
+}
+
+func NewTripStorageRepo(conn postgres.Querier) *TripStorageRepo {
+	return &TripStorageRepo{
+		conn: conn,
+	}
+}
+
+const tripColumns = "id, household_id, store_id, list_ids, started_by, status, currency, spent, unitemized, started_at, finished_at"
+
+func (r *TripStorageRepo) GetTrip(ctx context.Context, id uuid.UUID) (shopping_list.Trip, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+tripColumns+" FROM trips WHERE id = $1", id)
+	if err != nil {
+		return shopping_list.Trip{}, err
+	}
+	defer rows.Close()
+
+	trip, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Trip])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Trip{}, shopping_list.ErrTripNotFound
+	}
+	if err != nil {
+		return shopping_list.Trip{}, err
+	}
+
+	if trip.Timeline, err = r.tripEntries(ctx, trip.ID); err != nil {
+		return shopping_list.Trip{}, err
+	}
+	return trip, nil
+}
+
+func (r *TripStorageRepo) tripEntries(ctx context.Context, tripID uuid.UUID) ([]shopping_list.TripEntry, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		`SELECT id, trip_id, kind, item_id, list_id, name, quantity_value, quantity_unit, category, amount, actor_id, occurred_at
+		FROM trip_entries WHERE trip_id = $1 ORDER BY occurred_at`,
+		tripID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entryRows, err := pgx.CollectRows(rows, pgx.RowToStructByName[tripEntryRow])
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]shopping_list.TripEntry, 0, len(entryRows))
+	for _, row := range entryRows {
+		entries = append(entries, row.entry())
+	}
+	return entries, nil
+}
+
+func (r *TripStorageRepo) ActiveTrips(ctx context.Context, householdID uuid.UUID) ([]shopping_list.Trip, error) {
+	// language=sql
+	rows, err := r.conn.Query(
+		ctx,
+		"SELECT "+tripColumns+" FROM trips WHERE household_id = $1 AND status = 'active' ORDER BY started_at DESC",
+		householdID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return pgx.CollectRows(rows, pgx.RowToStructByName[shopping_list.Trip])
+}
+
+func (r *TripStorageRepo) UserActiveTrip(ctx context.Context, userID uuid.UUID) (shopping_list.Trip, error) {
+	// language=sql
+	rows, err := r.conn.Query(ctx, "SELECT "+tripColumns+" FROM trips WHERE started_by = $1 AND status = 'active'", userID)
+	if err != nil {
+		return shopping_list.Trip{}, err
+	}
+	defer rows.Close()
+
+	trip, err := pgx.CollectExactlyOneRow(rows, pgx.RowToStructByName[shopping_list.Trip])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return shopping_list.Trip{}, shopping_list.ErrTripNotFound
+	}
+
+	return trip, err
+}
+
+func (r *TripStorageRepo) CreateTrip(ctx context.Context, trip shopping_list.Trip) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO trips (id, household_id, store_id, list_ids, started_by, status, currency, started_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		trip.ID, trip.HouseholdID, trip.StoreID, trip.ListIDs, trip.StartedBy, string(trip.Status), trip.Currency, trip.StartedAt)
+	return err
+}
+
+func (r *TripStorageRepo) FinishTrip(ctx context.Context, trip shopping_list.Trip) error {
+	// language=sql
+	tag, err := r.conn.Exec(
+		ctx,
+		"UPDATE trips SET status = $2, spent = $3, unitemized = $4, finished_at = $5 WHERE id = $1",
+		trip.ID, string(trip.Status), trip.Spent, trip.Unitemized, trip.FinishedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shopping_list.ErrTripNotFound
+	}
+
+	return nil
+}
+
+func (r *TripStorageRepo) AddTripEntry(ctx context.Context, entry shopping_list.TripEntry) error {
+	// language=sql
+	_, err := r.conn.Exec(
+		ctx,
+		`INSERT INTO trip_entries (id, trip_id, kind, item_id, list_id, name, quantity_value, quantity_unit, category, amount,
+			actor_id, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)`,
+		entry.ID, entry.TripID, string(entry.Kind), entry.ItemID, entry.ListID, entry.Name, entry.Quantity.Value,
+		string(entry.Quantity.Unit), entry.Category, entry.Amount, entry.ActorID, entry.OccurredAt)
+	return err
+}
+
+// tripEntryRow is a trip_entries row; the item's quantity is stored in two columns.
+type tripEntryRow struct {
+	ID            uuid.UUID
+	TripID        uuid.UUID
+	Kind          string
+	ItemID        uuid.UUID
+	ListID        uuid.UUID
+	Name          string
+	QuantityValue float64
+	QuantityUnit  string
+	Category      string
+	Amount        *int64
+	ActorID       uuid.UUID
+	OccurredAt    time.Time
+}
+
+func (r tripEntryRow) entry() shopping_list.TripEntry {
+	return shopping_list.TripEntry{
+		ID:         r.ID,
+		TripID:     r.TripID,
+		Kind:       shopping_list.TripEntryKind(r.Kind),
+		ItemID:     r.ItemID,
+		ListID:     r.ListID,
+		Name:       r.Name,
+		Quantity:   quantity.New(r.QuantityValue, quantity.Unit(r.QuantityUnit)),
+		Category:   r.Category,
+		Amount:     r.Amount,
+		ActorID:    r.ActorID,
+		OccurredAt: r.OccurredAt,
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS list_items;
+DROP TABLE IF EXISTS shopping_lists;
@@ -0,0 +1,20 @@
+CREATE TABLE shopping_lists (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE list_items (
+    id UUID PRIMARY KEY,
+    list_id UUID NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    quantity TEXT NOT NULL DEFAULT '',
+    checked BOOLEAN NOT NULL DEFAULT FALSE,
+    checked_at TIMESTAMPTZ,
+    position INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX list_items_list_id_position_idx ON list_items (list_id, position);
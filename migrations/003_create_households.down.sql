@@ -0,0 +1,3 @@
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS household_id;
+DROP TABLE IF EXISTS household_members;
+DROP TABLE IF EXISTS households;
@@ -0,0 +1,33 @@
+CREATE TABLE households (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE household_members (
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX household_members_user_id_idx ON household_members (user_id);
+
+ALTER TABLE shopping_lists ADD COLUMN household_id UUID REFERENCES households (id) ON DELETE CASCADE;
+
+-- Lists created before households existed are parked in a member-less household
+-- so the column can be made mandatory.
+INSERT INTO households (id, name, created_at, updated_at)
+SELECT gen_random_uuid(), 'Unassigned lists', now(), now()
+WHERE EXISTS (SELECT 1 FROM shopping_lists);
+
+UPDATE shopping_lists
+SET household_id = (SELECT id FROM households WHERE name = 'Unassigned lists' LIMIT 1)
+WHERE household_id IS NULL;
+
+ALTER TABLE shopping_lists ALTER COLUMN household_id SET NOT NULL;
+
+CREATE INDEX shopping_lists_household_id_idx ON shopping_lists (household_id);
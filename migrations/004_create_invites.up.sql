@@ -0,0 +1,21 @@
+CREATE TABLE invites (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    list_id UUID REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'editor', 'viewer')),
+    created_by UUID NOT NULL,
+    max_uses INT NOT NULL DEFAULT 1 CHECK (max_uses >= 0),
+    uses INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX invites_household_id_idx ON invites (household_id);
+
+CREATE TABLE invite_redemptions (
+    invite_id UUID NOT NULL REFERENCES invites (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    redeemed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (invite_id, user_id)
+);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS list_items_list_id_lower_name_idx;
+
+ALTER TABLE list_items ADD COLUMN quantity TEXT NOT NULL DEFAULT '';
+
+UPDATE list_items
+SET quantity = trim(both ' ' FROM concat_ws(' ', trim(trailing '.' FROM trim(trailing '0' FROM quantity_value::TEXT)) || ' ' || quantity_unit, NULLIF(note, '')));
+
+ALTER TABLE list_items
+    DROP COLUMN quantity_value,
+    DROP COLUMN quantity_unit,
+    DROP COLUMN note;
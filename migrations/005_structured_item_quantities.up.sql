@@ -0,0 +1,21 @@
+ALTER TABLE list_items
+    ADD COLUMN quantity_value NUMERIC(12, 3) NOT NULL DEFAULT 1 CHECK (quantity_value > 0),
+    ADD COLUMN quantity_unit TEXT NOT NULL DEFAULT 'pcs' CHECK (quantity_unit IN ('pcs', 'g', 'kg', 'ml', 'l', 'pack')),
+    ADD COLUMN note TEXT NOT NULL DEFAULT '';
+
+-- Carry over free-text quantities such as "2", "500 g" or "1.5l"; anything
+-- that does not parse is kept as a note on the item.
+UPDATE list_items
+SET quantity_value = (regexp_match(quantity, '^\s*([0-9]+(?:\.[0-9]+)?)'))[1]::NUMERIC,
+    quantity_unit = COALESCE(lower((regexp_match(quantity, '^\s*[0-9]+(?:\.[0-9]+)?\s*(pcs|g|kg|ml|l|pack)\s*$', 'i'))[1]), 'pcs')
+WHERE quantity ~* '^\s*[0-9]+(?:\.[0-9]+)?\s*(pcs|g|kg|ml|l|pack)?\s*$'
+  AND (regexp_match(quantity, '^\s*([0-9]+(?:\.[0-9]+)?)'))[1]::NUMERIC > 0;
+
+UPDATE list_items
+SET note = quantity
+WHERE quantity <> ''
+  AND NOT quantity ~* '^\s*[0-9]+(?:\.[0-9]+)?\s*(pcs|g|kg|ml|l|pack)?\s*$';
+
+ALTER TABLE list_items DROP COLUMN quantity;
+
+CREATE INDEX list_items_list_id_lower_name_idx ON list_items (list_id, lower(name)) WHERE NOT checked;
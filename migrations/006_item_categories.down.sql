@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS household_categories;
+
+ALTER TABLE list_items DROP COLUMN IF EXISTS category;
@@ -0,0 +1,11 @@
+-- Categories are defined by the keyword dictionary, not by a table, so the
+-- column holds the dictionary's category ID. Existing items start as "other".
+ALTER TABLE list_items ADD COLUMN category TEXT NOT NULL DEFAULT 'other';
+
+CREATE TABLE household_categories (
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    item_name TEXT NOT NULL, -- normalized: lower-case words separated by single spaces
+    category TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (household_id, item_name)
+);
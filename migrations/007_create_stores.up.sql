@@ -0,0 +1,11 @@
+CREATE TABLE stores (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- Ordered walking route: [{"category": "produce", "aisle": "1"}, ...]
+    sections JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX stores_household_id_idx ON stores (household_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS shopping_lists_household_id_created_at_idx;
+
+DROP TABLE IF EXISTS templates;
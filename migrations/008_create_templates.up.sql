@@ -0,0 +1,19 @@
+CREATE TABLE templates (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    -- [{"name": "milk", "quantity": {"value": 1, "unit": "l"}, "note": "", "category": ""}, ...]
+    items JSONB NOT NULL DEFAULT '[]',
+    -- {"every_days": 7} or {"day_of_month": 1}; NULL for templates that are only applied by hand
+    cadence JSONB,
+    list_id UUID REFERENCES shopping_lists (id) ON DELETE SET NULL,
+    next_run_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CHECK ((cadence IS NULL) = (next_run_at IS NULL))
+);
+
+CREATE INDEX templates_household_id_idx ON templates (household_id);
+CREATE INDEX templates_next_run_at_idx ON templates (next_run_at) WHERE next_run_at IS NOT NULL;
+
+CREATE INDEX shopping_lists_household_id_created_at_idx ON shopping_lists (household_id, created_at);
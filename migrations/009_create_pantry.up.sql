@@ -0,0 +1,17 @@
+CREATE TABLE pantry_items (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    quantity_value NUMERIC(12, 3) NOT NULL CHECK (quantity_value >= 0),
+    quantity_unit TEXT NOT NULL CHECK (quantity_unit IN ('pcs', 'g', 'kg', 'ml', 'l', 'pack')),
+    category TEXT NOT NULL DEFAULT 'other',
+    threshold_value NUMERIC(12, 3) CHECK (threshold_value > 0),
+    threshold_unit TEXT CHECK (threshold_unit IN ('pcs', 'g', 'kg', 'ml', 'l', 'pack')),
+    expires_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    CHECK ((threshold_value IS NULL) = (threshold_unit IS NULL))
+);
+
+CREATE INDEX pantry_items_household_id_lower_name_idx ON pantry_items (household_id, lower(name));
+CREATE INDEX pantry_items_household_id_expires_at_idx ON pantry_items (household_id, expires_at) WHERE expires_at IS NOT NULL;
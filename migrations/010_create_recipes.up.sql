@@ -0,0 +1,13 @@
+CREATE TABLE recipes (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    servings INTEGER NOT NULL CHECK (servings > 0),
+    -- [{"name": "flour", "quantity": {"value": 500, "unit": "g"}, "note": "", "category": ""}, ...]
+    ingredients JSONB NOT NULL DEFAULT '[]',
+    instructions TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX recipes_household_id_idx ON recipes (household_id);
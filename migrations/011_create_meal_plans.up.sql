@@ -0,0 +1,12 @@
+CREATE TABLE meal_plan_entries (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    date DATE NOT NULL,
+    meal TEXT NOT NULL CHECK (meal IN ('breakfast', 'lunch', 'dinner', 'snack')),
+    recipe_id UUID NOT NULL REFERENCES recipes (id) ON DELETE CASCADE,
+    servings INTEGER NOT NULL CHECK (servings > 0),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX meal_plan_entries_household_id_date_idx ON meal_plan_entries (household_id, date);
@@ -0,0 +1,16 @@
+CREATE TABLE prices (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    quantity_value NUMERIC(12, 3) NOT NULL CHECK (quantity_value > 0),
+    quantity_unit TEXT NOT NULL CHECK (quantity_unit IN ('pcs', 'g', 'kg', 'ml', 'l', 'pack')),
+    -- in the currency's minor unit, such as cents
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    paid_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX prices_household_id_lower_name_idx ON prices (household_id, lower(name), paid_at DESC);
+CREATE INDEX prices_store_id_lower_name_idx ON prices (store_id, lower(name), quantity_unit, paid_at DESC);
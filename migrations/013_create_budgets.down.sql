@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS budgets;
+
+DROP INDEX IF EXISTS prices_household_id_paid_at_idx;
+ALTER TABLE prices DROP COLUMN IF EXISTS category;
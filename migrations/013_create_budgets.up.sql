@@ -0,0 +1,19 @@
+ALTER TABLE prices ADD COLUMN category TEXT NOT NULL DEFAULT 'other';
+
+CREATE INDEX prices_household_id_paid_at_idx ON prices (household_id, paid_at);
+
+CREATE TABLE budgets (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    -- empty for a budget that covers every category
+    category TEXT NOT NULL DEFAULT '',
+    period TEXT NOT NULL CHECK (period IN ('weekly', 'monthly')),
+    -- in the currency's minor unit, such as cents
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    -- percentages of amount that alert members when spending reaches them
+    thresholds INTEGER[] NOT NULL DEFAULT '{80, 100}',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    UNIQUE (household_id, period, category)
+);
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS list_items_list_id_checked_at_idx;
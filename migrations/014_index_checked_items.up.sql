@@ -0,0 +1 @@
+CREATE INDEX list_items_list_id_checked_at_idx ON list_items (list_id, checked_at) WHERE checked;
@@ -0,0 +1,11 @@
+CREATE TABLE products (
+    -- EAN-13, or EAN-8; UPC-A codes are stored as EAN-13 with a leading zero
+    gtin TEXT PRIMARY KEY CHECK (gtin ~ '^([0-9]{8}|[0-9]{13})$'),
+    name TEXT NOT NULL,
+    brand TEXT NOT NULL DEFAULT '',
+    category TEXT NOT NULL,
+    quantity_value NUMERIC(12, 3) NOT NULL CHECK (quantity_value > 0),
+    quantity_unit TEXT NOT NULL CHECK (quantity_unit IN ('pcs', 'g', 'kg', 'ml', 'l', 'pack')),
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
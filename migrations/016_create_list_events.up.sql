@@ -0,0 +1,23 @@
+CREATE TABLE list_events (
+    id UUID PRIMARY KEY,
+    -- orders events across concurrent transactions
+    seq BIGINT GENERATED ALWAYS AS IDENTITY UNIQUE,
+    list_id UUID NOT NULL REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    -- NULL for changes made by the scheduler
+    actor_id UUID,
+    type TEXT NOT NULL CHECK (type IN ('item_added', 'item_edited', 'item_checked', 'item_unchecked', 'item_removed',
+        'items_reordered')),
+    item_id UUID,
+    -- the item as it was before and after the change
+    item_before JSONB,
+    item_after JSONB,
+    -- item IDs in list order before and after a reorder
+    item_order JSONB,
+    -- the event this one undid
+    reverts UUID REFERENCES list_events (id) ON DELETE CASCADE,
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX list_events_list_id_seq_idx ON list_events (list_id, seq);
+CREATE INDEX list_events_list_id_actor_id_seq_idx ON list_events (list_id, actor_id, seq DESC) WHERE reverts IS NULL;
+CREATE INDEX list_events_reverts_idx ON list_events (reverts);
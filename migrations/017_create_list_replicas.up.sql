@@ -0,0 +1,7 @@
+CREATE TABLE list_replicas (
+    list_id UUID PRIMARY KEY REFERENCES shopping_lists (id) ON DELETE CASCADE,
+    -- JSON rather than JSONB: field values are compared byte for byte
+    document JSON NOT NULL,
+    version BIGINT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
@@ -0,0 +1,21 @@
+DROP TRIGGER IF EXISTS households_sync_deleted ON households;
+DROP TRIGGER IF EXISTS household_members_sync_joined ON household_members;
+DROP TRIGGER IF EXISTS household_members_sync_left ON household_members;
+DROP TRIGGER IF EXISTS list_items_sync_deleted ON list_items;
+DROP TRIGGER IF EXISTS shopping_lists_sync_deleted ON shopping_lists;
+DROP TRIGGER IF EXISTS list_items_sync_change ON list_items;
+DROP TRIGGER IF EXISTS shopping_lists_sync_change ON shopping_lists;
+
+DROP FUNCTION IF EXISTS sync_member_joined();
+DROP FUNCTION IF EXISTS sync_household_deleted();
+DROP FUNCTION IF EXISTS sync_member_left();
+DROP FUNCTION IF EXISTS sync_item_deleted();
+DROP FUNCTION IF EXISTS sync_list_deleted();
+DROP FUNCTION IF EXISTS sync_record_change();
+
+DROP TABLE IF EXISTS sync_tombstones;
+
+ALTER TABLE list_items DROP COLUMN IF EXISTS change_seq, DROP COLUMN IF EXISTS change_xid;
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS change_seq, DROP COLUMN IF EXISTS change_xid;
+
+DROP SEQUENCE IF EXISTS sync_change_seq;
@@ -0,0 +1,110 @@
+-- Every insert or update of a list or item, and every tombstone, takes the next
+-- number of one sequence, together with the ID of the writing transaction. The
+-- transaction ID tells readers which changes are safe to hand out: a change
+-- whose transaction is older than every transaction still running can no
+-- longer be joined by a smaller change_seq committing late.
+CREATE SEQUENCE sync_change_seq;
+
+ALTER TABLE shopping_lists
+    ADD COLUMN change_seq BIGINT NOT NULL DEFAULT nextval('sync_change_seq'),
+    ADD COLUMN change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+ALTER TABLE list_items
+    ADD COLUMN change_seq BIGINT NOT NULL DEFAULT nextval('sync_change_seq'),
+    ADD COLUMN change_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+CREATE INDEX shopping_lists_change_seq_idx ON shopping_lists (change_seq);
+CREATE INDEX list_items_change_seq_idx ON list_items (change_seq);
+
+CREATE FUNCTION sync_record_change() RETURNS trigger AS $$
+BEGIN
+    NEW.change_seq := nextval('sync_change_seq');
+    NEW.change_xid := pg_current_xact_id();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER shopping_lists_sync_change BEFORE INSERT OR UPDATE ON shopping_lists
+    FOR EACH ROW EXECUTE FUNCTION sync_record_change();
+CREATE TRIGGER list_items_sync_change BEFORE INSERT OR UPDATE ON list_items
+    FOR EACH ROW EXECUTE FUNCTION sync_record_change();
+
+-- Tombstones are kept per user, so that losing access to a list reads the
+-- same as the list being deleted, and are purged after the retention period.
+CREATE TABLE sync_tombstones (
+    change_seq BIGINT PRIMARY KEY DEFAULT nextval('sync_change_seq'),
+    change_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    user_id UUID NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('list', 'item')),
+    id UUID NOT NULL,
+    -- the list of a deleted item
+    list_id UUID,
+    deleted_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX sync_tombstones_user_id_change_seq_idx ON sync_tombstones (user_id, change_seq);
+CREATE INDEX sync_tombstones_deleted_at_idx ON sync_tombstones (deleted_at);
+
+CREATE FUNCTION sync_list_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT user_id, 'list', OLD.id FROM household_members WHERE household_id = OLD.household_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Items deleted along with their list are covered by the list's tombstone; the
+-- list is gone by then, so the join finds nothing.
+CREATE FUNCTION sync_item_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id, list_id)
+    SELECT m.user_id, 'item', OLD.id, OLD.list_id
+    FROM shopping_lists l
+    JOIN household_members m ON m.household_id = l.household_id
+    WHERE l.id = OLD.list_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- A member who leaves loses every list of the household.
+CREATE FUNCTION sync_member_left() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT OLD.user_id, 'list', id FROM shopping_lists WHERE household_id = OLD.household_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Deleting a household removes its members and lists in whichever order the
+-- cascades run, so the tombstones are written before either is gone.
+CREATE FUNCTION sync_household_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT m.user_id, 'list', l.id
+    FROM shopping_lists l
+    JOIN household_members m ON m.household_id = l.household_id
+    WHERE l.household_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+-- A new member has none of the household's lists yet; touching them makes them
+-- changes the member's next sync picks up.
+CREATE FUNCTION sync_member_joined() RETURNS trigger AS $$
+BEGIN
+    UPDATE shopping_lists SET updated_at = updated_at WHERE household_id = NEW.household_id;
+    UPDATE list_items SET updated_at = updated_at
+    WHERE list_id IN (SELECT id FROM shopping_lists WHERE household_id = NEW.household_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER shopping_lists_sync_deleted AFTER DELETE ON shopping_lists
+    FOR EACH ROW EXECUTE FUNCTION sync_list_deleted();
+CREATE TRIGGER list_items_sync_deleted AFTER DELETE ON list_items
+    FOR EACH ROW EXECUTE FUNCTION sync_item_deleted();
+CREATE TRIGGER household_members_sync_left AFTER DELETE ON household_members
+    FOR EACH ROW EXECUTE FUNCTION sync_member_left();
+CREATE TRIGGER household_members_sync_joined AFTER INSERT ON household_members
+    FOR EACH ROW EXECUTE FUNCTION sync_member_joined();
+CREATE TRIGGER households_sync_deleted BEFORE DELETE ON households
+    FOR EACH ROW EXECUTE FUNCTION sync_household_deleted();
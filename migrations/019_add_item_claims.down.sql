@@ -0,0 +1,5 @@
+ALTER TABLE list_items
+    DROP COLUMN IF EXISTS claimed_by,
+    DROP COLUMN IF EXISTS claim_assigned_by,
+    DROP COLUMN IF EXISTS claimed_at,
+    DROP COLUMN IF EXISTS claim_expires_at;
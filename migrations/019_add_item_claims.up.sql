@@ -0,0 +1,9 @@
+-- who is buying an item; cleared once the claim expires
+ALTER TABLE list_items
+    ADD COLUMN claimed_by UUID,
+    ADD COLUMN claim_assigned_by UUID,
+    ADD COLUMN claimed_at TIMESTAMPTZ,
+    ADD COLUMN claim_expires_at TIMESTAMPTZ;
+
+CREATE INDEX list_items_claimed_by_idx ON list_items (claimed_by) WHERE claimed_by IS NOT NULL;
+CREATE INDEX list_items_claim_expires_at_idx ON list_items (claim_expires_at) WHERE claim_expires_at IS NOT NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trip_entries;
+DROP TABLE IF EXISTS trips;
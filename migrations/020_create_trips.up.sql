@@ -0,0 +1,36 @@
+CREATE TABLE trips (
+    id UUID PRIMARY KEY,
+    household_id UUID NOT NULL REFERENCES households (id) ON DELETE CASCADE,
+    store_id UUID NOT NULL REFERENCES stores (id) ON DELETE CASCADE,
+    list_ids UUID[] NOT NULL,
+    started_by UUID NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('active', 'finished')),
+    currency TEXT NOT NULL CHECK (currency ~ '^[A-Z]{3}$'),
+    -- in the currency's minor unit, set when the trip is finished
+    spent BIGINT CHECK (spent >= 0),
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX trips_household_id_started_at_idx ON trips (household_id, started_at DESC);
+-- a member shops on one trip at a time
+CREATE UNIQUE INDEX trips_started_by_active_idx ON trips (started_by) WHERE status = 'active';
+
+-- the timeline of a trip; items are copied since they may leave the list
+CREATE TABLE trip_entries (
+    id UUID PRIMARY KEY,
+    trip_id UUID NOT NULL REFERENCES trips (id) ON DELETE CASCADE,
+    kind TEXT NOT NULL CHECK (kind IN ('checked', 'not_found')),
+    item_id UUID NOT NULL,
+    list_id UUID NOT NULL,
+    name TEXT NOT NULL,
+    quantity_value NUMERIC(12, 3) NOT NULL CHECK (quantity_value > 0),
+    quantity_unit TEXT NOT NULL CHECK (quantity_unit IN ('pcs', 'g', 'kg', 'ml', 'l', 'pack')),
+    category TEXT NOT NULL,
+    -- what was paid for the item, in the trip's currency
+    amount BIGINT CHECK (amount >= 0),
+    actor_id UUID NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX trip_entries_trip_id_occurred_at_idx ON trip_entries (trip_id, occurred_at);
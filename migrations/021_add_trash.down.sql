@@ -0,0 +1,60 @@
+DROP TRIGGER IF EXISTS shopping_lists_sync_restored ON shopping_lists;
+DROP TRIGGER IF EXISTS list_items_sync_trashed ON list_items;
+DROP TRIGGER IF EXISTS shopping_lists_sync_trashed ON shopping_lists;
+DROP TRIGGER IF EXISTS list_items_sync_deleted ON list_items;
+DROP TRIGGER IF EXISTS shopping_lists_sync_deleted ON shopping_lists;
+DROP FUNCTION IF EXISTS sync_list_restored();
+
+-- What is still in the trash is gone for good without it.
+DELETE FROM list_items WHERE deleted_at IS NOT NULL;
+DELETE FROM shopping_lists WHERE deleted_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION sync_item_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id, list_id)
+    SELECT m.user_id, 'item', OLD.id, OLD.list_id
+    FROM shopping_lists l
+    JOIN household_members m ON m.household_id = l.household_id
+    WHERE l.id = OLD.list_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_member_left() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT OLD.user_id, 'list', id FROM shopping_lists WHERE household_id = OLD.household_id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_household_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT m.user_id, 'list', l.id
+    FROM shopping_lists l
+    JOIN household_members m ON m.household_id = l.household_id
+    WHERE l.household_id = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_member_joined() RETURNS trigger AS $$
+BEGIN
+    UPDATE shopping_lists SET updated_at = updated_at WHERE household_id = NEW.household_id;
+    UPDATE list_items SET updated_at = updated_at
+    WHERE list_id IN (SELECT id FROM shopping_lists WHERE household_id = NEW.household_id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER shopping_lists_sync_deleted AFTER DELETE ON shopping_lists
+    FOR EACH ROW EXECUTE FUNCTION sync_list_deleted();
+CREATE TRIGGER list_items_sync_deleted AFTER DELETE ON list_items
+    FOR EACH ROW EXECUTE FUNCTION sync_item_deleted();
+
+DROP INDEX IF EXISTS list_items_deleted_at_idx;
+DROP INDEX IF EXISTS shopping_lists_deleted_at_idx;
+
+ALTER TABLE list_items DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,74 @@
+-- Deleted lists and items go to their household's trash, from where they can be
+-- restored until they are purged. The items of a deleted list stay as they were
+-- and come back with it.
+ALTER TABLE shopping_lists ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE list_items ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX shopping_lists_deleted_at_idx ON shopping_lists (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX list_items_deleted_at_idx ON list_items (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Sync clients drop a list or item when it goes to the trash, so tombstones are
+-- written then rather than when the trash is purged. Items of a list in the
+-- trash are covered by the list's tombstone.
+CREATE OR REPLACE FUNCTION sync_item_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id, list_id)
+    SELECT m.user_id, 'item', OLD.id, OLD.list_id
+    FROM shopping_lists l
+    JOIN household_members m ON m.household_id = l.household_id
+    WHERE l.id = OLD.list_id AND l.deleted_at IS NULL;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_member_left() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT OLD.user_id, 'list', id FROM shopping_lists WHERE household_id = OLD.household_id AND deleted_at IS NULL;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_household_deleted() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO sync_tombstones (user_id, kind, id)
+    SELECT m.user_id, 'list', l.id
+    FROM shopping_lists l
+    JOIN household_members m ON m.household_id = l.household_id
+    WHERE l.household_id = OLD.id AND l.deleted_at IS NULL;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION sync_member_joined() RETURNS trigger AS $$
+BEGIN
+    UPDATE shopping_lists SET updated_at = updated_at WHERE household_id = NEW.household_id AND deleted_at IS NULL;
+    UPDATE list_items SET updated_at = updated_at
+    WHERE deleted_at IS NULL
+        AND list_id IN (SELECT id FROM shopping_lists WHERE household_id = NEW.household_id AND deleted_at IS NULL);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- A restored list is a change again, and so are its items, which clients
+-- dropped along with the list.
+CREATE FUNCTION sync_list_restored() RETURNS trigger AS $$
+BEGIN
+    UPDATE list_items SET updated_at = updated_at WHERE list_id = NEW.id AND deleted_at IS NULL;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER shopping_lists_sync_deleted ON shopping_lists;
+DROP TRIGGER list_items_sync_deleted ON list_items;
+
+CREATE TRIGGER shopping_lists_sync_deleted AFTER DELETE ON shopping_lists
+    FOR EACH ROW WHEN (OLD.deleted_at IS NULL) EXECUTE FUNCTION sync_list_deleted();
+CREATE TRIGGER list_items_sync_deleted AFTER DELETE ON list_items
+    FOR EACH ROW WHEN (OLD.deleted_at IS NULL) EXECUTE FUNCTION sync_item_deleted();
+CREATE TRIGGER shopping_lists_sync_trashed AFTER UPDATE OF deleted_at ON shopping_lists
+    FOR EACH ROW WHEN (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL) EXECUTE FUNCTION sync_list_deleted();
+CREATE TRIGGER list_items_sync_trashed AFTER UPDATE OF deleted_at ON list_items
+    FOR EACH ROW WHEN (OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL) EXECUTE FUNCTION sync_item_deleted();
+CREATE TRIGGER shopping_lists_sync_restored AFTER UPDATE OF deleted_at ON shopping_lists
+    FOR EACH ROW WHEN (OLD.deleted_at IS NOT NULL AND NEW.deleted_at IS NULL) EXECUTE FUNCTION sync_list_restored();
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS list_events_checked_idx;
@@ -0,0 +1,2 @@
+-- suggestions read every check-off of a household's lists over the past year
+CREATE INDEX list_events_checked_idx ON list_events (list_id, occurred_at) WHERE type = 'item_checked' AND reverts IS NULL;
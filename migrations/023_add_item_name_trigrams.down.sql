@@ -0,0 +1 @@
+DROP INDEX IF EXISTS list_items_name_trgm_idx;
@@ -0,0 +1,5 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- likely duplicates are looked up among a list's unchecked items by name
+CREATE INDEX list_items_name_trgm_idx ON list_items USING gin (lower(name) gin_trgm_ops)
+    WHERE deleted_at IS NULL AND NOT checked;
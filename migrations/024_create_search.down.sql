@@ -0,0 +1,25 @@
+DROP INDEX IF EXISTS products_name_trgm_idx;
+DROP INDEX IF EXISTS recipes_name_trgm_idx;
+DROP INDEX IF EXISTS list_items_search_name_trgm_idx;
+DROP INDEX IF EXISTS shopping_lists_name_trgm_idx;
+DROP INDEX IF EXISTS products_search_idx;
+DROP INDEX IF EXISTS recipes_search_idx;
+DROP INDEX IF EXISTS list_items_search_idx;
+DROP INDEX IF EXISTS shopping_lists_search_idx;
+
+DROP TRIGGER IF EXISTS products_search ON products;
+DROP TRIGGER IF EXISTS recipes_search ON recipes;
+DROP TRIGGER IF EXISTS list_items_search ON list_items;
+DROP TRIGGER IF EXISTS shopping_lists_search ON shopping_lists;
+
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE recipes DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE list_items DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE shopping_lists DROP COLUMN IF EXISTS search_vector;
+
+DROP FUNCTION IF EXISTS products_search_update();
+DROP FUNCTION IF EXISTS recipes_search_update();
+DROP FUNCTION IF EXISTS list_items_search_update();
+DROP FUNCTION IF EXISTS shopping_lists_search_update();
+DROP FUNCTION IF EXISTS search_query(regconfig[], TEXT);
+DROP FUNCTION IF EXISTS search_document(TEXT, "char");
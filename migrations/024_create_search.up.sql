@@ -0,0 +1,96 @@
+-- Searchable rows keep a tsvector that triggers maintain. Names and text are
+-- indexed with English stems and with their words as written, so an English
+-- query finds other forms of its words and one in any other language, such
+-- as Ukrainian, which Postgres has no stemmer for, finds the words it names.
+CREATE FUNCTION search_document(body TEXT, weight "char") RETURNS tsvector AS $$
+    SELECT setweight(
+        to_tsvector('english', coalesce(body, '')) ||
+        to_tsvector('simple', coalesce(body, '')),
+        weight)
+$$ LANGUAGE sql IMMUTABLE;
+
+-- search_query matches what any of the configurations makes of the query.
+CREATE FUNCTION search_query(configs regconfig[], query TEXT) RETURNS tsquery AS $$
+DECLARE
+    config regconfig;
+    result tsquery := '';
+BEGIN
+    FOREACH config IN ARRAY configs LOOP
+        result := result || websearch_to_tsquery(config, query);
+    END LOOP;
+    RETURN result;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE FUNCTION shopping_lists_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := search_document(NEW.name, 'A');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION list_items_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := search_document(NEW.name, 'A') || search_document(NEW.note, 'B') ||
+        search_document(NEW.category, 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION recipes_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := search_document(NEW.name, 'A') ||
+        search_document((SELECT string_agg(i->>'name', ' ') FROM jsonb_array_elements(NEW.ingredients) i), 'B') ||
+        search_document(NEW.instructions, 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE FUNCTION products_search_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := search_document(NEW.name, 'A') || search_document(NEW.brand, 'B') ||
+        search_document(NEW.category, 'C');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+ALTER TABLE shopping_lists ADD COLUMN search_vector tsvector;
+ALTER TABLE list_items ADD COLUMN search_vector tsvector;
+ALTER TABLE recipes ADD COLUMN search_vector tsvector;
+ALTER TABLE products ADD COLUMN search_vector tsvector;
+
+-- Filling in the vectors is not a change sync clients need to see.
+ALTER TABLE shopping_lists DISABLE TRIGGER shopping_lists_sync_change;
+ALTER TABLE list_items DISABLE TRIGGER list_items_sync_change;
+UPDATE shopping_lists SET search_vector = search_document(name, 'A');
+UPDATE list_items
+SET search_vector = search_document(name, 'A') || search_document(note, 'B') || search_document(category, 'C');
+ALTER TABLE shopping_lists ENABLE TRIGGER shopping_lists_sync_change;
+ALTER TABLE list_items ENABLE TRIGGER list_items_sync_change;
+
+UPDATE recipes
+SET search_vector = search_document(name, 'A') ||
+    search_document((SELECT string_agg(i->>'name', ' ') FROM jsonb_array_elements(ingredients) i), 'B') ||
+    search_document(instructions, 'C');
+UPDATE products
+SET search_vector = search_document(name, 'A') || search_document(brand, 'B') || search_document(category, 'C');
+
+CREATE TRIGGER shopping_lists_search BEFORE INSERT OR UPDATE OF name ON shopping_lists
+    FOR EACH ROW EXECUTE FUNCTION shopping_lists_search_update();
+CREATE TRIGGER list_items_search BEFORE INSERT OR UPDATE OF name, note, category ON list_items
+    FOR EACH ROW EXECUTE FUNCTION list_items_search_update();
+CREATE TRIGGER recipes_search BEFORE INSERT OR UPDATE OF name, ingredients, instructions ON recipes
+    FOR EACH ROW EXECUTE FUNCTION recipes_search_update();
+CREATE TRIGGER products_search BEFORE INSERT OR UPDATE OF name, brand, category ON products
+    FOR EACH ROW EXECUTE FUNCTION products_search_update();
+
+CREATE INDEX shopping_lists_search_idx ON shopping_lists USING gin (search_vector) WHERE deleted_at IS NULL;
+CREATE INDEX list_items_search_idx ON list_items USING gin (search_vector) WHERE deleted_at IS NULL;
+CREATE INDEX recipes_search_idx ON recipes USING gin (search_vector);
+CREATE INDEX products_search_idx ON products USING gin (search_vector);
+
+-- the trigram fallback for misspelled queries
+CREATE INDEX shopping_lists_name_trgm_idx ON shopping_lists USING gin (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX list_items_search_name_trgm_idx ON list_items USING gin (lower(name) gin_trgm_ops) WHERE deleted_at IS NULL;
+CREATE INDEX recipes_name_trgm_idx ON recipes USING gin (lower(name) gin_trgm_ops);
+CREATE INDEX products_name_trgm_idx ON products USING gin (lower(name) gin_trgm_ops);
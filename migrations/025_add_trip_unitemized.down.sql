@@ -0,0 +1,2 @@
+DROP INDEX trips_household_id_finished_at_idx;
+ALTER TABLE trips DROP COLUMN unitemized;
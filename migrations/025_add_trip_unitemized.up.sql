@@ -0,0 +1,5 @@
+-- the part of a finished trip's total not paid for any checked off item, such
+-- as bags or items bought off the list; it counts towards budgets like prices
+ALTER TABLE trips ADD COLUMN unitemized BIGINT;
+
+CREATE INDEX trips_household_id_finished_at_idx ON trips (household_id, finished_at) WHERE unitemized IS NOT NULL;
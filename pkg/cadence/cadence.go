@@ -0,0 +1,53 @@
+// Package cadence describes how often something recurs: every few days or on
+// a fixed day of each month.
+package cadence
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrInvalid = errors.New("cadence needs either every_days between 1 and 365 or day_of_month between 1 and 31")
+
+// Cadence is either an interval in days or a day of the month; exactly one of
+// the fields is set.
+type Cadence struct {
+	EveryDays  int `json:"every_days,omitempty"`
+	DayOfMonth int `json:"day_of_month,omitempty"`
+}
+
+func (c Cadence) Validate() error {
+	switch {
+	case c.EveryDays != 0 && c.DayOfMonth != 0:
+		return ErrInvalid
+	case c.EveryDays != 0 && (c.EveryDays < 1 || c.EveryDays > 365):
+		return ErrInvalid
+	case c.DayOfMonth != 0 && (c.DayOfMonth < 1 || c.DayOfMonth > 31):
+		return ErrInvalid
+	case c.EveryDays == 0 && c.DayOfMonth == 0:
+		return ErrInvalid
+	}
+	return nil
+}
+
+// Next returns the first occurrence after t. Monthly occurrences are at
+// midnight in t's location, and a day past the end of a short month falls on
+// its last day, so day 31 recurs on 28 or 29 February.
+func (c Cadence) Next(t time.Time) time.Time {
+	if c.EveryDays > 0 {
+		return t.AddDate(0, 0, c.EveryDays)
+	}
+
+	year, month, _ := t.Date()
+	for i := 0; ; i++ {
+		if next := dayOfMonth(year, month+time.Month(i), c.DayOfMonth, t.Location()); next.After(t) {
+			return next
+		}
+	}
+}
+
+func dayOfMonth(year int, month time.Month, day int, loc *time.Location) time.Time {
+	first := time.Date(year, month, 1, 0, 0, 0, 0, loc)
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(day, last)-1)
+}
@@ -0,0 +1,53 @@
+package cadence
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return v
+	}
+
+	cases := []struct {
+		name    string
+		cadence Cadence
+		from    string
+		want    string
+	}{
+		{"every week", Cadence{EveryDays: 7}, "2024-03-01T10:30:00Z", "2024-03-08T10:30:00Z"},
+		{"first of the month", Cadence{DayOfMonth: 1}, "2024-03-01T10:30:00Z", "2024-04-01T00:00:00Z"},
+		{"later this month", Cadence{DayOfMonth: 15}, "2024-03-01T10:30:00Z", "2024-03-15T00:00:00Z"},
+		{"exactly at midnight", Cadence{DayOfMonth: 15}, "2024-03-15T00:00:00Z", "2024-04-15T00:00:00Z"},
+		{"short month", Cadence{DayOfMonth: 31}, "2024-02-01T00:00:00Z", "2024-02-29T00:00:00Z"},
+		{"after a short month", Cadence{DayOfMonth: 31}, "2024-02-29T12:00:00Z", "2024-03-31T00:00:00Z"},
+		{"year end", Cadence{DayOfMonth: 1}, "2024-12-20T00:00:00Z", "2025-01-01T00:00:00Z"},
+	}
+	for _, tc := range cases {
+		if got := tc.cadence.Next(at(tc.from)); !got.Equal(at(tc.want)) {
+			t.Errorf("%s: Next() = %v, want %s", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []Cadence{{EveryDays: 1}, {EveryDays: 365}, {DayOfMonth: 1}, {DayOfMonth: 31}}
+	for _, c := range valid {
+		if err := c.Validate(); err != nil {
+			t.Errorf("%+v: Validate() = %v, want nil", c, err)
+		}
+	}
+
+	invalid := []Cadence{{}, {EveryDays: -1}, {EveryDays: 400}, {DayOfMonth: 32}, {EveryDays: 7, DayOfMonth: 1}}
+	for _, c := range invalid {
+		if err := c.Validate(); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%+v: Validate() = %v, want ErrInvalid", c, err)
+		}
+	}
+}
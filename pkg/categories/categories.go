@@ -0,0 +1,191 @@
+// Package categories assigns shopping items to store categories such as
+// produce or dairy by matching their names against a keyword dictionary.
+package categories
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Other is the category of items that match no keyword.
+const Other = "other"
+
+//go:embed default.json
+var defaultDictionary []byte
+
+var ErrInvalidDictionary = errors.New("invalid category dictionary")
+
+type Category struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Dictionary maps keywords and synonyms to categories. A keyword ending in
+// "*" matches any word that starts with it, which covers plurals and the
+// inflected forms of Ukrainian nouns ("яблу*" matches "яблука" and "яблук").
+type Dictionary struct {
+	categories []Category
+	index      map[string]int
+	phrases    map[string]string // normalized keyword -> category ID
+	prefixes   []prefix          // longest first
+	maxWords   int
+}
+
+type prefix struct {
+	stem     string
+	category string
+}
+
+type dictionaryFile struct {
+	Categories []struct {
+		ID       string   `json:"id"`
+		Name     string   `json:"name"`
+		Keywords []string `json:"keywords"`
+	} `json:"categories"`
+}
+
+// Default returns the built-in English and Ukrainian dictionary.
+func Default() *Dictionary {
+	d, err := Parse(defaultDictionary)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// Load reads a dictionary from a JSON file in the format of default.json.
+func Load(path string) (*Dictionary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+// Parse reads a JSON dictionary. Categories keep the order they are listed in;
+// Other is appended when the dictionary does not define it.
+func Parse(data []byte) (*Dictionary, error) {
+	var file dictionaryFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDictionary, err)
+	}
+
+	d := &Dictionary{
+		index:   make(map[string]int),
+		phrases: make(map[string]string),
+	}
+	seen := make(map[string]string)
+	for _, c := range file.Categories {
+		id := strings.TrimSpace(c.ID)
+		if id == "" {
+			return nil, fmt.Errorf("%w: category without id", ErrInvalidDictionary)
+		}
+		if _, ok := d.index[id]; ok {
+			return nil, fmt.Errorf("%w: duplicate category %q", ErrInvalidDictionary, id)
+		}
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			name = id
+		}
+		d.index[id] = len(d.categories)
+		d.categories = append(d.categories, Category{ID: id, Name: name})
+
+		for _, keyword := range c.Keywords {
+			stem, isPrefix := strings.CutSuffix(keyword, "*")
+			stem = Normalize(stem)
+			if stem == "" {
+				continue
+			}
+			key := stem
+			if isPrefix {
+				key += "*"
+			}
+			if other, ok := seen[key]; ok && other != id {
+				return nil, fmt.Errorf("%w: keyword %q is in both %q and %q", ErrInvalidDictionary, keyword, other, id)
+			}
+			seen[key] = id
+
+			if isPrefix {
+				d.prefixes = append(d.prefixes, prefix{stem: stem, category: id})
+				continue
+			}
+			d.phrases[stem] = id
+			d.maxWords = max(d.maxWords, len(strings.Fields(stem)))
+		}
+	}
+	if _, ok := d.index[Other]; !ok {
+		d.index[Other] = len(d.categories)
+		d.categories = append(d.categories, Category{ID: Other, Name: "Other"})
+	}
+	sort.SliceStable(d.prefixes, func(i, j int) bool {
+		return len(d.prefixes[i].stem) > len(d.prefixes[j].stem)
+	})
+
+	return d, nil
+}
+
+// Categories returns the categories in dictionary order.
+func (d *Dictionary) Categories() []Category {
+	return append([]Category(nil), d.categories...)
+}
+
+// Category looks up a category by ID.
+func (d *Dictionary) Category(id string) (Category, bool) {
+	i, ok := d.index[id]
+	if !ok {
+		return Category{}, false
+	}
+	return d.categories[i], true
+}
+
+// Position is the index of the category in dictionary order, or -1.
+func (d *Dictionary) Position(id string) int {
+	i, ok := d.index[id]
+	if !ok {
+		return -1
+	}
+	return i
+}
+
+// Categorize returns the category of an item name, or Other. Multi-word
+// keywords win over single words, and words are tried from the end of the
+// name so that "chocolate milk" is dairy while "milk chocolate" is a snack.
+func (d *Dictionary) Categorize(name string) string {
+	words := strings.Fields(Normalize(name))
+
+	for n := min(d.maxWords, len(words)); n > 1; n-- {
+		for i := len(words) - n; i >= 0; i-- {
+			if id, ok := d.phrases[strings.Join(words[i:i+n], " ")]; ok {
+				return id
+			}
+		}
+	}
+
+	for i := len(words) - 1; i >= 0; i-- {
+		if id, ok := d.phrases[words[i]]; ok {
+			return id
+		}
+		for _, p := range d.prefixes {
+			if strings.HasPrefix(words[i], p.stem) {
+				return p.category
+			}
+		}
+	}
+
+	return Other
+}
+
+// Normalize lower-cases a name and reduces it to words separated by single
+// spaces, so that "  Whole-Milk " and "whole milk" are the same item.
+func Normalize(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '’' && r != 'ʼ'
+	})
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,77 @@
+package categories
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCategorize(t *testing.T) {
+	d := Default()
+	cases := []struct {
+		name string
+		want string
+	}{
+		{"Milk", "dairy"},
+		{"whole milk", "dairy"},
+		{"chocolate milk", "dairy"},
+		{"milk chocolate", "snacks"},
+		{"Bananas", "produce"},
+		{"cherry tomatoes", "produce"},
+		{"ice cream", "frozen"},
+		{"Vanilla Ice-Cream", "frozen"},
+		{"sour cream", "dairy"},
+		{"toilet paper", "household"},
+		{"chicken breast", "meat"},
+		{"молоко", "dairy"},
+		{"яблука", "produce"},
+		{"борошна", "pantry"},
+		{"куряче філе", "meat"},
+		{"мінеральна вода", "beverages"},
+		{"кавун", "produce"},
+		{"кава мелена", "pantry"},
+		{"lightbulbs", Other},
+		{"", Other},
+	}
+	for _, tc := range cases {
+		if got := d.Categorize(tc.name); got != tc.want {
+			t.Errorf("Categorize(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	d, err := Parse([]byte(`{"categories": [
+		{"id": "fruit", "name": "Fruit", "keywords": ["apple*", "Pear"]},
+		{"id": "drinks", "keywords": ["apple juice"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if got := d.Categorize("apple juice"); got != "drinks" {
+		t.Errorf("Categorize(apple juice) = %q, want drinks", got)
+	}
+	if got := d.Categorize("pears"); got != Other {
+		t.Errorf("Categorize(pears) = %q, want %q", got, Other)
+	}
+	if got := d.Position(Other); got != 2 {
+		t.Errorf("Position(other) = %d, want 2", got)
+	}
+	if c, _ := d.Category("drinks"); c.Name != "drinks" {
+		t.Errorf("Category(drinks).Name = %q, want drinks", c.Name)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	cases := []string{
+		`not json`,
+		`{"categories": [{"id": "", "keywords": ["x"]}]}`,
+		`{"categories": [{"id": "a"}, {"id": "a"}]}`,
+		`{"categories": [{"id": "a", "keywords": ["milk"]}, {"id": "b", "keywords": ["Milk"]}]}`,
+	}
+	for _, tc := range cases {
+		if _, err := Parse([]byte(tc)); !errors.Is(err, ErrInvalidDictionary) {
+			t.Errorf("Parse(%s) error = %v, want ErrInvalidDictionary", tc, err)
+		}
+	}
+}
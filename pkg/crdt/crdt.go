@@ -0,0 +1,292 @@
+// Package crdt merges concurrent edits to an ordered collection of items,
+// such as a shopping list edited offline on several devices. Replicas that
+// apply the same operations end up in the same state in any order, with
+// duplicates ignored:
+//
+//   - item fields are last-writer-wins registers ordered by Lamport clock,
+//     then client ID;
+//   - items form an observed-remove set, so a remove only removes the adds it
+//     has seen and a concurrent add wins;
+//   - order follows a replicated growable array of slots: each add or move
+//     creates a slot after another slot, newer slots first, and an item sits
+//     in its most recently written slot.
+package crdt
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrInvalidOp = errors.New("invalid operation")
+
+// Timestamp orders writes: by Lamport clock, then by client ID.
+type Timestamp struct {
+	Clock  uint64 `json:"clock"`
+	Client string `json:"client"`
+}
+
+// After reports whether t is later than o.
+func (t Timestamp) After(o Timestamp) bool {
+	if t.Clock != o.Clock {
+		return t.Clock > o.Clock
+	}
+	return t.Client > o.Client
+}
+
+type OpType string
+
+const (
+	// Add creates an item, or adds it again, with its fields and a slot.
+	Add OpType = "add"
+	// Set writes fields of an item.
+	Set OpType = "set"
+	// Remove removes the adds of an item listed in Observed.
+	Remove OpType = "remove"
+	// Move puts an item in a new slot.
+	Move OpType = "move"
+)
+
+// Op is an operation made by a client. ID must be unique across clients, such
+// as a UUID. The ID of an add is the tag a later remove observes, and the ID
+// of an add or move names the slot it creates. After is the slot that slot
+// follows, usually the current slot of the item in front, or "" for the start.
+type Op struct {
+	ID       string                     `json:"id"`
+	Type     OpType                     `json:"type"`
+	Item     string                     `json:"item"`
+	Clock    uint64                     `json:"clock"`
+	Client   string                     `json:"client"`
+	Fields   map[string]json.RawMessage `json:"fields,omitempty"`
+	After    string                     `json:"after,omitempty"`
+	Observed []string                   `json:"observed,omitempty"`
+}
+
+func (op Op) at() Timestamp {
+	return Timestamp{Clock: op.Clock, Client: op.Client}
+}
+
+// Register is a last-writer-wins value.
+type Register struct {
+	Value json.RawMessage `json:"value"`
+	At    Timestamp       `json:"at"`
+}
+
+// set keeps the later write. Writes with equal timestamps keep the larger
+// value so that replicas agree whichever they saw first.
+func (r *Register) set(value json.RawMessage, at Timestamp) {
+	if at.After(r.At) || (at == r.At && bytes.Compare(value, r.Value) > 0) {
+		r.Value, r.At = value, at
+	}
+}
+
+// Slot is a place in the order, following the slot After. Slots are never
+// removed; a slot whose item moved on or was removed is skipped.
+type Slot struct {
+	Item  string    `json:"item"`
+	After string    `json:"after"`
+	At    Timestamp `json:"at"`
+}
+
+// Position is the last-writer-wins slot of an item.
+type Position struct {
+	Slot string    `json:"slot"`
+	At   Timestamp `json:"at"`
+}
+
+func (p *Position) set(slot string, at Timestamp) {
+	if at.After(p.At) || (at == p.At && slot > p.Slot) {
+		p.Slot, p.At = slot, at
+	}
+}
+
+// Element is the replicated state of one item. Removed items are kept as
+// tombstones so that late operations still find them.
+type Element struct {
+	Tags     map[string]bool     `json:"tags"`
+	Removed  map[string]bool     `json:"removed"`
+	Fields   map[string]Register `json:"fields"`
+	Position Position            `json:"position"`
+}
+
+func (e *Element) alive() bool {
+	for tag := range e.Tags {
+		if !e.Removed[tag] {
+			return true
+		}
+	}
+	return false
+}
+
+// Document is the replicated state of a collection.
+type Document struct {
+	Elements map[string]*Element `json:"elements"`
+	Slots    map[string]Slot     `json:"slots"`
+	// Clock is the highest clock applied; a client's next operation should
+	// carry a larger one.
+	Clock uint64 `json:"clock"`
+}
+
+func New() *Document {
+	return &Document{Elements: make(map[string]*Element), Slots: make(map[string]Slot)}
+}
+
+// Apply merges an operation into the document. Applying an operation twice
+// has no further effect.
+func (d *Document) Apply(op Op) error {
+	if op.ID == "" || op.Item == "" || op.Client == "" {
+		return fmt.Errorf("%w: id, item and client are required", ErrInvalidOp)
+	}
+	switch op.Type {
+	case Add, Set, Remove, Move:
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidOp, op.Type)
+	}
+
+	e := d.element(op.Item)
+	at := op.at()
+	switch op.Type {
+	case Add:
+		e.Tags[op.ID] = true
+	case Remove:
+		for _, tag := range op.Observed {
+			e.Removed[tag] = true
+		}
+	}
+	if op.Type == Add || op.Type == Move {
+		d.Slots[op.ID] = Slot{Item: op.Item, After: op.After, At: at}
+		e.Position.set(op.ID, at)
+	}
+	if op.Type == Add || op.Type == Set {
+		for name, value := range op.Fields {
+			field := e.Fields[name]
+			field.set(value, at)
+			e.Fields[name] = field
+		}
+	}
+	d.Clock = max(d.Clock, op.Clock)
+
+	return nil
+}
+
+func (d *Document) element(item string) *Element {
+	e, ok := d.Elements[item]
+	if !ok {
+		e = &Element{
+			Tags:    make(map[string]bool),
+			Removed: make(map[string]bool),
+			Fields:  make(map[string]Register),
+		}
+		d.Elements[item] = e
+	}
+	return e
+}
+
+// Alive reports whether the item has an add that no remove has observed.
+func (d *Document) Alive(item string) bool {
+	e, ok := d.Elements[item]
+	return ok && e.alive()
+}
+
+// Tags returns the item's adds that are not removed, which a remove has to
+// observe to remove the item.
+func (d *Document) Tags(item string) []string {
+	e, ok := d.Elements[item]
+	if !ok {
+		return nil
+	}
+
+	var tags []string
+	for tag := range e.Tags {
+		if !e.Removed[tag] {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Slot returns the item's current slot, which an op placing another item
+// right behind it names as After.
+func (d *Document) Slot(item string) string {
+	e, ok := d.Elements[item]
+	if !ok {
+		return ""
+	}
+	return e.Position.Slot
+}
+
+// Field returns the current value of an item's field.
+func (d *Document) Field(item, name string) (json.RawMessage, bool) {
+	e, ok := d.Elements[item]
+	if !ok {
+		return nil, false
+	}
+	field, ok := e.Fields[name]
+	return field.Value, ok
+}
+
+// Order returns the items that are alive, in order. The slots form a tree
+// rooted at the start: each slot follows its After slot, with the most
+// recently created first when several follow the same one, and an unknown
+// After stands for the start. Items are listed at their current slot. Slots
+// whose Afters form a cycle are not reachable from the start; the most
+// recently created of them is put at the end and the rest follow from there.
+func (d *Document) Order() []string {
+	children := make(map[string][]string)
+	for id, slot := range d.Slots {
+		after := slot.After
+		if _, ok := d.Slots[after]; !ok {
+			after = ""
+		}
+		children[after] = append(children[after], id)
+	}
+	for _, ids := range children {
+		d.sortNewestFirst(ids)
+	}
+
+	order := make([]string, 0, len(d.Elements))
+	visited := make(map[string]bool, len(d.Slots))
+	var visit func(id string)
+	visit = func(id string) {
+		if visited[id] {
+			return
+		}
+		visited[id] = true
+		slot := d.Slots[id]
+		if e := d.Elements[slot.Item]; e.Position.Slot == id && e.alive() {
+			order = append(order, slot.Item)
+		}
+		for _, child := range children[id] {
+			visit(child)
+		}
+	}
+
+	for _, child := range children[""] {
+		visit(child)
+	}
+	for len(visited) < len(d.Slots) {
+		var stranded []string
+		for id := range d.Slots {
+			if !visited[id] {
+				stranded = append(stranded, id)
+			}
+		}
+		d.sortNewestFirst(stranded)
+		visit(stranded[0])
+	}
+
+	return order
+}
+
+func (d *Document) sortNewestFirst(ids []string) {
+	sort.Slice(ids, func(i, j int) bool {
+		a, b := d.Slots[ids[i]].At, d.Slots[ids[j]].At
+		if a != b {
+			return a.After(b)
+		}
+		return ids[i] > ids[j]
+	})
+}
@@ -0,0 +1,266 @@
+package crdt
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+func apply(t *testing.T, d *Document, ops ...Op) {
+	t.Helper()
+	for _, op := range ops {
+		if err := d.Apply(op); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func field(value string) map[string]json.RawMessage {
+	return map[string]json.RawMessage{"name": json.RawMessage(fmt.Sprintf("%q", value))}
+}
+
+// history is a random run of two or three clients editing a few items
+// concurrently, with no client seeing the others' operations until it is done.
+type history struct {
+	Ops []Op
+}
+
+func (history) Generate(r *rand.Rand, size int) reflect.Value {
+	items := []string{"a", "b", "c", "d"}
+	clients := []string{"phone", "tablet", "web"}[:2+r.Intn(2)]
+
+	var h history
+	slots := []string{""}
+	for _, client := range clients {
+		var clock uint64
+		var tags []string
+		for n := r.Intn(size + 1); n > 0; n-- {
+			clock += uint64(1 + r.Intn(2))
+			op := Op{
+				ID:     fmt.Sprintf("%s-%d", client, clock),
+				Item:   items[r.Intn(len(items))],
+				Clock:  clock,
+				Client: client,
+			}
+			switch r.Intn(4) {
+			case 0:
+				op.Type = Add
+				op.Fields = field(fmt.Sprintf("%s %d", client, clock))
+				op.After = slots[r.Intn(len(slots))]
+				tags = append(tags, op.ID)
+				slots = append(slots, op.ID)
+			case 1:
+				op.Type = Set
+				op.Fields = field(fmt.Sprintf("%s %d", client, clock))
+			case 2:
+				op.Type = Remove
+				for _, tag := range tags {
+					if r.Intn(2) == 0 {
+						op.Observed = append(op.Observed, tag)
+					}
+				}
+			case 3:
+				op.Type = Move
+				op.After = slots[r.Intn(len(slots))]
+				slots = append(slots, op.ID)
+			}
+			h.Ops = append(h.Ops, op)
+		}
+	}
+
+	return reflect.ValueOf(h)
+}
+
+// replay applies the ops in a random order, delivering some of them twice.
+func replay(ops []Op, seed int64) (*Document, error) {
+	r := rand.New(rand.NewSource(seed))
+	shuffled := append([]Op(nil), ops...)
+	for _, op := range ops {
+		if r.Intn(4) == 0 {
+			shuffled = append(shuffled, op)
+		}
+	}
+	r.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+
+	d := New()
+	for _, op := range shuffled {
+		if err := d.Apply(op); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func TestConvergence(t *testing.T) {
+	converges := func(h history, seed1, seed2 int64) bool {
+		d1, err := replay(h.Ops, seed1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d2, err := replay(h.Ops, seed2)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		state1, _ := json.Marshal(d1)
+		state2, _ := json.Marshal(d2)
+		return string(state1) == string(state2) && reflect.DeepEqual(d1.Order(), d2.Order())
+	}
+
+	if err := quick.Check(converges, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestOrderListsEveryLiveItemOnce(t *testing.T) {
+	complete := func(h history, seed int64) bool {
+		d, err := replay(h.Ops, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		seen := make(map[string]bool)
+		for _, id := range d.Order() {
+			if seen[id] || !d.Alive(id) {
+				return false
+			}
+			seen[id] = true
+		}
+		for id := range d.Elements {
+			if d.Alive(id) && !seen[id] {
+				return false
+			}
+		}
+		return true
+	}
+
+	if err := quick.Check(complete, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLastWriterWins(t *testing.T) {
+	d := New()
+	apply(t, d,
+		Op{ID: "1", Type: Add, Item: "milk", Clock: 1, Client: "phone", Fields: field("milk")},
+		Op{ID: "3", Type: Set, Item: "milk", Clock: 3, Client: "phone", Fields: field("oat milk")},
+		Op{ID: "2", Type: Set, Item: "milk", Clock: 3, Client: "tablet", Fields: field("soy milk")},
+		Op{ID: "4", Type: Set, Item: "milk", Clock: 2, Client: "web", Fields: field("whole milk")},
+	)
+
+	got, _ := d.Field("milk", "name")
+	if string(got) != `"soy milk"` {
+		t.Errorf("name = %s, want the write from tablet, which wins the clock tie", got)
+	}
+	if d.Clock != 3 {
+		t.Errorf("Clock = %d, want 3", d.Clock)
+	}
+}
+
+func TestAddWins(t *testing.T) {
+	d := New()
+	apply(t, d,
+		Op{ID: "add-1", Type: Add, Item: "eggs", Clock: 1, Client: "phone", Fields: field("eggs")},
+		// The tablet removes the eggs it knows of while the phone adds them again.
+		Op{ID: "add-2", Type: Add, Item: "eggs", Clock: 2, Client: "phone", Fields: field("eggs")},
+		Op{ID: "remove", Type: Remove, Item: "eggs", Clock: 5, Client: "tablet", Observed: []string{"add-1"}},
+	)
+	if !d.Alive("eggs") {
+		t.Fatal("concurrent add lost to remove")
+	}
+	if got := d.Tags("eggs"); !reflect.DeepEqual(got, []string{"add-2"}) {
+		t.Errorf("Tags() = %v, want [add-2]", got)
+	}
+
+	apply(t, d, Op{ID: "remove-2", Type: Remove, Item: "eggs", Clock: 6, Client: "tablet", Observed: []string{"add-2"}})
+	if d.Alive("eggs") {
+		t.Error("item alive after every add was removed")
+	}
+}
+
+func TestRemoveBeforeAdd(t *testing.T) {
+	d := New()
+	apply(t, d,
+		Op{ID: "remove", Type: Remove, Item: "eggs", Clock: 2, Client: "tablet", Observed: []string{"add"}},
+		Op{ID: "add", Type: Add, Item: "eggs", Clock: 1, Client: "phone", Fields: field("eggs")},
+	)
+	if d.Alive("eggs") {
+		t.Error("remove delivered before its add did not remove the item")
+	}
+}
+
+func TestOrder(t *testing.T) {
+	d := New()
+	apply(t, d,
+		Op{ID: "1", Type: Add, Item: "a", Clock: 1, Client: "phone"},
+		Op{ID: "2", Type: Add, Item: "b", Clock: 2, Client: "phone", After: "1"},
+		Op{ID: "3", Type: Add, Item: "c", Clock: 3, Client: "phone", After: "2"},
+		// Both devices insert after a; the later insert comes first.
+		Op{ID: "4", Type: Add, Item: "x", Clock: 4, Client: "phone", After: "1"},
+		Op{ID: "5", Type: Add, Item: "y", Clock: 4, Client: "tablet", After: "1"},
+		Op{ID: "6", Type: Remove, Item: "b", Clock: 5, Client: "phone", Observed: []string{"2"}},
+	)
+
+	// c stays behind the removed b.
+	want := []string{"a", "y", "x", "c"}
+	if got := d.Order(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+
+	// Moving a behind c leaves the items that were inserted behind a in place.
+	apply(t, d, Op{ID: "7", Type: Move, Item: "a", Clock: 6, Client: "phone", After: d.Slot("c")})
+	want = []string{"y", "x", "c", "a"}
+	if got := d.Order(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() after move = %v, want %v", got, want)
+	}
+}
+
+func TestConcurrentMoves(t *testing.T) {
+	d := New()
+	apply(t, d,
+		Op{ID: "1", Type: Add, Item: "a", Clock: 1, Client: "phone"},
+		Op{ID: "2", Type: Add, Item: "b", Clock: 2, Client: "phone", After: "1"},
+		// Concurrently, the phone moves a behind b and the tablet b behind a.
+		Op{ID: "3", Type: Move, Item: "a", Clock: 3, Client: "phone", After: "2"},
+		Op{ID: "4", Type: Move, Item: "b", Clock: 3, Client: "tablet", After: "1"},
+	)
+
+	// b goes behind the slot a left, and a behind the one b left.
+	want := []string{"b", "a"}
+	if got := d.Order(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderBreaksCycles(t *testing.T) {
+	d := New()
+	apply(t, d,
+		// Slots that follow each other can only come from forged IDs.
+		Op{ID: "1", Type: Add, Item: "a", Clock: 1, Client: "phone", After: "2"},
+		Op{ID: "2", Type: Add, Item: "b", Clock: 2, Client: "phone", After: "1"},
+	)
+
+	want := []string{"b", "a"}
+	if got := d.Order(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Order() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyInvalid(t *testing.T) {
+	d := New()
+	for _, op := range []Op{
+		{Type: Add, Item: "a", Client: "phone"},
+		{ID: "1", Type: Add, Client: "phone"},
+		{ID: "1", Type: Add, Item: "a"},
+		{ID: "1", Type: "rename", Item: "a", Client: "phone"},
+	} {
+		if err := d.Apply(op); err == nil {
+			t.Errorf("Apply(%+v) succeeded", op)
+		}
+	}
+}
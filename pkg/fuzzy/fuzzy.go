@@ -0,0 +1,95 @@
+// Package fuzzy compares short strings such as item names that may be
+// abbreviated, misspelled or differently inflected.
+package fuzzy
+
+import (
+	"golang.org/x/text/unicode/norm"
+	"strings"
+	"unicode"
+)
+
+// Trigram returns how similar a and b are, from 0 for nothing in common to 1
+// for the same words. It follows PostgreSQL's pg_trgm: case is ignored, each
+// word is padded with two spaces in front and one behind, and the result is
+// the number of three-letter sequences the two share divided by the number
+// they have between them.
+func Trigram(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for t := range ta {
+		if _, ok := tb[t]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+// Similarity is Trigram on folded names, so that names differing only in case,
+// accents or being plural are the same.
+func Similarity(a, b string) float64 {
+	fa, fb := Fold(a), Fold(b)
+	if fa != "" && fa == fb {
+		return 1
+	}
+	return Trigram(fa, fb)
+}
+
+// Fold reduces a name to what identifies the item: lower case words without
+// diacritics or punctuation, each stripped of an English plural ending, so
+// that "Tomatoes", "tomatos" and "tomato" all fold to "tomato". Singulars are
+// not always right, such as "cooky" for "cookies", but every form of a word
+// folds alike.
+func Fold(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+
+	words := strings.FieldsFunc(norm.NFC.String(b.String()), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, word := range words {
+		words[i] = singular(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// singular drops the plural ending of an English word of more than three
+// letters.
+func singular(word string) string {
+	if len([]rune(word)) <= 3 {
+		return word
+	}
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"),
+		strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
@@ -0,0 +1,79 @@
+package fuzzy
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTrigram(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want float64
+	}{
+		{"milk", "milk", 1},
+		{"Milk", "MILK", 1},
+		{"milk", "bread", 0},
+		{"", "milk", 0},
+		// pg_trgm: similarity('word', 'two words') = 0.363636
+		{"word", "two words", 0.363636},
+		// pg_trgm: similarity('cat', 'cats') = 0.5
+		{"cat", "cats", 0.5},
+		{"молоко", "молока", 0.555556},
+	}
+
+	for _, tc := range cases {
+		got := Trigram(tc.a, tc.b)
+		if math.Abs(got-tc.want) > 1e-6 {
+			t.Errorf("Trigram(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	cases := []struct {
+		name, want string
+	}{
+		{"Tomatoes", "tomato"},
+		{"tomatos", "tomato"},
+		{"tomato", "tomato"},
+		{"Jalapeños", "jalapeno"},
+		{"Crème fraîche", "creme fraiche"},
+		{"  Whole-Milk ", "whole milk"},
+		{"berries", "berry"},
+		{"peaches", "peach"},
+		{"glasses", "glass"},
+		{"Hummus", "hummus"},
+		{"eggs", "egg"},
+		{"peas", "pea"},
+		{"gas", "gas"},
+		{"ёжики", "ежики"},
+	}
+
+	for _, tc := range cases {
+		if got := Fold(tc.name); got != tc.want {
+			t.Errorf("Fold(%q) = %q, want %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want float64
+	}{
+		{"tomatos", "Tomatoes", 1},
+		{"jalapeno", "Jalapeños", 1},
+		{"milk", "bread", 0},
+		{"", "", 0},
+		{"cat", "cats", 1},
+		// "words" folds to "word", which both then share
+		{"word", "two words", 0.555556},
+	}
+
+	for _, tc := range cases {
+		got := Similarity(tc.a, tc.b)
+		if math.Abs(got-tc.want) > 1e-6 {
+			t.Errorf("Similarity(%q, %q) = %v, want %v", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+// Package gtin validates the barcodes printed on products. EAN-13, UPC-A and
+// EAN-8 codes are all GTINs: digits ending in a check digit.
+package gtin
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidCharacter = errors.New("GTIN must only have digits, spaces and hyphens")
+	ErrInvalidLength    = errors.New("GTIN must have 8, 12 or 13 digits")
+	ErrInvalidChecksum  = errors.New("GTIN check digit does not match")
+)
+
+// Normalize validates a barcode and returns it in the form products are
+// catalogued under: a UPC-A code becomes the EAN-13 code with a leading zero,
+// EAN-13 and EAN-8 codes stay as they are. Spaces and hyphens are ignored.
+func Normalize(code string) (string, error) {
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+	for _, r := range code {
+		if r < '0' || r > '9' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCharacter, code)
+		}
+	}
+
+	switch len(code) {
+	case 8, 13:
+	case 12:
+		code = "0" + code
+	default:
+		return "", fmt.Errorf("%w: %q", ErrInvalidLength, code)
+	}
+
+	if CheckDigit(code[:len(code)-1]) != code[len(code)-1] {
+		return "", fmt.Errorf("%w: %q", ErrInvalidChecksum, code)
+	}
+	return code, nil
+}
+
+// CheckDigit returns the check digit that completes the given digits. Going
+// from the right, the digits are weighted 3, 1, 3, 1 and so on; the check
+// digit brings their sum up to a multiple of ten.
+func CheckDigit(digits string) byte {
+	sum := 0
+	for i := 0; i < len(digits); i++ {
+		d := int(digits[len(digits)-1-i] - '0')
+		if i%2 == 0 {
+			d *= 3
+		}
+		sum += d
+	}
+	return byte('0' + (10-sum%10)%10)
+}
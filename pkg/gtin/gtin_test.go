@@ -0,0 +1,71 @@
+package gtin
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		code string
+		want string
+	}{
+		{"4006381333931", "4006381333931"}, // EAN-13
+		{"036000291452", "0036000291452"},  // UPC-A
+		{"96385074", "96385074"},           // EAN-8
+		{"400 6381 333931", "4006381333931"},
+		{"0-36000-29145-2", "0036000291452"},
+	}
+
+	for _, tc := range cases {
+		got, err := Normalize(tc.code)
+		if err != nil {
+			t.Errorf("Normalize(%q) error = %v", tc.code, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tc.code, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeInvalid(t *testing.T) {
+	cases := []struct {
+		code string
+		want error
+	}{
+		{"4006381333932", ErrInvalidChecksum},
+		{"036000291453", ErrInvalidChecksum},
+		{"96385075", ErrInvalidChecksum},
+		{"", ErrInvalidLength},
+		{"12345", ErrInvalidLength},
+		{"40063813339310", ErrInvalidLength},
+		{"40063813339a1", ErrInvalidCharacter},
+		{"4006381abc", ErrInvalidCharacter},
+		{"4006381333931.", ErrInvalidCharacter},
+	}
+
+	for _, tc := range cases {
+		if _, err := Normalize(tc.code); !errors.Is(err, tc.want) {
+			t.Errorf("Normalize(%q) error = %v, want %v", tc.code, err, tc.want)
+		}
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	cases := []struct {
+		digits string
+		want   byte
+	}{
+		{"400638133393", '1'},
+		{"03600029145", '2'},
+		{"9638507", '4'},
+		{"000000000000", '0'},
+	}
+
+	for _, tc := range cases {
+		if got := CheckDigit(tc.digits); got != tc.want {
+			t.Errorf("CheckDigit(%q) = %c, want %c", tc.digits, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Package itemparser turns free-form item entries such as "2x 1.5l milk",
+// "a dozen eggs" or "3 bananas (ripe)" into a name, a quantity and a note.
+// English and Ukrainian number words and units are understood.
+package itemparser
+
+import (
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var ErrNoName = errors.New("item name is missing")
+
+type Result struct {
+	Name     string            `json:"name"`
+	Quantity quantity.Quantity `json:"quantity"`
+	Note     string            `json:"note,omitempty"`
+}
+
+var (
+	notePattern       = regexp.MustCompile(`\(([^)]*)\)`)
+	numberPattern     = regexp.MustCompile(`^\d+(?:[.,]\d+)?$|^\d+/\d+$`)
+	countFirstPattern = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)([xх×])$`)
+	countLastPattern  = regexp.MustCompile(`^([xх×])(\d+(?:[.,]\d+)?)$`)
+	numberUnitPattern = regexp.MustCompile(`^(\d+(?:[.,]\d+)?)(\pL+)$`)
+	apostrophes       = strings.NewReplacer("’", "'", "ʼ", "'", "`", "'")
+)
+
+type token struct {
+	raw  string // as typed, used for the item name
+	word string // lower-cased, used for matching
+}
+
+// amount is what was read in front of or behind the name: count packs of
+// value unit each.
+type amount struct {
+	count float64
+	value float64
+	unit  quantity.Unit
+}
+
+// Parse reads an item entry. Text in parentheses and anything after the first
+// ", " becomes the note. Without an amount the quantity is one piece.
+func Parse(input string) (Result, error) {
+	text, notes := extractNotes(apostrophes.Replace(input))
+	tokens := tokenize(text)
+
+	amt, rest, ok := parseLeading(tokens)
+	if !ok {
+		amt, rest, ok = parseTrailing(tokens)
+	}
+	if !ok {
+		amt, rest = amount{count: 1, value: 1, unit: quantity.Pieces}, tokens
+	}
+
+	words := make([]string, 0, len(rest))
+	for _, t := range rest {
+		words = append(words, t.raw)
+	}
+	name := strings.Join(words, " ")
+	if name == "" {
+		return Result{}, ErrNoName
+	}
+
+	qty := quantity.New(amt.value, amt.unit).Scale(amt.count)
+	if err := qty.Validate(); err != nil {
+		return Result{}, err
+	}
+	if amt.count != 1 && amt.unit != quantity.Pieces && amt.unit != quantity.Pack {
+		// Keep the pack size visible: "2x 1.5l milk" is 3 l bought as two bottles.
+		size := quantity.New(amt.value, amt.unit)
+		notes = append([]string{strconv.FormatFloat(amt.count, 'f', -1, 64) + " × " + size.String()}, notes...)
+	}
+
+	return Result{
+		Name:     name,
+		Quantity: qty,
+		Note:     strings.Join(notes, "; "),
+	}, nil
+}
+
+func extractNotes(input string) (string, []string) {
+	var notes []string
+	for _, match := range notePattern.FindAllStringSubmatch(input, -1) {
+		if note := strings.TrimSpace(match[1]); note != "" {
+			notes = append(notes, note)
+		}
+	}
+	text := notePattern.ReplaceAllString(input, " ")
+
+	if before, after, found := strings.Cut(text, ", "); found {
+		text = before
+		if note := strings.TrimSpace(after); note != "" {
+			notes = append(notes, note)
+		}
+	}
+
+	return text, notes
+}
+
+// tokenize splits input on whitespace and pulls apart amounts written without
+// a space, such as "2x", "x2", "1.5l" or "500г".
+func tokenize(input string) []token {
+	var tokens []token
+	add := func(raw string) {
+		tokens = append(tokens, token{raw: raw, word: strings.ToLower(raw)})
+	}
+
+	for _, field := range strings.Fields(input) {
+		if m := countFirstPattern.FindStringSubmatch(strings.ToLower(field)); m != nil {
+			add(m[1])
+			add(m[2])
+			continue
+		}
+		if m := countLastPattern.FindStringSubmatch(strings.ToLower(field)); m != nil {
+			add(m[1])
+			add(m[2])
+			continue
+		}
+		if m := numberUnitPattern.FindStringSubmatch(field); m != nil {
+			if _, ok := lookupUnit(strings.ToLower(m[2])); ok {
+				add(m[1])
+				add(m[2])
+				continue
+			}
+		}
+		add(field)
+	}
+
+	return tokens
+}
+
+// parseLeading reads "[count x] amount [unit] [of]" at the start of the entry.
+func parseLeading(tokens []token) (amount, []token, bool) {
+	value, i, ok := readNumber(tokens, 0)
+	if !ok {
+		return amount{}, nil, false
+	}
+
+	amt := amount{count: 1, value: value, unit: quantity.Pieces}
+	if i < len(tokens) && multiplierWords[tokens[i].word] {
+		i++
+		if size, next, ok := readNumber(tokens, i); ok {
+			amt.count, amt.value, i = value, size, next
+		}
+	}
+	if i < len(tokens) {
+		if unit, ok := lookupUnit(tokens[i].word); ok {
+			amt.unit = unit
+			i++
+		}
+	}
+	for i < len(tokens) && fillerWords[tokens[i].word] {
+		i++
+	}
+
+	return amt, tokens[i:], true
+}
+
+// parseTrailing reads "name amount [unit]" or "name x count".
+func parseTrailing(tokens []token) (amount, []token, bool) {
+	n := len(tokens)
+	if n < 2 {
+		return amount{}, nil, false
+	}
+
+	if unit, ok := lookupUnit(tokens[n-1].word); ok && n >= 3 {
+		if value, ok := parseNumber(tokens[n-2].word); ok {
+			return amount{count: 1, value: value, unit: unit}, tokens[:n-2], true
+		}
+	}
+
+	value, ok := parseNumber(tokens[n-1].word)
+	if !ok {
+		return amount{}, nil, false
+	}
+	rest := tokens[:n-1]
+	if multiplierWords[rest[len(rest)-1].word] {
+		rest = rest[:len(rest)-1]
+	}
+
+	return amount{count: 1, value: value, unit: quantity.Pieces}, rest, true
+}
+
+// readNumber reads a numeric amount or a run of number words starting at i,
+// such as "2", "1/2", "½", "a dozen", "half a dozen" or "півтора".
+func readNumber(tokens []token, i int) (float64, int, bool) {
+	if i >= len(tokens) {
+		return 0, i, false
+	}
+	word := tokens[i].word
+
+	value, ok := parseNumber(word)
+	if !ok {
+		value, ok = numberWords[word]
+	}
+	if !ok {
+		if group, isGroup := groupWords[word]; isGroup {
+			return group, i + 1, true
+		}
+		return 0, i, false
+	}
+	i++
+
+	switch {
+	case word == "half" && i < len(tokens) && (tokens[i].word == "a" || tokens[i].word == "an"):
+		i++ // "half a dozen", "half a kilo"
+	case (word == "a" || word == "an") && i < len(tokens):
+		// "a couple of": the article only introduces the amount.
+		if next := tokens[i].word; next != "a" && next != "an" {
+			if n, isNumber := numberWords[next]; isNumber {
+				value, i = n, i+1
+			}
+		}
+	}
+
+	if i < len(tokens) {
+		if group, isGroup := groupWords[tokens[i].word]; isGroup {
+			return value * group, i + 1, true
+		}
+	}
+
+	return value, i, true
+}
+
+func parseNumber(word string) (float64, bool) {
+	if value, ok := fractions[word]; ok {
+		return value, true
+	}
+	if !numberPattern.MatchString(word) {
+		return 0, false
+	}
+
+	if num, den, isFraction := strings.Cut(word, "/"); isFraction {
+		n, _ := strconv.ParseFloat(num, 64)
+		d, _ := strconv.ParseFloat(den, 64)
+		if d == 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+
+	value, err := strconv.ParseFloat(strings.Replace(word, ",", ".", 1), 64)
+	return value, err == nil && value > 0
+}
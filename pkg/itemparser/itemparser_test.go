@@ -0,0 +1,59 @@
+package itemparser
+
+import (
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		input string
+		want  Result
+	}{
+		// English
+		{"milk", Result{Name: "milk", Quantity: quantity.New(1, quantity.Pieces)}},
+		{"2x 1.5l milk", Result{Name: "milk", Quantity: quantity.New(3, quantity.Liter), Note: "2 × 1.5 l"}},
+		{"2 x 1.5 l milk", Result{Name: "milk", Quantity: quantity.New(3, quantity.Liter), Note: "2 × 1.5 l"}},
+		{"a dozen eggs", Result{Name: "eggs", Quantity: quantity.New(12, quantity.Pieces)}},
+		{"half a dozen eggs", Result{Name: "eggs", Quantity: quantity.New(6, quantity.Pieces)}},
+		{"two dozen eggs", Result{Name: "eggs", Quantity: quantity.New(24, quantity.Pieces)}},
+		{"3 bananas (ripe)", Result{Name: "bananas", Quantity: quantity.New(3, quantity.Pieces), Note: "ripe"}},
+		{"500g of flour", Result{Name: "flour", Quantity: quantity.New(500, quantity.Gram)}},
+		{"half a kilo Flour", Result{Name: "Flour", Quantity: quantity.New(0.5, quantity.Kilogram)}},
+		{"a couple of lemons", Result{Name: "lemons", Quantity: quantity.New(2, quantity.Pieces)}},
+		{"2 packs butter, unsalted", Result{Name: "butter", Quantity: quantity.New(2, quantity.Pack), Note: "unsalted"}},
+		{"1/2 kg cheese", Result{Name: "cheese", Quantity: quantity.New(0.5, quantity.Kilogram)}},
+		{"Orange juice 1,5l", Result{Name: "Orange juice", Quantity: quantity.New(1.5, quantity.Liter)}},
+		{"eggs x12", Result{Name: "eggs", Quantity: quantity.New(12, quantity.Pieces)}},
+		{"7up", Result{Name: "7up", Quantity: quantity.New(1, quantity.Pieces)}},
+
+		// Ukrainian
+		{"2 л молока", Result{Name: "молока", Quantity: quantity.New(2, quantity.Liter)}},
+		{"десяток яєць", Result{Name: "яєць", Quantity: quantity.New(10, quantity.Pieces)}},
+		{"півтора кг борошна", Result{Name: "борошна", Quantity: quantity.New(1.5, quantity.Kilogram)}},
+		{"дві пачки масла", Result{Name: "масла", Quantity: quantity.New(2, quantity.Pack)}},
+		{"п’ять яблук (зелених)", Result{Name: "яблук", Quantity: quantity.New(5, quantity.Pieces), Note: "зелених"}},
+		{"3х 500г сиру", Result{Name: "сиру", Quantity: quantity.New(1500, quantity.Gram), Note: "3 × 500 g"}},
+		{"пів кіло цукру", Result{Name: "цукру", Quantity: quantity.New(0.5, quantity.Kilogram)}},
+	}
+
+	for _, tc := range cases {
+		got, err := Parse(tc.input)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tc.input, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("Parse(%q) = %+v, want %+v", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestParseWithoutName(t *testing.T) {
+	for _, input := range []string{"", "  ", "2 kg", "a dozen", "(ripe)"} {
+		if _, err := Parse(input); !errors.Is(err, ErrNoName) {
+			t.Errorf("Parse(%q) error = %v, want ErrNoName", input, err)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+package itemparser
+
+import "github.com/PocketPalCo/shopping-service/pkg/quantity"
+
+// numberWords are spelled-out amounts in English and Ukrainian.
+var numberWords = map[string]float64{
+	// English
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12,
+	"half": 0.5, "couple": 2, "pair": 2,
+
+	// Ukrainian
+	"один": 1, "одна": 1, "одне": 1, "одну": 1, "два": 2, "дві": 2, "три": 3, "чотири": 4,
+	"п'ять": 5, "шість": 6, "сім": 7, "вісім": 8, "дев'ять": 9, "десять": 10,
+	"одинадцять": 11, "дванадцять": 12, "пів": 0.5, "половина": 0.5, "півтора": 1.5, "півтори": 1.5,
+	"пара": 2, "пару": 2,
+}
+
+// groupWords multiply the amount before them ("two dozen") or stand for an
+// amount on their own ("a dozen", "десяток").
+var groupWords = map[string]float64{
+	"dozen": 12, "dozens": 12,
+	"дюжина": 12, "дюжину": 12, "дюжини": 12,
+	"десяток": 10, "десятка": 10, "десятки": 10,
+}
+
+// fillerWords may sit between an amount or unit and the item name.
+var fillerWords = map[string]bool{
+	"of": true, "a": true, "an": true,
+}
+
+// multiplierWords separate a pack count from a pack size, as in "2 x 1.5l".
+var multiplierWords = map[string]bool{
+	"x": true, "×": true, "х": true, // the last one is Cyrillic
+}
+
+// unitWords adds Ukrainian unit names to the symbols and English names
+// understood by quantity.ParseUnit.
+var unitWords = map[string]quantity.Unit{
+	"шт": quantity.Pieces, "штука": quantity.Pieces, "штуки": quantity.Pieces, "штук": quantity.Pieces, "штуку": quantity.Pieces,
+	"г": quantity.Gram, "гр": quantity.Gram, "грам": quantity.Gram, "грами": quantity.Gram, "грамів": quantity.Gram,
+	"кг": quantity.Kilogram, "кіло": quantity.Kilogram, "кілограм": quantity.Kilogram, "кілограми": quantity.Kilogram, "кілограмів": quantity.Kilogram,
+	"мл": quantity.Milliliter, "мілілітр": quantity.Milliliter, "мілілітри": quantity.Milliliter, "мілілітрів": quantity.Milliliter,
+	"л": quantity.Liter, "літр": quantity.Liter, "літри": quantity.Liter, "літрів": quantity.Liter, "літра": quantity.Liter,
+	"уп": quantity.Pack, "упаковка": quantity.Pack, "упаковки": quantity.Pack, "упаковок": quantity.Pack, "упаковку": quantity.Pack,
+	"пачка": quantity.Pack, "пачки": quantity.Pack, "пачок": quantity.Pack, "пачку": quantity.Pack,
+}
+
+var fractions = map[string]float64{
+	"½": 0.5, "¼": 0.25, "¾": 0.75, "⅓": 1.0 / 3, "⅔": 2.0 / 3,
+}
+
+func lookupUnit(word string) (quantity.Unit, bool) {
+	if unit, ok := unitWords[word]; ok {
+		return unit, true
+	}
+	if multiplierWords[word] {
+		return "", false
+	}
+	unit, err := quantity.ParseUnit(word)
+	return unit, err == nil
+}
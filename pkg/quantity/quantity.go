@@ -0,0 +1,206 @@
+// Package quantity models item amounts as a value with a unit and converts
+// between units of the same dimension.
+package quantity
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+type Unit string
+
+const (
+	Pieces     Unit = "pcs"
+	Pack       Unit = "pack"
+	Gram       Unit = "g"
+	Kilogram   Unit = "kg"
+	Milliliter Unit = "ml"
+	Liter      Unit = "l"
+)
+
+// Dimension groups units that can be converted into each other.
+type Dimension string
+
+const (
+	Count  Dimension = "count"
+	Packs  Dimension = "packs"
+	Mass   Dimension = "mass"
+	Volume Dimension = "volume"
+)
+
+// MinValue and MaxValue bound the values a quantity can have: values are kept
+// to three decimals and below a billion.
+const (
+	MinValue = 0.001
+	MaxValue = 1e9
+)
+
+var (
+	ErrUnknownUnit   = errors.New("unknown unit")
+	ErrIncompatible  = errors.New("incompatible units")
+	ErrInvalidAmount = errors.New("quantity must be at least 0.001 and less than a billion")
+)
+
+type unitInfo struct {
+	dimension Dimension
+	factor    float64 // size of the unit in the dimension's base unit
+}
+
+// conversions maps every unit to its dimension and its size in the base unit
+// (pcs, pack, g, ml).
+var conversions = map[Unit]unitInfo{
+	Pieces:     {Count, 1},
+	Pack:       {Packs, 1},
+	Gram:       {Mass, 1},
+	Kilogram:   {Mass, 1000},
+	Milliliter: {Volume, 1},
+	Liter:      {Volume, 1000},
+}
+
+// promotions lists the larger unit a base unit is promoted to once the amount
+// reaches 1000 base units.
+var promotions = map[Unit]Unit{
+	Gram:       Kilogram,
+	Milliliter: Liter,
+}
+
+var aliases = map[string]Unit{
+	"pcs": Pieces, "pc": Pieces, "piece": Pieces, "pieces": Pieces, "x": Pieces,
+	"pack": Pack, "packs": Pack, "pk": Pack, "pkg": Pack, "package": Pack, "packages": Pack,
+	"g": Gram, "gr": Gram, "gram": Gram, "grams": Gram, "gramme": Gram, "grammes": Gram,
+	"kg": Kilogram, "kilo": Kilogram, "kilos": Kilogram, "kilogram": Kilogram, "kilograms": Kilogram,
+	"ml": Milliliter, "milliliter": Milliliter, "milliliters": Milliliter, "millilitre": Milliliter, "millilitres": Milliliter,
+	"l": Liter, "lt": Liter, "liter": Liter, "liters": Liter, "litre": Liter, "litres": Liter,
+}
+
+// ParseUnit resolves a unit symbol or English unit name, case-insensitively.
+func ParseUnit(s string) (Unit, error) {
+	unit, ok := aliases[strings.ToLower(strings.TrimSpace(s))]
+	if !ok {
+		return "", fmt.Errorf("%w: %q", ErrUnknownUnit, s)
+	}
+	return unit, nil
+}
+
+func (u Unit) Valid() bool {
+	_, ok := conversions[u]
+	return ok
+}
+
+func (u Unit) Dimension() Dimension {
+	return conversions[u].dimension
+}
+
+type Quantity struct {
+	Value float64 `json:"value"`
+	Unit  Unit    `json:"unit"`
+}
+
+// One is the quantity assumed when none is given.
+var One = Quantity{Value: 1, Unit: Pieces}
+
+func New(value float64, unit Unit) Quantity {
+	return Quantity{Value: value, Unit: unit}
+}
+
+// Validate checks the unit and that the value, rounded to three decimals, is
+// within MinValue and MaxValue. NaN is never within them.
+func (q Quantity) Validate() error {
+	if !q.Unit.Valid() {
+		return fmt.Errorf("%w: %q", ErrUnknownUnit, q.Unit)
+	}
+	if v := round(q.Value); !(v >= MinValue && v < MaxValue) {
+		return ErrInvalidAmount
+	}
+	return nil
+}
+
+// Compatible reports whether q and o measure the same dimension.
+func (q Quantity) Compatible(o Quantity) bool {
+	return q.Unit.Valid() && o.Unit.Valid() && q.Unit.Dimension() == o.Unit.Dimension()
+}
+
+// Convert expresses q in unit.
+func (q Quantity) Convert(unit Unit) (Quantity, error) {
+	to := Quantity{Unit: unit}
+	if !q.Compatible(to) {
+		return Quantity{}, fmt.Errorf("%w: %s and %s", ErrIncompatible, q.Unit, unit)
+	}
+	return Quantity{Value: round(q.Value * conversions[q.Unit].factor / conversions[unit].factor), Unit: unit}, nil
+}
+
+// Add sums two quantities of the same dimension. The result is expressed in
+// the larger of the two units, promoted to kg or l once it reaches 1000 g or
+// 1000 ml. Adding mass to volume, or pieces to packs, fails with ErrIncompatible,
+// and a sum that reaches MaxValue fails with ErrInvalidAmount.
+func (q Quantity) Add(o Quantity) (Quantity, error) {
+	if !q.Compatible(o) {
+		return Quantity{}, fmt.Errorf("%w: %s and %s", ErrIncompatible, q.Unit, o.Unit)
+	}
+
+	unit := q.Unit
+	if conversions[o.Unit].factor > conversions[unit].factor {
+		unit = o.Unit
+	}
+	base := q.Value*conversions[q.Unit].factor + o.Value*conversions[o.Unit].factor
+	if larger, ok := promotions[unit]; ok && base >= conversions[larger].factor {
+		unit = larger
+	}
+
+	sum := Quantity{Value: round(base / conversions[unit].factor), Unit: unit}
+	if !(sum.Value < MaxValue) {
+		return Quantity{}, ErrInvalidAmount
+	}
+	return sum, nil
+}
+
+// Sub takes o away from q and returns what is left in q's unit, or zero when
+// o is at least as much as q.
+func (q Quantity) Sub(o Quantity) (Quantity, error) {
+	if !q.Compatible(o) {
+		return Quantity{}, fmt.Errorf("%w: %s and %s", ErrIncompatible, q.Unit, o.Unit)
+	}
+
+	left := q.Value*conversions[q.Unit].factor - o.Value*conversions[o.Unit].factor
+	return Quantity{Value: max(round(left/conversions[q.Unit].factor), 0), Unit: q.Unit}, nil
+}
+
+// Compare returns -1, 0 or 1 as q is less than, equal to or greater than o.
+// Quantities of different dimensions fail with ErrIncompatible.
+func (q Quantity) Compare(o Quantity) (int, error) {
+	if !q.Compatible(o) {
+		return 0, fmt.Errorf("%w: %s and %s", ErrIncompatible, q.Unit, o.Unit)
+	}
+
+	a := round(q.Value * conversions[q.Unit].factor)
+	b := round(o.Value * conversions[o.Unit].factor)
+	switch {
+	case a < b:
+		return -1, nil
+	case a > b:
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// Scale multiplies q by factor, keeping its unit.
+func (q Quantity) Scale(factor float64) Quantity {
+	return Quantity{Value: round(q.Value * factor), Unit: q.Unit}
+}
+
+func (q Quantity) String() string {
+	return fmt.Sprintf("%s %s", formatValue(q.Value), q.Unit)
+}
+
+func formatValue(v float64) string {
+	s := fmt.Sprintf("%.3f", v)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// round drops floating point noise beyond three decimal places.
+func round(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
@@ -0,0 +1,171 @@
+package quantity
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b Quantity
+		want Quantity
+	}{
+		{"grams into kilograms", New(1, Kilogram), New(500, Gram), New(1.5, Kilogram)},
+		{"kilograms into grams", New(500, Gram), New(1, Kilogram), New(1.5, Kilogram)},
+		{"grams stay grams", New(200, Gram), New(300, Gram), New(500, Gram)},
+		{"grams promoted", New(800, Gram), New(300, Gram), New(1.1, Kilogram)},
+		{"millilitres into litres", New(1.5, Liter), New(250, Milliliter), New(1.75, Liter)},
+		{"pieces", New(2, Pieces), New(3, Pieces), New(5, Pieces)},
+		{"packs", New(1, Pack), New(1, Pack), New(2, Pack)},
+	}
+	for _, tc := range cases {
+		got, err := tc.a.Add(tc.b)
+		if err != nil {
+			t.Errorf("%s: Add() error = %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: Add() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestAddIncompatible(t *testing.T) {
+	cases := [][2]Quantity{
+		{New(1, Kilogram), New(1, Liter)},
+		{New(2, Pieces), New(1, Pack)},
+		{New(100, Gram), New(3, Pieces)},
+	}
+	for _, tc := range cases {
+		if _, err := tc[0].Add(tc[1]); !errors.Is(err, ErrIncompatible) {
+			t.Errorf("%v + %v: error = %v, want ErrIncompatible", tc[0], tc[1], err)
+		}
+	}
+}
+
+func TestAddOverflow(t *testing.T) {
+	cases := [][2]Quantity{
+		{New(MaxValue-1, Pieces), New(1, Pieces)},
+		{New(MaxValue-1, Kilogram), New(1000, Gram)},
+		{New(MaxValue-1, Pack), New(math.Inf(1), Pack)},
+	}
+	for _, tc := range cases {
+		if _, err := tc[0].Add(tc[1]); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("%v + %v: error = %v, want ErrInvalidAmount", tc[0], tc[1], err)
+		}
+	}
+	if got, err := New(MaxValue-2, Pieces).Add(New(1, Pieces)); err != nil || got.Value != MaxValue-1 {
+		t.Errorf("Add() just below MaxValue = %v, %v, want %v", got, err, MaxValue-1)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	got, err := New(1.25, Liter).Convert(Milliliter)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if want := New(1250, Milliliter); got != want {
+		t.Errorf("Convert() = %v, want %v", got, want)
+	}
+
+	if _, err := New(1, Kilogram).Convert(Milliliter); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Convert() error = %v, want ErrIncompatible", err)
+	}
+}
+
+func TestSub(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b Quantity
+		want Quantity
+	}{
+		{"grams from kilograms", New(1.5, Kilogram), New(500, Gram), New(1, Kilogram)},
+		{"litres from millilitres", New(1500, Milliliter), New(1, Liter), New(500, Milliliter)},
+		{"more than there is", New(2, Pieces), New(3, Pieces), New(0, Pieces)},
+	}
+	for _, tc := range cases {
+		got, err := tc.a.Sub(tc.b)
+		if err != nil {
+			t.Errorf("%s: Sub() error = %v", tc.name, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("%s: Sub() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	if _, err := New(1, Kilogram).Sub(New(1, Liter)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Sub() error = %v, want ErrIncompatible", err)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b Quantity
+		want int
+	}{
+		{New(500, Gram), New(1, Kilogram), -1},
+		{New(1, Liter), New(1000, Milliliter), 0},
+		{New(3, Pieces), New(2, Pieces), 1},
+	}
+	for _, tc := range cases {
+		got, err := tc.a.Compare(tc.b)
+		if err != nil || got != tc.want {
+			t.Errorf("Compare(%v, %v) = %d, %v, want %d", tc.a, tc.b, got, err, tc.want)
+		}
+	}
+
+	if _, err := New(1, Pack).Compare(New(1, Pieces)); !errors.Is(err, ErrIncompatible) {
+		t.Errorf("Compare() error = %v, want ErrIncompatible", err)
+	}
+}
+
+func TestParseUnit(t *testing.T) {
+	for in, want := range map[string]Unit{"KG": Kilogram, " litres ": Liter, "pc": Pieces, "gr": Gram} {
+		got, err := ParseUnit(in)
+		if err != nil || got != want {
+			t.Errorf("ParseUnit(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseUnit("cup"); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("ParseUnit(cup) error = %v, want ErrUnknownUnit", err)
+	}
+}
+
+func TestString(t *testing.T) {
+	if got := New(1.5, Kilogram).String(); got != "1.5 kg" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := New(2, Pieces).String(); got != "2 pcs" {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := []Quantity{New(0.001, Kilogram), New(0.0006, Gram), New(1, Pieces), New(999999999.999, Milliliter)}
+	for _, q := range valid {
+		if err := q.Validate(); err != nil {
+			t.Errorf("Validate(%v) = %v, want nil", q, err)
+		}
+	}
+
+	invalid := []Quantity{
+		New(0, Pieces),
+		New(-1, Pieces),
+		New(0.0004, Kilogram),
+		New(1e9, Gram),
+		New(999999999.9999, Gram),
+		New(math.Inf(1), Liter),
+		New(math.NaN(), Liter),
+	}
+	for _, q := range invalid {
+		if err := q.Validate(); !errors.Is(err, ErrInvalidAmount) {
+			t.Errorf("Validate(%v) = %v, want ErrInvalidAmount", q, err)
+		}
+	}
+	if err := New(1, "cup").Validate(); !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("Validate(1 cup) = %v, want ErrUnknownUnit", err)
+	}
+}
@@ -0,0 +1,56 @@
+package receipt
+
+import "regexp"
+
+// Generic reads receipts that print each item as its name followed by the line
+// total, as in "Milk 1.5l   1.29 A". A count or weight, as in "2 x 0.99" or
+// "0.842 kg x 1.49 EUR/kg", may follow on the same line or on the next one.
+const Generic = "generic"
+
+// UkrainianFiscal reads Ukrainian fiscal receipts, which print the count or
+// weight of an item on the line before its name, as in "2.000 X 38.50".
+const UkrainianFiscal = "ua-fiscal"
+
+// amount is a price such as "1.29" or "1,29".
+const amount = `\d+[.,]\d{2}`
+
+// totalPattern matches the total line of English, German and Ukrainian receipts.
+var totalPattern = regexp.MustCompile(
+	`(?i)^(?:total|sum|summe|zu zahlen|сума|разом|всього|до сплати)(?:\s|:|$)\D*(?P<price>` + amount + `)`)
+
+func init() {
+	mustRegister(Format{
+		Name: Generic,
+		Grammars: []Grammar{mustPattern(
+			`^(?P<name>.*?\D)(?:\s+(?:(?P<count>\d+)\s*[xX×*]\s*`+amount+`\s+)?(?P<price>`+amount+`)(?:\s*[A-Z€$£₴]{1,3})?)?$`,
+			`^(?:(?P<weight>\d+[.,]\d+)\s*(?P<unit>kg|g|кг|г)|(?P<count>\d+))\s*[xX×*]\s*`+amount+
+				`(?:\s*\S*/\S+)?(?:\s+=?\s*(?P<price>`+amount+`)(?:\s*[A-Z€$£₴]{1,3})?)?$`,
+			false,
+		)},
+		Total: totalPattern,
+	})
+
+	mustRegister(Format{
+		Name: UkrainianFiscal,
+		Grammars: []Grammar{mustPattern(
+			`^(?P<name>.*?\D)\s+(?P<price>`+amount+`)(?:\s*[АБВГДЕ])?$`,
+			`^(?P<count>\d+(?:[.,]\d+)?)\s*[xXхХ×*]\s*`+amount+`$`,
+			true,
+		)},
+		Total: totalPattern,
+	})
+}
+
+func mustPattern(item, detail string, detailFirst bool) *Pattern {
+	p, err := NewPattern(item, detail, detailFirst)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+func mustRegister(f Format) {
+	if err := Register(f); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,303 @@
+// Package receipt reads plain-text receipts, such as the OCR output of a
+// photo, into line items with a name, a quantity and the amount paid. Stores
+// print their receipts differently, so the layout of a store chain is a
+// Format made of grammars; formats are looked up by name and more can be
+// registered.
+package receipt
+
+import (
+	"errors"
+	"fmt"
+	"github.com/PocketPalCo/shopping-service/pkg/itemparser"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown receipt format")
+	ErrInvalidFormat = errors.New("invalid receipt format")
+)
+
+// Line is an item read from a receipt. Amount is the line total in the
+// currency's minor unit, such as cents. Quantity is nil when the receipt does
+// not say how much was bought.
+type Line struct {
+	Text     string             `json:"text"`
+	Name     string             `json:"name"`
+	Quantity *quantity.Quantity `json:"quantity,omitempty"`
+	Amount   int64              `json:"amount"`
+}
+
+// Receipt is what was read from a receipt. Ignored holds the lines that are
+// not items, such as the store's address or a discount. Total is the printed
+// total, when the format found one.
+type Receipt struct {
+	Lines   []Line   `json:"lines"`
+	Ignored []string `json:"ignored"`
+	Total   *int64   `json:"total,omitempty"`
+}
+
+// Grammar reads one way a store prints items. Match reads the item at the
+// start of lines and returns how many lines it spans, or 0 when the lines do
+// not start with an item.
+type Grammar interface {
+	Match(lines []string) (Line, int)
+}
+
+// Format is the layout of a store chain's receipts. Each line is read by the
+// first grammar that matches it. Reading stops at the line Total matches;
+// its price group is the receipt total.
+type Format struct {
+	Name     string
+	Grammars []Grammar
+	Total    *regexp.Regexp
+}
+
+// Parse reads a receipt. Blank lines are skipped.
+func (f Format) Parse(text string) Receipt {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	result := Receipt{Lines: []Line{}, Ignored: []string{}}
+	for i := 0; i < len(lines); {
+		if f.Total != nil {
+			if m := f.Total.FindStringSubmatch(lines[i]); m != nil {
+				if total, ok := parseAmount(group(f.Total, m, "price")); ok {
+					result.Total = &total
+				}
+				break
+			}
+		}
+
+		matched := false
+		for _, grammar := range f.Grammars {
+			if line, n := grammar.Match(lines[i:]); n > 0 {
+				result.Lines = append(result.Lines, line)
+				i += n
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			result.Ignored = append(result.Ignored, lines[i])
+			i++
+		}
+	}
+
+	return result
+}
+
+var (
+	formatsMu sync.RWMutex
+	formats   = make(map[string]Format)
+)
+
+// Register makes a format available to Lookup under its name, replacing any
+// format registered with the same name.
+func Register(f Format) error {
+	if f.Name == "" || len(f.Grammars) == 0 {
+		return fmt.Errorf("%w: a format needs a name and at least one grammar", ErrInvalidFormat)
+	}
+
+	formatsMu.Lock()
+	defer formatsMu.Unlock()
+	formats[f.Name] = f
+	return nil
+}
+
+func Lookup(name string) (Format, error) {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	f, ok := formats[name]
+	if !ok {
+		return Format{}, fmt.Errorf("%w %q", ErrUnknownFormat, name)
+	}
+	return f, nil
+}
+
+// Formats returns the names of the registered formats, sorted.
+func Formats() []string {
+	formatsMu.RLock()
+	defer formatsMu.RUnlock()
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// weightUnits are the units a weight group may be followed by; weights
+// without one are in kilograms.
+var weightUnits = map[string]quantity.Unit{
+	"kg": quantity.Kilogram, "кг": quantity.Kilogram,
+	"g": quantity.Gram, "г": quantity.Gram,
+}
+
+// Pattern is a Grammar built from regular expressions with named groups:
+//
+//	name    the item name, which may include a pack size such as "1.5l"
+//	price   the line total
+//	count   how many were bought, as in "3 x 0.99"; a fraction is a weight in kg
+//	weight  a weighed amount, with its unit in the unit group, as in "0.842 kg"
+//
+// Item matches a line on its own. Detail, when set, matches a line that
+// carries the item's count or weight and, when the item line has no price,
+// its total. The detail line follows the item line, or precedes it when
+// DetailFirst is set.
+type Pattern struct {
+	Item        *regexp.Regexp
+	Detail      *regexp.Regexp
+	DetailFirst bool
+}
+
+// NewPattern compiles a Pattern. detail may be empty.
+func NewPattern(item, detail string, detailFirst bool) (*Pattern, error) {
+	p := &Pattern{DetailFirst: detailFirst}
+
+	var err error
+	if p.Item, err = regexp.Compile(item); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+	}
+	if p.Item.SubexpIndex("name") < 0 {
+		return nil, fmt.Errorf("%w: the item pattern needs a name group", ErrInvalidFormat)
+	}
+	if detail != "" {
+		if p.Detail, err = regexp.Compile(detail); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidFormat, err)
+		}
+	}
+
+	return p, nil
+}
+
+func (p *Pattern) Match(lines []string) (Line, int) {
+	itemAt, detailAt := 0, 1
+	if p.DetailFirst {
+		itemAt, detailAt = 1, 0
+	}
+
+	if p.Detail != nil && len(lines) > 1 {
+		if line, ok := p.read(lines[itemAt], lines[detailAt]); ok {
+			return line, 2
+		}
+	}
+	if line, ok := p.read(lines[0], ""); ok {
+		return line, 1
+	}
+	return Line{}, 0
+}
+
+// read builds an item from an item line and, unless it is empty, a detail line.
+func (p *Pattern) read(itemLine, detailLine string) (Line, bool) {
+	m := p.Item.FindStringSubmatch(itemLine)
+	if m == nil {
+		return Line{}, false
+	}
+	fields := map[string]string{}
+	collect(p.Item, m, fields)
+
+	text := itemLine
+	if detailLine != "" {
+		d := p.Detail.FindStringSubmatch(detailLine)
+		if d == nil {
+			return Line{}, false
+		}
+		collect(p.Detail, d, fields)
+		if p.DetailFirst {
+			text = detailLine + "\n" + itemLine
+		} else {
+			text = itemLine + "\n" + detailLine
+		}
+	}
+
+	amount, ok := parseAmount(fields["price"])
+	if !ok || amount < 0 {
+		return Line{}, false
+	}
+	name, qty, ok := readName(fields["name"])
+	if !ok {
+		return Line{}, false
+	}
+
+	if weight := fields["weight"]; weight != "" {
+		w, err := parseNumber(weight)
+		if err != nil || w <= 0 {
+			return Line{}, false
+		}
+		unit, ok := weightUnits[strings.ToLower(fields["unit"])]
+		if !ok {
+			unit = quantity.Kilogram
+		}
+		weighed := quantity.New(w, unit)
+		qty = &weighed
+	} else if count := fields["count"]; count != "" {
+		n, err := parseNumber(count)
+		if err != nil || n <= 0 {
+			return Line{}, false
+		}
+		var total quantity.Quantity
+		switch {
+		case n != math.Trunc(n):
+			// Only weighed goods are sold in fractions, by the kilogram.
+			total = quantity.New(n, quantity.Kilogram)
+		case qty != nil:
+			total = qty.Scale(n)
+		default:
+			total = quantity.New(n, quantity.Pieces)
+		}
+		qty = &total
+	}
+
+	return Line{Text: text, Name: name, Quantity: qty, Amount: amount}, true
+}
+
+// readName splits a pack size such as "1.5l" off an item name. The quantity
+// is nil when the name has none.
+func readName(s string) (string, *quantity.Quantity, bool) {
+	parsed, err := itemparser.Parse(s)
+	if err != nil {
+		return "", nil, false
+	}
+	if parsed.Quantity == quantity.One {
+		return parsed.Name, nil, true
+	}
+	return parsed.Name, &parsed.Quantity, true
+}
+
+func collect(re *regexp.Regexp, m []string, fields map[string]string) {
+	for i, name := range re.SubexpNames() {
+		if name != "" && m[i] != "" {
+			fields[name] = m[i]
+		}
+	}
+}
+
+func group(re *regexp.Regexp, m []string, name string) string {
+	if i := re.SubexpIndex(name); i >= 0 {
+		return m[i]
+	}
+	return ""
+}
+
+// parseAmount reads a price such as "1.29", "1,29" or "-0,50" into minor units.
+func parseAmount(s string) (int64, bool) {
+	v, err := parseNumber(s)
+	if err != nil {
+		return 0, false
+	}
+	return int64(math.Round(v * 100)), true
+}
+
+func parseNumber(s string) (float64, error) {
+	return strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(s), ",", "."), 64)
+}
@@ -0,0 +1,112 @@
+package receipt
+
+import (
+	"errors"
+	"github.com/PocketPalCo/shopping-service/pkg/quantity"
+	"reflect"
+	"testing"
+)
+
+func qty(value float64, unit quantity.Unit) *quantity.Quantity {
+	q := quantity.New(value, unit)
+	return &q
+}
+
+func TestGeneric(t *testing.T) {
+	text := `FRESH MARKET
+Main St 1
+
+Milk 1.5l          1.29 A
+Bread              2,49 B
+Apples 3 x 0.50    1.50
+Bananas
+0,842 kg x 1,49 EUR/kg   1,25
+Butter             3.98
+2 x 1.99
+Rabatt            -0.50
+TOTAL              10.01
+VISA               10.01`
+
+	format, err := Lookup(Generic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := format.Parse(text)
+
+	want := []Line{
+		{Text: "Milk 1.5l          1.29 A", Name: "Milk", Quantity: qty(1.5, quantity.Liter), Amount: 129},
+		{Text: "Bread              2,49 B", Name: "Bread", Amount: 249},
+		{Text: "Apples 3 x 0.50    1.50", Name: "Apples", Quantity: qty(3, quantity.Pieces), Amount: 150},
+		{Text: "Bananas\n0,842 kg x 1,49 EUR/kg   1,25", Name: "Bananas", Quantity: qty(0.842, quantity.Kilogram), Amount: 125},
+		{Text: "Butter             3.98\n2 x 1.99", Name: "Butter", Quantity: qty(2, quantity.Pieces), Amount: 398},
+	}
+	if !reflect.DeepEqual(got.Lines, want) {
+		t.Errorf("Parse().Lines = %+v, want %+v", got.Lines, want)
+	}
+
+	wantIgnored := []string{"FRESH MARKET", "Main St 1", "Rabatt            -0.50"}
+	if !reflect.DeepEqual(got.Ignored, wantIgnored) {
+		t.Errorf("Parse().Ignored = %q, want %q", got.Ignored, wantIgnored)
+	}
+	if got.Total == nil || *got.Total != 1001 {
+		t.Errorf("Parse().Total = %v, want 1001", got.Total)
+	}
+}
+
+func TestUkrainianFiscal(t *testing.T) {
+	text := `ТОВ "АТБ-МАРКЕТ"
+2.000 X 38.50
+Молоко 2,5% 0,9л          77.00 А
+Хліб білий                24.90 А
+0.634 X 59.90
+Банани                    37.98 А
+СУМА                     139.88`
+
+	format, err := Lookup(UkrainianFiscal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := format.Parse(text)
+
+	want := []Line{
+		{Text: "2.000 X 38.50\nМолоко 2,5% 0,9л          77.00 А", Name: "Молоко 2,5%", Quantity: qty(1.8, quantity.Liter), Amount: 7700},
+		{Text: "Хліб білий                24.90 А", Name: "Хліб білий", Amount: 2490},
+		{Text: "0.634 X 59.90\nБанани                    37.98 А", Name: "Банани", Quantity: qty(0.634, quantity.Kilogram), Amount: 3798},
+	}
+	if !reflect.DeepEqual(got.Lines, want) {
+		t.Errorf("Parse().Lines = %+v, want %+v", got.Lines, want)
+	}
+	if got.Total == nil || *got.Total != 13988 {
+		t.Errorf("Parse().Total = %v, want 13988", got.Total)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	if err := Register(Format{Name: "empty"}); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("Register() error = %v, want ErrInvalidFormat", err)
+	}
+	if _, err := NewPattern(`^(?P<price>\d+)$`, "", false); !errors.Is(err, ErrInvalidFormat) {
+		t.Errorf("NewPattern() without a name group error = %v, want ErrInvalidFormat", err)
+	}
+	if _, err := Lookup("nope"); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Lookup() error = %v, want ErrUnknownFormat", err)
+	}
+
+	pattern, err := NewPattern(`^(?P<name>\D+);(?P<price>\d+\.\d{2})$`, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Register(Format{Name: "csv", Grammars: []Grammar{pattern}}); err != nil {
+		t.Fatal(err)
+	}
+	format, err := Lookup("csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := format.Parse("milk;1.29\n")
+	want := []Line{{Text: "milk;1.29", Name: "milk", Amount: 129}}
+	if !reflect.DeepEqual(got.Lines, want) {
+		t.Errorf("Parse().Lines = %+v, want %+v", got.Lines, want)
+	}
+}
@@ -0,0 +1,113 @@
+// Package repurchase estimates how often something is bought from when it was
+// bought before, and how likely it is to be needed again at a given time.
+package repurchase
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+const (
+	// Alpha is the weight of the latest interval in the running average, so
+	// that a changed habit shows within a few purchases.
+	Alpha = 0.4
+	// SameTrip merges purchases closer together than this, such as the same
+	// item checked off on two lists during one trip.
+	SameTrip = 12 * time.Hour
+	// Lead is how far into the interval, as a fraction of it, an item starts
+	// coming due.
+	Lead = 0.75
+	// Stale is how many intervals confidence takes to fall to 1/e once an
+	// item is an interval overdue: by then it has probably stopped being
+	// bought.
+	Stale = 2.0
+)
+
+// Habit is what a purchase history says about the next purchase.
+type Habit struct {
+	// Interval is the expected time between purchases.
+	Interval time.Duration
+	// Last is the latest purchase.
+	Last time.Time
+	// Purchases counts the purchases the habit is estimated from.
+	Purchases int
+	// Regularity is 1 for purchases at exactly the same interval and falls
+	// towards 0 the more the intervals vary.
+	Regularity float64
+}
+
+// Estimate estimates the repurchase habit from purchase times in any order.
+// Purchases closer than SameTrip count once. The interval is an exponentially
+// weighted average of the time between purchases, oldest first, and its
+// variability an exponentially weighted mean absolute deviation from the
+// average so far. It reports false without two purchases to go by.
+func Estimate(purchases []time.Time) (Habit, bool) {
+	times := slices.Clone(purchases)
+	slices.SortFunc(times, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	distinct := times[:0]
+	for _, t := range times {
+		if len(distinct) == 0 || t.Sub(distinct[len(distinct)-1]) >= SameTrip {
+			distinct = append(distinct, t)
+		}
+	}
+	if len(distinct) < 2 {
+		return Habit{}, false
+	}
+
+	var average, deviation float64
+	for i := 1; i < len(distinct); i++ {
+		gap := distinct[i].Sub(distinct[i-1]).Seconds()
+		if i == 1 {
+			average = gap
+			continue
+		}
+		deviation = Alpha*math.Abs(gap-average) + (1-Alpha)*deviation
+		average = Alpha*gap + (1-Alpha)*average
+	}
+
+	return Habit{
+		Interval:   time.Duration(average * float64(time.Second)),
+		Last:       distinct[len(distinct)-1],
+		Purchases:  len(distinct),
+		Regularity: 1 / (1 + deviation/average),
+	}, true
+}
+
+// Due is when the next purchase is expected.
+func (h Habit) Due() time.Time {
+	return h.Last.Add(h.Interval)
+}
+
+// Confidence is how sure the habit makes it, from 0 to 1, that the item is
+// needed at now. It is the product of how much history there is, which
+// approaches 1 with the number of intervals seen, how regular the purchases
+// were, and how due the item is: nothing until Lead of the interval has
+// passed, rising to full on the due date and decaying once it is more than
+// one interval overdue.
+func (h Habit) Confidence(now time.Time) float64 {
+	if h.Interval <= 0 {
+		return 0
+	}
+	intervals := float64(h.Purchases - 1)
+	support := intervals / (intervals + 2)
+
+	return support * h.Regularity * timing(float64(now.Sub(h.Last))/float64(h.Interval))
+}
+
+// timing weighs how due an item is at progress intervals since the last
+// purchase.
+func timing(progress float64) float64 {
+	switch {
+	case progress < Lead:
+		return 0
+	case progress < 1:
+		return (progress - Lead) / (1 - Lead)
+	case progress < 2:
+		return 1
+	}
+	return math.Exp(-(progress - 2) / Stale)
+}
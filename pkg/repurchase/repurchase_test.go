@@ -0,0 +1,124 @@
+package repurchase
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+var start = time.Date(2024, 3, 1, 18, 0, 0, 0, time.UTC)
+
+const day = 24 * time.Hour
+
+// history returns purchases at the given days after start.
+func history(days ...float64) []time.Time {
+	times := make([]time.Time, 0, len(days))
+	for _, d := range days {
+		times = append(times, start.Add(time.Duration(d*float64(day))))
+	}
+	return times
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestEstimate(t *testing.T) {
+	cases := []struct {
+		name       string
+		purchases  []time.Time
+		interval   time.Duration
+		last       float64
+		count      int
+		regularity float64
+	}{
+		{"weekly", history(0, 7, 14, 21, 28), 7 * day, 28, 5, 1},
+		{"any order", history(14, 0, 28, 7, 21), 7 * day, 28, 5, 1},
+		{"same trip counts once", history(0, 0.1, 7, 14, 14.2), 7 * day, 14, 3, 1},
+		{"one interval", history(0, 10), 10 * day, 10, 2, 1},
+		// The average moves 40% towards the shorter interval and the
+		// deviation to 40% of the 6 days it differs by.
+		{"changed habit", history(0, 10, 20, 30, 34), time.Duration(7.6 * float64(day)), 34, 5, 7.6 / 10},
+	}
+	for _, tc := range cases {
+		habit, ok := Estimate(tc.purchases)
+		if !ok {
+			t.Errorf("%s: Estimate() reported no habit", tc.name)
+			continue
+		}
+		if habit.Interval != tc.interval {
+			t.Errorf("%s: Interval = %v, want %v", tc.name, habit.Interval, tc.interval)
+		}
+		if want := start.Add(time.Duration(tc.last * float64(day))); !habit.Last.Equal(want) {
+			t.Errorf("%s: Last = %v, want %v", tc.name, habit.Last, want)
+		}
+		if habit.Purchases != tc.count {
+			t.Errorf("%s: Purchases = %d, want %d", tc.name, habit.Purchases, tc.count)
+		}
+		if !near(habit.Regularity, tc.regularity) {
+			t.Errorf("%s: Regularity = %v, want %v", tc.name, habit.Regularity, tc.regularity)
+		}
+	}
+}
+
+func TestEstimateWithoutHistory(t *testing.T) {
+	for _, purchases := range [][]time.Time{nil, history(3), history(3, 3.2)} {
+		if habit, ok := Estimate(purchases); ok {
+			t.Errorf("Estimate(%v) = %+v, want none", purchases, habit)
+		}
+	}
+}
+
+func TestEstimateLeavesInputAlone(t *testing.T) {
+	purchases := history(7, 0)
+	Estimate(purchases)
+	if !purchases[0].Equal(start.Add(7 * day)) {
+		t.Errorf("Estimate() reordered its input: %v", purchases)
+	}
+}
+
+func TestDue(t *testing.T) {
+	habit, _ := Estimate(history(0, 7, 14))
+	if want := start.Add(21 * day); !habit.Due().Equal(want) {
+		t.Errorf("Due() = %v, want %v", habit.Due(), want)
+	}
+}
+
+func TestConfidence(t *testing.T) {
+	// Four weekly intervals: two thirds of the way to full support.
+	weekly, _ := Estimate(history(0, 7, 14, 21, 28))
+	support := 4.0 / 6
+
+	cases := []struct {
+		name  string
+		after float64 // days since the last purchase
+		want  float64
+	}{
+		{"just bought", 0, 0},
+		{"not due yet", 5, 0},
+		{"coming due", 6.125, support * 0.5},
+		{"due", 7, support},
+		{"overdue", 10.5, support},
+		{"long overdue", 28, support * math.Exp(-1)},
+	}
+	for _, tc := range cases {
+		now := weekly.Last.Add(time.Duration(tc.after * float64(day)))
+		if got := weekly.Confidence(now); !near(got, tc.want) {
+			t.Errorf("%s: Confidence() = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+
+	// More history and steadier purchases are both surer.
+	short, _ := Estimate(history(0, 7))
+	irregular, _ := Estimate(history(0, 3, 14, 16, 28))
+	if short.Confidence(short.Due()) >= weekly.Confidence(weekly.Due()) {
+		t.Errorf("one interval is as sure as four")
+	}
+	if irregular.Confidence(irregular.Due()) >= weekly.Confidence(weekly.Due()) {
+		t.Errorf("irregular purchases are as sure as weekly ones")
+	}
+
+	if got := (Habit{}).Confidence(start); got != 0 {
+		t.Errorf("Confidence() without an interval = %v, want 0", got)
+	}
+}
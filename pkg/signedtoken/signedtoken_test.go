@@ -0,0 +1,39 @@
+package signedtoken
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	signer := New([]byte("secret"))
+	id := uuid.New()
+
+	got, err := signer.Verify(signer.Sign(id))
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got != id {
+		t.Errorf("Verify() = %s, want %s", got, id)
+	}
+}
+
+func TestVerifyRejectsForgedTokens(t *testing.T) {
+	signer := New([]byte("secret"))
+	other := New([]byte("other-secret"))
+	token := signer.Sign(uuid.New())
+
+	cases := map[string]string{
+		"other key":    other.Sign(uuid.New()),
+		"swapped id":   signer.Sign(uuid.New())[:22] + token[22:],
+		"no separator": "abc",
+		"bad base64":   "!!!.???",
+		"empty":        "",
+	}
+	for name, tc := range cases {
+		if _, err := signer.Verify(tc); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%s: Verify() error = %v, want ErrInvalidToken", name, err)
+		}
+	}
+}
@@ -94,18 +94,49 @@ func (ip *InstrumentedPool) Begin(ctx context.Context) (pgx.Tx, error) {
 	}, nil
 }
 
+// The transaction methods run on the wrapped pgx.Tx so the statements are part
+// of the transaction; only the timing is shared with the pool.
+
 func (it *InstrumentedTransaction) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
-	return it.pool.Exec(ctx, sql, args...)
+	start := time.Now()
+	tag, err := it.Tx.Exec(ctx, sql, args...)
+	it.pool.record(ctx, sql, "exec", start)
+	return tag, err
 }
 
 func (it *InstrumentedTransaction) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
-	return it.pool.Query(ctx, sql, args...)
+	start := time.Now()
+	rows, err := it.Tx.Query(ctx, sql, args...)
+	it.pool.record(ctx, sql, "query", start)
+	return rows, err
 }
 
 func (it *InstrumentedTransaction) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
-	return it.pool.QueryRow(ctx, sql, args...)
+	start := time.Now()
+	row := it.Tx.QueryRow(ctx, sql, args...)
+	it.pool.record(ctx, sql, "query", start)
+	return row
 }
 
 func (it *InstrumentedTransaction) Begin(ctx context.Context) (pgx.Tx, error) {
-	return it.pool.Begin(ctx)
+	tx, err := it.Tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InstrumentedTransaction{
+		pool: it.pool,
+		Tx:   tx,
+	}, nil
+}
+
+func (ip *InstrumentedPool) record(ctx context.Context, sql, queryType string, start time.Time) {
+	duration := time.Since(start).Milliseconds()
+	ip.queryDuration.Record(
+		ctx,
+		float64(duration),
+		api.WithAttributes(attribute.KeyValue{Key: "sql", Value: attribute.StringValue(sql)}),
+		api.WithAttributes(attribute.Key("db.query_type").String(queryType)),
+		api.WithAttributes(attribute.Key("db.query_duration").Int64(duration)),
+	)
 }